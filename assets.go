@@ -0,0 +1,46 @@
+package markdown
+
+// Asset manifests.
+
+import (
+	"net/url"
+)
+
+// An Asset describes a local resource referenced by a document,
+// either by an image, or by a link.
+type Asset struct {
+	URL   string // as written in the document
+	Path  string // the unescaped path of the URL, without query and fragment
+	Image bool   // whether the asset is referenced by an image, or a video or audio element
+	Line  int    // line number of the top-level block containing the reference
+}
+
+// Assets returns the local resources referenced by the document's
+// images and links, in the order of their occurrence, so that they
+// may be copied along with the rendered document, or checked for
+// existence. A URL refers to a local resource if it has neither a
+// scheme nor a host, and has a non-empty path; links to fragments,
+// like "#intro", and to other sites are not included. Resources
+// referenced multiple times are listed for each reference.
+func (d *Document) Assets() []Asset {
+	var assets []Asset
+	seen := make(map[*Node]bool)
+	for _, tree := range d.blocks {
+		walkContent(tree, seen, func(el *Node) {
+			if el.key != LINK && el.key != IMAGE && el.key != MEDIA && el.key != EMBED {
+				return
+			}
+			u, err := url.Parse(el.contents.link.url)
+			if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+				return
+			}
+			assets = append(assets, Asset{
+				URL:   el.contents.link.url,
+				Path:  u.Path,
+				Image: el.key == IMAGE || el.key == MEDIA,
+				Line:  tree.line,
+			})
+		})
+	}
+	return assets
+}
@@ -0,0 +1,163 @@
+package markdown
+
+// Autolinks like <http://example.org/>.
+
+import (
+	"strings"
+)
+
+/* autoLinkURL - creates the link for an autolink. URLs with a scheme
+ * not contained in AutoLinkSchemes, or rejected by AutoLinkFilter,
+ * are kept as text.
+ */
+func (p *yyParser) autoLinkURL(url string) *Node {
+	x := &p.extension
+	if x.AutoLinkSchemes != nil {
+		scheme := url[:strings.Index(url, ":")]
+		ok := false
+		for _, s := range x.AutoLinkSchemes {
+			if strings.EqualFold(s, scheme) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return p.mkString("<" + url + ">")
+		}
+	}
+	text := url
+	if x.AutoLinkFilter != nil {
+		u, ok := x.AutoLinkFilter(url)
+		if !ok {
+			return p.mkString("<" + url + ">")
+		}
+		url = u
+	}
+	if x.IDNDisplay {
+		text = displayURL(text)
+	}
+	return p.mkLink(p.mkString(text), url, "")
+}
+
+/* displayURL - decodes internationalized domain names
+ * in the host part of a URL
+ */
+func displayURL(url string) string {
+	i := strings.Index(url, "://")
+	if i == -1 {
+		return url
+	}
+	start := i + 3
+	end := len(url)
+	if j := strings.IndexAny(url[start:], "/?#"); j != -1 {
+		end = start + j
+	}
+	host := url[start:end]
+	if at := strings.LastIndexByte(host, '@'); at != -1 {
+		start += at + 1
+		host = host[at+1:]
+	}
+	if colon := strings.LastIndexByte(host, ':'); colon != -1 {
+		host = host[:colon]
+	}
+	labels := strings.Split(host, ".")
+	changed := false
+	for i, l := range labels {
+		if len(l) > 4 && strings.EqualFold(l[:4], "xn--") {
+			if s, ok := decodePunycode(l[4:]); ok {
+				labels[i] = s
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return url
+	}
+	return url[:start] + strings.Join(labels, ".") + url[start+len(host):]
+}
+
+/* decodePunycode - decodes a label encoded according to RFC 3492
+ */
+func decodePunycode(s string) (string, bool) {
+	const (
+		base        = 36
+		tmin        = 1
+		tmax        = 26
+		skew        = 38
+		damp        = 700
+		initialBias = 72
+		initialN    = 128
+	)
+	var out []rune
+	if i := strings.LastIndexByte(s, '-'); i != -1 {
+		for _, r := range s[:i] {
+			if r >= 0x80 {
+				return "", false
+			}
+			out = append(out, r)
+		}
+		s = s[i+1:]
+	}
+	n, bias, i := rune(initialN), initialBias, 0
+	for len(s) > 0 {
+		oldi, w := i, 1
+		for k := base; ; k += base {
+			if len(s) == 0 {
+				return "", false
+			}
+			c := s[0]
+			s = s[1:]
+			var digit int
+			switch {
+			case c >= '0' && c <= '9':
+				digit = int(c-'0') + 26
+			case c >= 'a' && c <= 'z':
+				digit = int(c - 'a')
+			case c >= 'A' && c <= 'Z':
+				digit = int(c - 'A')
+			default:
+				return "", false
+			}
+			i += digit * w
+			if i < 0 || i > 0x10FFFF*(len(out)+1) {
+				return "", false
+			}
+			t := k - bias
+			if t < tmin {
+				t = tmin
+			} else if t > tmax {
+				t = tmax
+			}
+			if digit < t {
+				break
+			}
+			w *= base - t
+		}
+
+		// adapt bias
+		delta := i - oldi
+		if oldi == 0 {
+			delta /= damp
+		} else {
+			delta /= 2
+		}
+		delta += delta / (len(out) + 1)
+		k := 0
+		for delta > ((base-tmin)*tmax)/2 {
+			delta /= base - tmin
+			k += base
+		}
+		bias = k + (base-tmin+1)*delta/(delta+skew)
+
+		n += rune(i / (len(out) + 1))
+		if n > 0x10FFFF || !validCodePoint(n) {
+			return "", false
+		}
+		i %= len(out) + 1
+		out = append(out, 0)
+		copy(out[i+1:], out[i:])
+		out[i] = n
+		i++
+	}
+	return string(out), true
+}
@@ -0,0 +1,84 @@
+package markdown
+
+// Autolinked issue and commit references, like GH-123, #123, or 3f2a9c1.
+
+import (
+	"regexp"
+)
+
+// DefaultAutoRefs matches references commonly used on code hosting
+// platforms: issues like GH-123 or #123, and commit hashes of 7 to
+// 40 hexadecimal digits. It may be assigned to Extensions.AutoRefs.
+var DefaultAutoRefs = regexp.MustCompile(`GH-[0-9]+|#[0-9]+|[0-9a-f]{7,40}`)
+
+func (p *Parser) autoRefsEnabled() bool {
+	x := &p.yy.extension
+	return x.AutoRefs != nil && x.AutoRefURL != nil
+}
+
+/* linkAutoRefs - traverses an element list, replacing references
+ * matching AutoRefs within the text of runs of STR elements
+ * by links. Text inside links, code spans, and raw HTML is not
+ * touched.
+ */
+func (p *Parser) linkAutoRefs(list *Node) {
+	for el := list; el != nil; el = el.next {
+		switch el.key {
+		case LINK, IMAGE, MEDIA, EMBED:
+			continue
+		case STR:
+			last := el
+			text := el.contents.str
+			for last.next != nil && last.next.key == STR {
+				last = last.next
+				text += last.contents.str
+			}
+			if refs := p.autoRefs(text, last.next); refs != nil {
+				*el = *refs
+			}
+			el = last
+			continue
+		}
+		p.linkAutoRefs(el.children)
+	}
+}
+
+/* autoRefs - returns a list of STR and LINK elements replacing text,
+ * followed by next, or nil if text contains no references
+ */
+func (p *Parser) autoRefs(text string, next *Node) *Node {
+	x := &p.yy.extension
+	var list, tail *Node
+	add := func(el *Node) {
+		if tail == nil {
+			list = el
+		} else {
+			tail.next = el
+		}
+		tail = el
+	}
+	pos := 0
+	for _, m := range x.AutoRefs.FindAllStringIndex(text, -1) {
+		if m[0] == m[1] || m[0] > 0 && isAlnum(text[m[0]-1]) || m[1] < len(text) && isAlnum(text[m[1]]) {
+			continue
+		}
+		ref := text[m[0]:m[1]]
+		url, ok := x.AutoRefURL(ref)
+		if !ok {
+			continue
+		}
+		if pos < m[0] {
+			add(p.yy.mkString(text[pos:m[0]]))
+		}
+		add(p.yy.mkLink(p.yy.mkString(ref), url, ""))
+		pos = m[1]
+	}
+	if list == nil {
+		return nil
+	}
+	if pos < len(text) {
+		add(p.yy.mkString(text[pos:]))
+	}
+	tail.next = next
+	return list
+}
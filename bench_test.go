@@ -0,0 +1,90 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Benchmarks, run by `make bench', see misc/devel.mk.
+
+func benchmarkInput(b *testing.B, input string, opt *Extensions) {
+	var buf bytes.Buffer
+	p := NewParser(opt)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	}
+}
+
+func BenchmarkComment(b *testing.B) {
+	benchmarkInput(b, "Thanks, this *works* now. See [the docs](http://example.org/docs) and `go vet`.\n", nil)
+}
+
+func BenchmarkArticle(b *testing.B) {
+	input, err := os.ReadFile(filepath.Join("tests", "md1.0.3", "Markdown Documentation - Syntax.text"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchmarkInput(b, string(input), &Extensions{Smart: true, Notes: true})
+}
+
+func BenchmarkNestedEmphasis(b *testing.B) {
+	benchmarkInput(b, strings.Repeat("*a **b _c ", 200)+"\n", nil)
+}
+
+func BenchmarkHugeList(b *testing.B) {
+	benchmarkInput(b, strings.Repeat("* item with *some* text\n", 5000), nil)
+}
+
+func BenchmarkGiantCodeBlock(b *testing.B) {
+	benchmarkInput(b, strings.Repeat("    for i := 0; i < n; i++ { x <<= 1 }\n", 2000), nil)
+}
+
+func benchmarkSections(b *testing.B, parallel int) {
+	input := strings.Repeat("# Section\n\nSome *text* with a [link](http://example.org/).\n\n"+strings.Repeat("* item with *some* text\n", 50)+"\n", 200)
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	p.SetParallel(parallel)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	}
+}
+
+func BenchmarkSections(b *testing.B) {
+	benchmarkSections(b, 1)
+}
+
+func BenchmarkSectionsParallel(b *testing.B) {
+	benchmarkSections(b, 4)
+}
+
+func BenchmarkNestedLists(b *testing.B) {
+	item := "* item with *some* text\n  continued\n\n    * nested item\n      continued\n\n        > quoted\n        > text\n\n"
+	benchmarkInput(b, strings.Repeat(item, 500), nil)
+}
+
+func BenchmarkLongBlockquote(b *testing.B) {
+	benchmarkInput(b, strings.Repeat("> quoted line with *some* text\n", 5000), nil)
+}
+
+func BenchmarkGlossary(b *testing.B) {
+	var s strings.Builder
+	for i := 0; i < 3000; i++ {
+		fmt.Fprintf(&s, "See [term %d] and [Term %d][].\n\n", i, i)
+	}
+	for i := 0; i < 3000; i++ {
+		fmt.Fprintf(&s, "[term %d]: /glossary#t%d\n", i, i)
+	}
+	benchmarkInput(b, s.String(), nil)
+}
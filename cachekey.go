@@ -0,0 +1,106 @@
+package markdown
+
+// Keys for caches of rendered output.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// DialectVersion is incremented whenever a change to this package
+// alters the output produced for some input and options, so that
+// cached output can be invalidated.
+//
+// Version 2: attribute names of raw HTML tags may contain the
+// characters ':', '@', '.', and '_', as used by frameworks like
+// Vue, so that tags like <div :x="1"> are kept as HTML, instead
+// of being escaped as text.
+const DialectVersion = 2
+
+// CacheKey returns a hash of the input, the extensions and HTML
+// options, and DialectVersion, which may be used as key for a cache
+// of rendered HTML. Options are normalized before, so that, for
+// example, a nil *Extensions and a zero value result in the same
+// key. Fields holding functions, like Text or LinkClass, or file
+// systems, like SVGFS, are not part of the key; if they affect the
+// output, a version of them must be included in the key by the
+// caller.
+func CacheKey(src []byte, ext *Extensions, opt *HTMLOptions) string {
+	var x Extensions
+	if ext != nil {
+		x = *ext
+	}
+	if x.MaxNesting == 0 {
+		x.MaxNesting = DefaultMaxNesting
+	}
+	var o HTMLOptions
+	if opt != nil {
+		o = *opt
+	}
+	if o.PermalinkSymbol == "" {
+		o.PermalinkSymbol = "¶"
+	}
+	if o.FirstNote == 0 {
+		o.FirstNote = 1
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "dialect %d\n", DialectVersion)
+	hashFields(h, reflect.ValueOf(x))
+	hashFields(h, reflect.ValueOf(o))
+	fmt.Fprintf(h, "input %d\n", len(src))
+	h.Write(src)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+/* hashFields - writes the names and values of a struct's fields
+ * to w, in a stable format; functions, interfaces like fs.FS,
+ * and pointers, except those to structs like URLPolicy, are
+ * skipped, as are the functions of structs in slices, like
+ * Embeds, map keys are sorted, and nil slices are told from
+ * empty ones
+ */
+func hashFields(w io.Writer, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Func, reflect.Interface:
+			continue
+		case reflect.Ptr:
+			if f.Type().Elem().Kind() != reflect.Struct {
+				continue
+			}
+			fmt.Fprintf(w, "%s %t\n", t.Field(i).Name, f.IsNil())
+			if !f.IsNil() {
+				hashFields(w, f.Elem())
+			}
+		case reflect.Slice:
+			/* nil and empty lists of allowed tags differ */
+			if f.Type().Elem().Kind() == reflect.Struct {
+				fmt.Fprintf(w, "%s %t %d\n", t.Field(i).Name, f.IsNil(), f.Len())
+				for j := 0; j < f.Len(); j++ {
+					hashFields(w, f.Index(j))
+				}
+				continue
+			}
+			fmt.Fprintf(w, "%s %t %q\n", t.Field(i).Name, f.IsNil(), fmt.Sprint(f))
+		case reflect.Map:
+			keys := f.MapKeys()
+			sort.Slice(keys, func(i, j int) bool {
+				return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+			})
+			fmt.Fprintf(w, "%s {", t.Field(i).Name)
+			for _, k := range keys {
+				fmt.Fprintf(w, "%q:%q ", fmt.Sprint(k), fmt.Sprint(f.MapIndex(k)))
+			}
+			fmt.Fprintln(w, "}")
+		default:
+			fmt.Fprintf(w, "%s %q\n", t.Field(i).Name, fmt.Sprint(f))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Libmarkdown is a C interface to package markdown, so that programs
+not written in Go can embed the converter. It is built as a shared
+library:
+
+	go build -buildmode=c-shared -o libmarkdown.so
+
+A C program includes markdown.h from this directory, and calls
+markdown_render, which returns the HTML in a buffer allocated
+using malloc:
+
+	char *html = markdown_render("# Title\n\nSome *text*.\n", MARKDOWN_SMART);
+	fputs(html, stdout);
+	free(html);
+
+Thread safety: markdown_render creates a new parser for each call,
+so it may be called from several threads at the same time. Loading
+the library starts the Go runtime, which runs its own threads; the
+library should not be loaded into a process more than once.
+*/
+package main
+
+/*
+#include <stdlib.h>
+#define MARKDOWN_IMPL
+#include "markdown.h"
+*/
+import "C"
+
+import (
+	"strings"
+
+	"github.com/knieriem/markdown"
+)
+
+//export markdown_render
+func markdown_render(src *C.char, options C.uint) *C.char {
+	opt := uint(options)
+	has := func(flag C.uint) bool {
+		return opt&uint(flag) != 0
+	}
+	p := markdown.NewParser(&markdown.Extensions{
+		Smart:      has(C.MARKDOWN_SMART),
+		Notes:      has(C.MARKDOWN_NOTES),
+		Strike:     has(C.MARKDOWN_STRIKE),
+		Dlists:     has(C.MARKDOWN_DLISTS),
+		TOC:        has(C.MARKDOWN_TOC),
+		FilterHTML: has(C.MARKDOWN_FILTER_HTML),
+	})
+	var b strings.Builder
+	p.Markdown(strings.NewReader(C.GoString(src)), markdown.ToHTMLOpt(&b, &markdown.HTMLOptions{
+		Permalinks: has(C.MARKDOWN_PERMALINKS),
+		StrictCSP:  has(C.MARKDOWN_STRICT_CSP),
+	}))
+	return C.CString(b.String())
+}
+
+func main() {
+}
@@ -0,0 +1,38 @@
+/*
+ * C interface of package github.com/knieriem/markdown,
+ * see main.go for how to build the library.
+ */
+#ifndef MARKDOWN_H
+#define MARKDOWN_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/* Options, to be or-ed together */
+enum {
+	MARKDOWN_SMART		= 1<<0,	/* smart quotes, dashes, and ellipses */
+	MARKDOWN_NOTES		= 1<<1,	/* footnotes */
+	MARKDOWN_STRIKE		= 1<<2,	/* ~~strike-through~~ */
+	MARKDOWN_DLISTS		= 1<<3,	/* definition lists */
+	MARKDOWN_TOC		= 1<<4,	/* [TOC] placeholder */
+	MARKDOWN_FILTER_HTML	= 1<<5,	/* drop raw HTML */
+	MARKDOWN_PERMALINKS	= 1<<6,	/* permalink anchors in headings */
+	MARKDOWN_STRICT_CSP	= 1<<7,	/* no inline scripts, styles, javascript: URLs */
+};
+
+/*
+ * markdown_render converts the NUL-terminated, UTF-8 encoded
+ * Markdown text src into HTML. The result is allocated using
+ * malloc, and must be released by the caller using free.
+ * It may be called from several threads concurrently.
+ */
+#ifndef MARKDOWN_IMPL	/* cgo declares it differently */
+extern char *markdown_render(const char *src, unsigned int options);
+#endif
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
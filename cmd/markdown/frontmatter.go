@@ -0,0 +1,41 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/knieriem/markdown"
+)
+
+/* frontMatter - returns the settings of the front matter of src,
+ * see markdown.FrontMatter, as extension specification, in the
+ * syntax of markdown.ParseExtensions, and the rest of src. Keys
+ * other than names of extensions, like title, are ignored.
+ */
+func frontMatter(src string) (spec, rest string) {
+	meta, rest := markdown.FrontMatter(src)
+	keys := make([]string, 0, len(meta))
+	for key := range meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var list []string
+	for _, key := range keys {
+		value := meta[key]
+		if b, err := strconv.ParseBool(value); err == nil || value == "yes" || value == "no" {
+			b = b || value == "yes"
+			if _, err := markdown.ParseExtensions(key); err == nil {
+				if !b {
+					key = "-" + key
+				}
+				list = append(list, key)
+			}
+		} else if _, err := strconv.Atoi(value); err == nil {
+			if _, err := markdown.ParseExtensions(key + "=" + value); err == nil {
+				list = append(list, key+"="+value)
+			}
+		}
+	}
+	return strings.Join(list, ","), rest
+}
@@ -1,51 +1,266 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"github.com/knieriem/markdown"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
-var format = flag.String("t", "html", "output format")
+var format = flag.String("t", "html", "output format: html, groff-mm, or markdown")
+var permalinks = flag.Bool("permalinks", false, "insert permalink anchors into headings (html)")
+var listValues = flag.Bool("listvalues", false, "preserve the numbers of ordered list items (html)")
+var strictCSP = flag.Bool("strictcsp", false, "emit no inline scripts, styles, or javascript: URLs (html)")
+var safeURLs = flag.Bool("safeurls", false, "reduce links and images with data: or protocol-relative URLs to their text (html)")
+var width = flag.Int("width", 0, "fill paragraphs into lines of at most `n` characters (groff-mm, markdown)")
+var quiet = flag.Bool("q", false, "do not report diagnostics")
+var strict = flag.Bool("strict", false, "exit with status 1 if there are diagnostics")
+var verbose = flag.Bool("verbose", false, "report the number of diagnostics")
+var diagFormat = flag.String("diagnostics", "text", "format of diagnostics written to stderr, text or json")
+var outFile = flag.String("o", "", "write the output to `file` instead of stdout")
+var outExt = flag.String("ext", "", "write the output for each input file to a file with the input's name, and `extension`, like .html")
+var useFrontMatter = flag.Bool("frontmatter", false, "read extension settings, like smart: true, from a document's front matter")
+var useMmap = flag.Bool("mmap", false, "map input files into memory instead of reading them, which needs less memory for large files")
+var htmlDefaults markdown.HTMLOptions // set by -dialect
+var stdoutOnError = flag.Bool("stdout-on-error", false, "if an output file cannot be written, write the output to stdout")
 
 func main() {
 	var opt markdown.Extensions
 	flag.BoolVar(&opt.Notes, "notes", false, "turn on footnote syntax")
 	flag.BoolVar(&opt.Smart, "smart", false, "turn on smart quotes, dashes, and ellipses")
+	flag.BoolVar(&opt.Primes, "primes", false, "with -smart, turn 5'10\" into primes")
+	flag.BoolVar(&opt.Arrows, "arrows", false, "with -smart, turn -> and <=> into arrows")
+	flag.BoolVar(&opt.Symbols, "symbols", false, "with -smart, turn (c), (r), (tm) into symbols")
+	flag.BoolVar(&opt.Fractions, "fractions", false, "with -smart, turn 1/2 into fraction glyphs")
 	flag.BoolVar(&opt.Strike, "strike", false, "turn on strike-through syntax")
 	flag.BoolVar(&opt.Dlists, "dlists", false, "support definitions lists")
+	flag.BoolVar(&opt.TOC, "toc", false, "replace a [TOC] paragraph by a table of contents")
+	flag.BoolVar(&opt.FancyLists, "fancylists", false, "support enumerators like a., iv), or (B) in ordered lists")
+	flag.BoolVar(&opt.GridTables, "gridtables", false, "support tables framed by +---+ borders")
+	flag.BoolVar(&opt.LaxSublists, "laxsublists", false, "allow sublists to be indented by two or three spaces")
+	flag.IntVar(&opt.CodeTabs, "codetabs", 0, "tab width inside code blocks, -1 keeps tabs")
+	flag.BoolVar(&opt.NoIntraEmphasis, "nointraemphasis", false, "do not emphasize within words, like snake*case*words")
+	flag.BoolVar(&opt.Citations, "citations", false, "turn a blockquote's final \"-- \" line into a citation")
+	flag.Var(&opt, "x", "extensions to turn on or off, like smart,notes,-strike")
+	flag.Func("dialect", "start from the extensions and html options of dialect `name`, one of "+
+		strings.Join(markdown.Dialects(), ", ")+"; options following it modify them", func(name string) error {
+		d, ok := markdown.LookupDialect(name)
+		if !ok {
+			return fmt.Errorf("unknown dialect %q", name)
+		}
+		opt = d.Extensions
+		htmlDefaults = d.HTML
+		return nil
+	})
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [FILE]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] [FILE ...]\n", os.Args[0])
 		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr, "\nExit status is 2 in case of errors, 1 if there are diagnostics\nand -strict is set, and 0 otherwise.")
 	}
 	flag.Parse()
+	switch *diagFormat {
+	case "text", "json":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown diagnostics format %q\n", *diagFormat)
+		flag.Usage()
+		os.Exit(2)
+	}
+	log.SetFlags(0)
+	log.SetPrefix("markdown: ")
+
+	switch {
+	case *outFile != "" && *outExt != "":
+		log.Print("-o and -ext are mutually exclusive")
+		os.Exit(2)
+	case *outFile != "" && flag.NArg() > 1:
+		log.Print("-o requires a single input file")
+		os.Exit(2)
+	case *outExt != "" && flag.NArg() == 0:
+		log.Print("-ext requires input files")
+		os.Exit(2)
+	}
+
+	startPProf()
 
-	r := os.Stdin
-	if flag.NArg() > 0 {
-		f, err := os.Open(flag.Arg(0))
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{""}
+	}
+	status := 0
+	var diags []fileDiag
+	for _, file := range files {
+		d, err := convert(opt, file)
 		if err != nil {
-			log.Fatal(err)
+			log.Print(err)
+			status = 2
 		}
-		defer f.Close()
-		r = f
+		diags = append(diags, d...)
 	}
+	stopPProf()
 
-	p := markdown.NewParser(&opt)
+	if !*quiet {
+		reportDiagnostics(diags)
+	}
+	if *verbose {
+		fmt.Fprintf(os.Stderr, "%d problem(s) found in %d file(s)\n", len(diags), len(files))
+	}
+	if status == 0 && *strict && len(diags) != 0 {
+		status = 1
+	}
+	os.Exit(status)
+}
 
-	startPProf()
-	defer stopPProf()
+// A diagnostic, and the file it refers to.
+type fileDiag struct {
+	file string
+	markdown.Diagnostic
+}
 
-	w := bufio.NewWriter(os.Stdout)
+/* convert - converts a file, or stdin, if file is empty, writing
+ * the result to the destination selected by -o or -ext, or stdout
+ */
+func convert(opt markdown.Extensions, file string) ([]fileDiag, error) {
+	var r io.Reader = os.Stdin
+	var data []byte
+	name := "<stdin>"
+	if file != "" {
+		name = file
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+		if *useMmap && !*useFrontMatter {
+			d, unmap, err := mapFile(f)
+			if err != nil {
+				return nil, err
+			}
+			defer unmap()
+			data = d
+		}
+	}
+	if *useFrontMatter {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		spec, src := frontMatter(string(b))
+		if err := opt.Set(spec); err != nil {
+			return nil, fmt.Errorf("%s: front matter: %v", name, err)
+		}
+		r = strings.NewReader(src)
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	p := markdown.NewParser(&opt)
 
+	var buf bytes.Buffer
+	var f markdown.Formatter
 	switch *format {
 	case "groff-mm":
-		p.Markdown(r, markdown.ToGroffMM(w))
+		f = markdown.ToGroffMMOpt(&buf, &markdown.GroffOptions{Width: *width})
+	case "markdown":
+		f = markdown.ToMarkdownOpt(&buf, &markdown.MarkdownOptions{Width: *width})
 	default:
-		p.Markdown(r, markdown.ToHTML(w))
+		hopt := htmlDefaults
+		hopt.Permalinks = hopt.Permalinks || *permalinks
+		hopt.ListValues = hopt.ListValues || *listValues
+		hopt.StrictCSP = hopt.StrictCSP || *strictCSP
+		if *safeURLs && hopt.URLs == nil {
+			hopt.URLs = new(markdown.URLPolicy)
+		}
+		f = markdown.ToHTMLOpt(&buf, &hopt)
+	}
+	if data != nil {
+		p.MarkdownBytes(data, f)
+	} else {
+		p.Markdown(r, f)
+	}
+	var diags []fileDiag
+	for _, d := range p.Diagnostics() {
+		diags = append(diags, fileDiag{name, d})
+	}
+
+	dest := *outFile
+	if *outExt != "" {
+		dest = strings.TrimSuffix(file, filepath.Ext(file)) + *outExt
+	}
+	if dest == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return diags, err
+	}
+	err := writeFile(dest, buf.Bytes())
+	if err != nil {
+		err = fmt.Errorf("cannot write %s: %v", dest, err)
+		if *stdoutOnError {
+			os.Stdout.Write(buf.Bytes())
+		}
+	}
+	return diags, err
+}
+
+/* writeFile - writes data to a temporary file, which replaces
+ * the named file once it is complete, so that no partial output
+ * is left behind. The permissions of an existing file are kept.
+ */
+func writeFile(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), ".markdown-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		mode := os.FileMode(0644)
+		if fi, err := os.Stat(name); err == nil {
+			mode = fi.Mode().Perm()
+		}
+		err = os.Chmod(f.Name(), mode)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+/* reportDiagnostics - writes diagnostics to stderr, either
+ * one per line, like "file:line: message [code]", or as
+ * a JSON array
+ */
+func reportDiagnostics(diags []fileDiag) {
+	if *diagFormat == "text" {
+		for _, d := range diags {
+			fmt.Fprintf(os.Stderr, "%s:%d: %s [%s]\n", d.file, d.Line, d.Msg, d.Code)
+		}
+		return
+	}
+	type jsonDiag struct {
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+		Code     string `json:"code"`
+		Message  string `json:"message"`
+		Severity string `json:"severity"`
+	}
+	list := []jsonDiag{}
+	for _, d := range diags {
+		list = append(list, jsonDiag{d.file, d.Line, d.Code, d.Msg, "warning"})
 	}
-	w.Flush()
+	enc := json.NewEncoder(os.Stderr)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	enc.Encode(list)
 }
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package main
+
+import (
+	"io"
+	"os"
+)
+
+/* mapFile - reads the contents of f, as memory mapping
+ * is not supported on this platform
+ */
+func mapFile(f *os.File) (data []byte, unmap func(), err error) {
+	data, err = io.ReadAll(f)
+	return data, func() {}, err
+}
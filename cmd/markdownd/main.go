@@ -0,0 +1,173 @@
+/*
+Markdownd is an HTTP server rendering Markdown to HTML, so that
+applications written in different languages can share one
+converter, with consistent output.
+
+Usage:
+
+	markdownd [-addr :8080] [-maxsize 1048576]
+
+Endpoints:
+
+	POST /render
+		Converts the request body, and returns HTML. Options may be
+		given as query parameters, like /render?smart=1&notes=1.
+		Alternatively, a body of type application/json may contain
+		an object {"markdown": "...", "options": {"smart": true}}.
+		Options are smart, notes, strike, dlists, toc, fancylists,
+		laxsublists, filterhtml, permalinks, and strictcsp.
+
+	GET /healthz
+		Returns "ok".
+
+	GET /metrics
+		Returns counters in the Prometheus text format.
+*/
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/knieriem/markdown"
+)
+
+var addr = flag.String("addr", ":8080", "address to listen on")
+var maxSize = flag.Int64("maxsize", 1<<20, "maximum size of a request body")
+
+// Counters reported by /metrics.
+var metrics struct {
+	requests int64
+	errors   int64
+	bytesIn  int64
+	bytesOut int64
+	renderNs int64
+}
+
+type options struct {
+	ext  markdown.Extensions
+	html markdown.HTMLOptions
+}
+
+/* flags - returns pointers to the options, by name
+ */
+func (o *options) flags() map[string]*bool {
+	return map[string]*bool{
+		"smart":       &o.ext.Smart,
+		"notes":       &o.ext.Notes,
+		"strike":      &o.ext.Strike,
+		"dlists":      &o.ext.Dlists,
+		"toc":         &o.ext.TOC,
+		"fancylists":  &o.ext.FancyLists,
+		"laxsublists": &o.ext.LaxSublists,
+		"filterhtml":  &o.ext.FilterHTML,
+		"permalinks":  &o.html.Permalinks,
+		"strictcsp":   &o.html.StrictCSP,
+	}
+}
+
+/* set - sets an option from a string like "1" or "true"
+ */
+func (o *options) set(name, value string) error {
+	p, ok := o.flags()[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown option %q", name)
+	}
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("option %s: %v", name, err)
+	}
+	*p = v
+	return nil
+}
+
+func main() {
+	flag.Parse()
+
+	http.HandleFunc("/render", handleRender)
+	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok\n")
+	})
+	http.HandleFunc("/metrics", handleMetrics)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func handleRender(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt64(&metrics.requests, 1)
+	src, opt, status, err := readRequest(w, r)
+	if err != nil {
+		atomic.AddInt64(&metrics.errors, 1)
+		http.Error(w, err.Error(), status)
+		return
+	}
+	atomic.AddInt64(&metrics.bytesIn, int64(len(src)))
+
+	start := time.Now()
+	var b strings.Builder
+	p := markdown.NewParser(&opt.ext)
+	p.Markdown(strings.NewReader(src), markdown.ToHTMLOpt(&b, &opt.html))
+	atomic.AddInt64(&metrics.renderNs, int64(time.Since(start)))
+	atomic.AddInt64(&metrics.bytesOut, int64(b.Len()))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	io.WriteString(w, b.String())
+}
+
+/* readRequest - returns the Markdown source and the options
+ * of a render request, or an error and the HTTP status to
+ * be returned
+ */
+func readRequest(w http.ResponseWriter, r *http.Request) (src string, opt options, status int, err error) {
+	if r.Method != http.MethodPost {
+		return "", opt, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method)
+	}
+	for name, values := range r.URL.Query() {
+		if err = opt.set(name, values[len(values)-1]); err != nil {
+			return "", opt, http.StatusBadRequest, err
+		}
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, *maxSize))
+	if err != nil {
+		return "", opt, http.StatusRequestEntityTooLarge, err
+	}
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return string(body), opt, 0, nil
+	}
+	var req struct {
+		Markdown string
+		Options  map[string]bool
+	}
+	if err = json.Unmarshal(body, &req); err != nil {
+		return "", opt, http.StatusBadRequest, err
+	}
+	for name, v := range req.Options {
+		if err = opt.set(name, strconv.FormatBool(v)); err != nil {
+			return "", opt, http.StatusBadRequest, err
+		}
+	}
+	return req.Markdown, opt, 0, nil
+}
+
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	for _, m := range []struct {
+		name, help string
+		value      float64
+	}{
+		{"markdownd_requests_total", "Number of render requests.", float64(atomic.LoadInt64(&metrics.requests))},
+		{"markdownd_errors_total", "Number of render requests failed.", float64(atomic.LoadInt64(&metrics.errors))},
+		{"markdownd_input_bytes_total", "Size of Markdown input rendered.", float64(atomic.LoadInt64(&metrics.bytesIn))},
+		{"markdownd_output_bytes_total", "Size of HTML output.", float64(atomic.LoadInt64(&metrics.bytesOut))},
+		{"markdownd_render_seconds_total", "Time spent rendering.", float64(atomic.LoadInt64(&metrics.renderNs)) / 1e9},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %g\n", m.name, m.help, m.name, m.name, m.value)
+	}
+}
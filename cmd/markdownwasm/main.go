@@ -0,0 +1,67 @@
+//go:build js && wasm
+
+/*
+Markdownwasm makes package markdown available to JavaScript in
+a browser, for example for a preview while editing. It is built
+as a WebAssembly module:
+
+	GOOS=js GOARCH=wasm go build -o markdown.wasm
+
+After loading the module using wasm_exec.js from the Go
+distribution, a function is available as markdown.render:
+
+	html = markdown.render(src, {smart: true, notes: true})
+
+The optional second argument may contain the following boolean
+options, which default to false: smart, notes, strike, dlists,
+toc, fancyLists, laxSublists, filterHTML, permalinks, and
+strictCSP.
+*/
+package main
+
+import (
+	"strings"
+	"syscall/js"
+
+	"github.com/knieriem/markdown"
+)
+
+func main() {
+	js.Global().Set("markdown", map[string]interface{}{
+		"render": js.FuncOf(render),
+	})
+	select {}
+}
+
+func render(this js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return ""
+	}
+	opts := js.Undefined()
+	if len(args) > 1 {
+		opts = args[1]
+	}
+	flag := func(name string) bool {
+		if opts.Type() != js.TypeObject {
+			return false
+		}
+		return opts.Get(name).Truthy()
+	}
+
+	p := markdown.NewParser(&markdown.Extensions{
+		Smart:       flag("smart"),
+		Notes:       flag("notes"),
+		Strike:      flag("strike"),
+		Dlists:      flag("dlists"),
+		TOC:         flag("toc"),
+		FancyLists:  flag("fancyLists"),
+		LaxSublists: flag("laxSublists"),
+		FilterHTML:  flag("filterHTML"),
+	})
+	var b strings.Builder
+	p.Markdown(strings.NewReader(args[0].String()), markdown.ToHTMLOpt(&b, &markdown.HTMLOptions{
+		Permalinks: flag("permalinks"),
+		StrictCSP:  flag("strictCSP"),
+	}))
+	return b.String()
+}
@@ -0,0 +1,163 @@
+/*
+Mddiff renders Markdown files using package markdown, and using
+a reference implementation, like peg-markdown or cmark, and
+reports where the outputs differ. Before comparing, white space
+between HTML tags is removed, and other white space collapsed,
+so that only differences in the dialect remain.
+
+Usage:
+
+	mddiff [-ref COMMAND] [-smart] [-notes] FILE|DIR ...
+
+For directories, files ending in .text or .md are compared.
+The reference command, if not given, is the first of peg-markdown
+and cmark found in $PATH; it is run for each file, reading the
+Markdown input from its standard input. The exit status is 1 if
+any differences have been found.
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/knieriem/markdown"
+	"github.com/knieriem/markdown/markdowntest"
+)
+
+var refCmd = flag.String("ref", "", "reference command, with arguments")
+var context = flag.Int("c", 2, "number of context lines shown around a difference")
+
+var refCandidates = []string{"peg-markdown", "cmark"}
+
+func main() {
+	var opt markdown.Extensions
+	flag.BoolVar(&opt.Smart, "smart", false, "turn on smart quotes, dashes, and ellipses")
+	flag.BoolVar(&opt.Notes, "notes", false, "turn on footnote syntax")
+
+	log.SetFlags(0)
+	log.SetPrefix("mddiff: ")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] FILE|DIR ...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ref := strings.Fields(*refCmd)
+	if len(ref) == 0 {
+		for _, name := range refCandidates {
+			if _, err := exec.LookPath(name); err == nil {
+				ref = []string{name}
+				break
+			}
+		}
+		if ref == nil {
+			log.Fatalf("no reference implementation found, tried %s; use -ref", strings.Join(refCandidates, ", "))
+		}
+	}
+
+	var files []string
+	for _, arg := range flag.Args() {
+		files = append(files, expand(arg)...)
+	}
+
+	p := markdown.NewParser(&opt)
+	ndiff := 0
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var buf bytes.Buffer
+		p.Markdown(bytes.NewReader(src), markdown.ToHTML(&buf))
+
+		cmd := exec.Command(ref[0], ref[1:]...)
+		cmd.Stdin = bytes.NewReader(src)
+		cmd.Stderr = os.Stderr
+		want, err := cmd.Output()
+		if err != nil {
+			log.Fatalf("%s: %s: %v", file, ref[0], err)
+		}
+		if report(file, normalize(want), normalize(buf.Bytes())) {
+			ndiff++
+		}
+	}
+	fmt.Printf("%d of %d files differ\n", ndiff, len(files))
+	if ndiff != 0 {
+		os.Exit(1)
+	}
+}
+
+/* expand - returns the Markdown files contained in a
+ * directory, or the argument itself, if it is a file
+ */
+func expand(arg string) []string {
+	fi, err := os.Stat(arg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !fi.IsDir() {
+		return []string{arg}
+	}
+	var files []string
+	for _, pat := range []string{"*.text", "*.md"} {
+		m, _ := filepath.Glob(filepath.Join(arg, pat))
+		files = append(files, m...)
+	}
+	return files
+}
+
+/* normalize - normalizes HTML, and splits it into lines,
+ * one per tag
+ */
+func normalize(b []byte) []string {
+	s := string(markdowntest.NormalizeHTML(b))
+	return strings.SplitAfter(strings.Replace(s, "><", ">\n<", -1), "\n")
+}
+
+/* report - prints the first difference between the lines of the
+ * reference output and our output, together with some context.
+ * It returns false if there are no differences.
+ */
+func report(file string, want, got []string) bool {
+	i := 0
+	for i < len(want) && i < len(got) && want[i] == got[i] {
+		i++
+	}
+	if i == len(want) && i == len(got) {
+		return false
+	}
+	fmt.Printf("%s: output differs at line %d of normalized HTML\n", file, i+1)
+	show := func(prefix string, lines []string) {
+		start := i - *context
+		if start < 0 {
+			start = 0
+		}
+		end := i + *context + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+		for j := start; j < end; j++ {
+			mark := " "
+			if j == i {
+				mark = prefix
+			}
+			fmt.Printf("%s %s\n", mark, strings.TrimSuffix(lines[j], "\n"))
+		}
+	}
+	fmt.Println("reference:")
+	show("-", want)
+	fmt.Println("markdown:")
+	show("+", got)
+	return true
+}
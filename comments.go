@@ -0,0 +1,20 @@
+package markdown
+
+// Comment lines, starting with %% or //.
+
+import (
+	"strings"
+)
+
+/* mkComment - creates a COMMENT element from a reversed list
+ * of STR elements, one for each line, without the comment marker
+ */
+func (p *yyParser) mkComment(list *Node) *Node {
+	var lines []string
+	for list = reverse(list); list != nil; list = list.next {
+		lines = append(lines, list.contents.str)
+	}
+	el := p.mkElem(COMMENT)
+	el.contents.str = strings.Join(lines, "\n")
+	return el
+}
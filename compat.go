@@ -0,0 +1,53 @@
+package markdown
+
+// The document API of early versions of this package, kept
+// as a layer over Parser and Document, so that programs
+// written against it still compile.
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Doc is the name of Document in early versions of this package.
+//
+// Deprecated: Use Document.
+type Doc = Document
+
+// Parse parses text into a Document, using a Parser
+// created for the given extensions.
+//
+// Deprecated: Use NewParser and Parser.Parse, which allow
+// to reuse the parser, and to retrieve diagnostics.
+func Parse(text string, x Extensions) *Document {
+	return NewParser(&x).Parse(strings.NewReader(text))
+}
+
+// WriteHtml writes the document in HTML format to w.
+//
+// Deprecated: Use Render with a Formatter returned by ToHTML.
+func (d *Document) WriteHtml(w io.Writer) error {
+	return d.write(w, ToHTML)
+}
+
+// WriteGroffMM writes the document in groff mm format to w.
+//
+// Deprecated: Use Render with a Formatter returned by ToGroffMM.
+func (d *Document) WriteGroffMM(w io.Writer) error {
+	return d.write(w, ToGroffMM)
+}
+
+/* write - renders the document using a formatter returned
+ * by fn, buffering the output if w is not a Writer
+ */
+func (d *Document) write(w io.Writer, fn func(Writer) Formatter) error {
+	if mw, ok := w.(Writer); ok {
+		return d.Render(fn(mw))
+	}
+	b := bufio.NewWriter(w)
+	if err := d.Render(fn(b)); err != nil {
+		return err
+	}
+	return b.Flush()
+}
@@ -0,0 +1,114 @@
+package markdown
+
+// Containers, fenced blocks like
+//	::: warning {#id .class key="value"}
+//	...
+//	:::
+
+import (
+	"strings"
+)
+
+// An Attr is an attribute of a container, like {#id}, {.class},
+// or {key=value}. Ids are represented by the key "id", classes
+// by the key "class".
+type Attr struct {
+	Key, Value string
+}
+
+// Key of the attributes attached to a node.
+type attrsKey struct{}
+
+// Attrs returns the attributes of a CONTAINER node, in the order
+// they have been written. The container's name, as returned by
+// Text, is not included.
+func (n *Node) Attrs() []Attr {
+	a, _ := n.Data(attrsKey{}).([]Attr)
+	return a
+}
+
+/* mkContainer - creates a CONTAINER element from the text of a
+ * container, starting with the opening fence, and not including
+ * the closing one. The contents are parsed later, as RAW text.
+ */
+func (p *yyParser) mkContainer(s string) *Node {
+	line := s
+	if i := strings.IndexByte(s, '\n'); i != -1 {
+		line, s = s[:i], s[i+1:]
+	}
+	line = strings.TrimSpace(strings.TrimLeft(line, ":"))
+	name, attrs := line, ""
+	if i := strings.IndexAny(line, " \t{"); i != -1 {
+		name, attrs = line[:i], strings.TrimSpace(line[i:])
+	}
+	el := p.mkElem(CONTAINER)
+	el.contents.str = name
+	if a := parseAttrs(strings.TrimSuffix(strings.TrimPrefix(attrs, "{"), "}")); a != nil {
+		el.SetData(attrsKey{}, a)
+	}
+	raw := p.mkString(s + "\n")
+	raw.key = RAW
+	el.children = raw
+	return el
+}
+
+/* parseAttrs - parses attributes like `#id .class key=value key="a value"`,
+ * separated by white space. Classes are collected into a single
+ * attribute. Malformed attributes are ignored.
+ */
+func parseAttrs(s string) (attrs []Attr) {
+	class := -1
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			return
+		}
+		n := strings.IndexAny(s, " \t")
+		if n == -1 {
+			n = len(s)
+		}
+		switch s[0] {
+		case '#':
+			if n > 1 {
+				attrs = append(attrs, Attr{"id", s[1:n]})
+			}
+		case '.':
+			if n == 1 {
+				break
+			}
+			if class == -1 {
+				class = len(attrs)
+				attrs = append(attrs, Attr{"class", s[1:n]})
+			} else {
+				attrs[class].Value += " " + s[1:n]
+			}
+		default:
+			i := strings.IndexByte(s[:n], '=')
+			if i <= 0 || !isAttrKey(s[:i]) {
+				break
+			}
+			key := s[:i]
+			if s[i+1:i+2] == `"` {
+				if j := strings.IndexByte(s[i+2:], '"'); j != -1 {
+					attrs = append(attrs, Attr{key, s[i+2 : i+2+j]})
+					s = s[i+3+j:]
+					continue
+				}
+			}
+			attrs = append(attrs, Attr{key, s[i+1 : n]})
+		}
+		s = s[n:]
+	}
+}
+
+/* isAttrKey - returns true if s consists of letters, digits,
+ * dashes, and underscores only
+ */
+func isAttrKey(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !isAlnum(c) && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
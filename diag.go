@@ -0,0 +1,18 @@
+package markdown
+
+// Diagnostics.
+
+import (
+	"fmt"
+)
+
+// A Diagnostic reports a problem found in a document.
+type Diagnostic struct {
+	Line int    // line number of the top-level block containing the problem
+	Code string // short identifier of the kind of problem, like "heading-jump"
+	Msg  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d: %s [%s]", d.Line, d.Msg, d.Code)
+}
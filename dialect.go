@@ -0,0 +1,96 @@
+package markdown
+
+// Named sets of extensions and output options.
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A Dialect bundles the Extensions of a Markdown flavor with
+// defaults for the HTML writer, so that applications may offer
+// users a choice of flavors by name, without setting up dozens
+// of fields. Dialects are registered using RegisterDialect;
+// "original", "gfm-like", and "pandoc-like" are predefined.
+type Dialect struct {
+	Name       string
+	Extensions Extensions
+	HTML       HTMLOptions
+}
+
+var dialects = map[string]*Dialect{
+	"original": {
+		Name: "original",
+	},
+	"gfm-like": {
+		Name: "gfm-like",
+		Extensions: Extensions{
+			Strike:          true,
+			LaxSublists:     true,
+			AllEscapes:      true,
+			NoIntraEmphasis: true,
+			TOC:             true,
+		},
+		HTML: HTMLOptions{
+			Permalinks: true,
+		},
+	},
+	"pandoc-like": {
+		Name: "pandoc-like",
+		Extensions: Extensions{
+			Smart:           true,
+			Notes:           true,
+			Strike:          true,
+			Dlists:          true,
+			FancyLists:      true,
+			GridTables:      true,
+			Containers:      true,
+			AllEscapes:      true,
+			NoIntraEmphasis: true,
+		},
+		HTML: HTMLOptions{
+			ListValues: true,
+		},
+	},
+}
+
+// RegisterDialect makes d available by its name. It panics
+// if the name is empty, or already in use.
+func RegisterDialect(d *Dialect) {
+	if d.Name == "" {
+		panic("markdown: RegisterDialect: empty name")
+	}
+	if _, ok := dialects[d.Name]; ok {
+		panic(fmt.Sprintf("markdown: RegisterDialect: dialect %q registered twice", d.Name))
+	}
+	dialects[d.Name] = d
+}
+
+// LookupDialect returns the dialect registered under name.
+func LookupDialect(name string) (d *Dialect, ok bool) {
+	d, ok = dialects[name]
+	return
+}
+
+// Dialects returns the names of the registered dialects, sorted.
+func Dialects() []string {
+	names := make([]string, 0, len(dialects))
+	for name := range dialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewParser returns a parser using the dialect's extensions.
+func (d *Dialect) NewParser() *Parser {
+	x := d.Extensions
+	return NewParser(&x)
+}
+
+// ToHTML returns a formatter writing HTML using the dialect's
+// options; see ToHTMLOpt.
+func (d *Dialect) ToHTML(w Writer) Formatter {
+	opt := d.HTML
+	return ToHTMLOpt(w, &opt)
+}
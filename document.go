@@ -0,0 +1,240 @@
+package markdown
+
+// Parsed documents.
+
+import (
+	"io"
+)
+
+// A Document is the result of parsing Markdown input
+// into a tree, which may be rendered any number of times.
+type Document struct {
+	blocks []*Node
+	refs   []*link /* reference definitions, see References */
+
+	slugify func(string) string /* the parser's Extensions.Slugify */
+}
+
+// Parse parses input from an io.Reader into a Document. Unlike
+// nodes passed to a Formatter by Markdown, the nodes of the
+// Document remain valid after later uses of the Parser; they
+// are allocated in large rows, together with nodes no longer
+// in use, though, see Detach. Diagnostics are available as
+// with Markdown.
+func (p *Parser) Parse(src io.Reader) *Document {
+	return p.parseDocument(p.preformat(src))
+}
+
+// ParseBytes is like Parse, but parses data, see MarkdownBytes.
+func (p *Parser) ParseBytes(data []byte) *Document {
+	return p.parseDocument(p.preformatBytes(data))
+}
+
+func (p *Parser) parseDocument(s string) *Document {
+	d := &Document{slugify: p.yy.extension.Slugify}
+
+	/* The nodes are allocated from a heap of their own, so that
+	 * the parser's heap may be reused by later calls.
+	 */
+	heap := p.yy.state.heap
+	p.yy.state.heap = elemHeap{}
+	p.yy.state.heap.init(1024)
+	p.parse(s, func(tree *Node) {
+		d.blocks = append(d.blocks, tree)
+	}, true)
+	p.yy.state.heap = heap
+
+	for ref := p.yy.references; ref != nil; ref = ref.next {
+		d.refs = append(d.refs, ref.contents.link)
+	}
+	for _, tree := range d.blocks {
+		walkElements(tree, d.linkDefinition)
+	}
+	return d
+}
+
+/* linkDefinition - points a REFERENCE block, the source of
+ * a definition, to the definition in effect for its label,
+ * unless the block is a duplicate that has been dropped, so
+ * that rewriting the definition changes the block as well
+ */
+func (d *Document) linkDefinition(el *Node) {
+	if el.key != REFERENCE {
+		return
+	}
+	l := el.contents.link
+	for _, ref := range d.refs {
+		if sameLabel(l.label, ref.label) {
+			if l.url == ref.url && l.title == ref.title {
+				l.ref = ref
+			}
+			return
+		}
+	}
+}
+
+// Blocks returns the top-level blocks of the document.
+func (d *Document) Blocks() []*Node {
+	return d.blocks
+}
+
+// Title returns the plain text of the document's first top-level
+// H1 heading, or an empty string, if there is none. See TitleFrom
+// for documents with front matter.
+func (d *Document) Title() string {
+	for _, tree := range d.blocks {
+		for b := tree; b != nil; b = b.next {
+			if b.key == H1 {
+				return inlineText(b.children)
+			}
+		}
+	}
+	return ""
+}
+
+// TitleFrom returns the title found in the front matter of the
+// document, as returned by FrontMatter, if it is not empty, and
+// the result of Title otherwise.
+func (d *Document) TitleFrom(meta map[string]string) string {
+	if t := meta["title"]; t != "" {
+		return t
+	}
+	return d.Title()
+}
+
+// Render sends the blocks of the document to a Formatter,
+// like Parser.Markdown does, stopping at the first error
+// writing the output, which is returned.
+func (d *Document) Render(f Formatter) error {
+	ef, _ := f.(errFormatter)
+	for _, tree := range d.blocks {
+		f.FormatBlock(tree)
+		if ef != nil && ef.Err() != nil {
+			break
+		}
+	}
+	f.Finish()
+	if ef != nil {
+		return ef.Err()
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the document, not allocated by
+// a Parser, which may be modified without affecting the original.
+// This allows, for example, to rewrite the links of a cached
+// document for each request. Data attached to nodes is copied,
+// but not the values themselves.
+func (d *Document) Clone() *Document {
+	c := &Document{blocks: make([]*Node, len(d.blocks)), refs: make([]*link, len(d.refs)), slugify: d.slugify}
+	seen := make(map[*Node]*Node)
+	n := 0
+	for _, ref := range d.refs {
+		n += countNodes(ref.label, seen)
+	}
+	for _, tree := range d.blocks {
+		n += countNodes(tree, seen)
+	}
+	cl := &cloner{seen: make(map[*Node]*Node, len(seen)), refs: make(map[*link]*link, len(d.refs)), arena: make([]Node, n)}
+	for i, ref := range d.refs {
+		l := *ref
+		l.label = cl.list(l.label)
+		c.refs[i] = &l
+		cl.refs[ref] = &l
+	}
+	for i, tree := range d.blocks {
+		c.blocks[i] = cl.list(tree)
+	}
+	return c
+}
+
+// Detach replaces the nodes of the document by copies, so that
+// the document does not keep the rows of nodes allocated while
+// parsing alive, which hold a lot of other nodes no longer in
+// use, like those of alternatives tried by the parser. The
+// copies are allocated in one piece, which is cheaper than
+// allocating nodes one by one. Documents that are cached for
+// a long time should be detached.
+func (d *Document) Detach() {
+	*d = *d.Clone()
+}
+
+/* countNodes - returns the number of nodes in a list, including
+ * children and link labels, counting shared lists once
+ */
+func countNodes(list *Node, seen map[*Node]*Node) (n int) {
+	if list == nil {
+		return
+	}
+	if _, ok := seen[list]; ok {
+		return
+	}
+	seen[list] = list
+	for ; list != nil; list = list.next {
+		n++
+		if list.contents.link != nil {
+			n += countNodes(list.contents.link.label, seen)
+		}
+		n += countNodes(list.children, seen)
+	}
+	return
+}
+
+/* cloner - copies lists of nodes, and their children, into
+ * an arena. Lists shared between several nodes, like the
+ * contents of a note referenced more than once, remain shared
+ * in the copy, which is why already copied lists are tracked
+ * in seen. Links resolved with a reference definition are
+ * pointed to the copy of the definition found in refs.
+ */
+type cloner struct {
+	seen  map[*Node]*Node
+	refs  map[*link]*link
+	arena []Node
+}
+
+func (cl *cloner) newNode() *Node {
+	if len(cl.arena) == 0 {
+		return new(Node)
+	}
+	n := &cl.arena[0]
+	cl.arena = cl.arena[1:]
+	return n
+}
+
+func (cl *cloner) list(list *Node) *Node {
+	if list == nil {
+		return nil
+	}
+	if c, ok := cl.seen[list]; ok {
+		return c
+	}
+	var head *Node
+	p := &head
+	for n := list; n != nil; n = n.next {
+		c := cl.newNode()
+		*c = *n
+		if n == list {
+			cl.seen[list] = c
+		}
+		if n.contents.link != nil {
+			l := *n.contents.link
+			l.label = cl.list(l.label)
+			if r, ok := cl.refs[l.ref]; ok {
+				l.ref = r
+			}
+			c.contents.link = &l
+		}
+		c.children = cl.list(n.children)
+		if n.data != nil {
+			c.data = make(map[interface{}]interface{}, len(n.data))
+			for k, v := range n.data {
+				c.data[k] = v
+			}
+		}
+		c.next = nil
+		*p = c
+		p = &c.next
+	}
+	return head
+}
@@ -14,7 +14,7 @@ field.
 */
 
 type elemHeap struct {
-	rows [][]element
+	rows [][]Node
 	heapPos
 	rowSize int
 
@@ -24,13 +24,13 @@ type elemHeap struct {
 
 type heapPos struct {
 	iRow int
-	row  []element
+	row  []Node
 }
 
-func (h *elemHeap) nextRow() []element {
+func (h *elemHeap) nextRow() []Node {
 	h.iRow++
 	if h.iRow == len(h.rows) {
-		h.rows = append(h.rows, make([]element, h.rowSize))
+		h.rows = append(h.rows, make([]Node, h.rowSize))
 	}
 	h.row = h.rows[h.iRow]
 	return h.row
@@ -38,11 +38,21 @@ func (h *elemHeap) nextRow() []element {
 
 func (h *elemHeap) init(size int) {
 	h.rowSize = size
-	h.rows = [][]element{make([]element, size)}
+	h.rows = [][]Node{make([]Node, size)}
 	h.row = h.rows[h.iRow]
 	h.base = h.heapPos
 }
 
+/* rewind - makes all rows available again, which is possible
+ * at the start of a document, when no element is in use any longer
+ */
+func (h *elemHeap) rewind() {
+	h.iRow = 0
+	h.row = h.rows[0]
+	h.base = h.heapPos
+	h.hasGlobals = false
+}
+
 func (h *elemHeap) Reset() {
 	if !h.hasGlobals {
 		h.heapPos = h.base
@@ -0,0 +1,186 @@
+package markdown
+
+// Embedded content of sites like YouTube.
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// An EmbedProvider describes a site whose content, like videos,
+// may be embedded into documents, see Extensions.Embeds.
+type EmbedProvider struct {
+	// Name is used in the @[name](id) syntax, like "youtube".
+	Name string
+
+	// Match, if not nil, recognizes URLs of the site's content,
+	// and returns the id of the content. It is applied to bare
+	// links, i.e. autolinks like <https://youtu.be/id> forming
+	// a paragraph on their own.
+	Match func(url string) (id string, ok bool)
+
+	// URL returns the URL of the content with the given id. It is
+	// the target of the link written by formatters not supporting
+	// embedded content.
+	URL func(id string) string
+
+	// HTML returns the markup embedding the content, like an
+	// <iframe> element; ok is false if id is invalid.
+	HTML func(id string) (html string, ok bool)
+}
+
+var youTubeID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// YouTube embeds videos from youtube.com, using the
+// youtube-nocookie.com domain, which sets no cookies until
+// the video is played.
+var YouTube = EmbedProvider{
+	Name: "youtube",
+	Match: func(s string) (id string, ok bool) {
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme != "https" && u.Scheme != "http" {
+			return "", false
+		}
+		switch strings.TrimPrefix(strings.TrimPrefix(u.Host, "www."), "m.") {
+		case "youtu.be":
+			id = strings.TrimPrefix(u.Path, "/")
+		case "youtube.com":
+			switch {
+			case u.Path == "/watch":
+				id = u.Query().Get("v")
+			case strings.HasPrefix(u.Path, "/embed/"), strings.HasPrefix(u.Path, "/shorts/"):
+				id = u.Path[strings.LastIndexByte(u.Path, '/')+1:]
+			}
+		}
+		return id, youTubeID.MatchString(id)
+	},
+	URL: func(id string) string {
+		return "https://www.youtube.com/watch?v=" + url.QueryEscape(id)
+	},
+	HTML: func(id string) (string, bool) {
+		if !youTubeID.MatchString(id) {
+			return "", false
+		}
+		return `<iframe class="embed youtube" src="https://www.youtube-nocookie.com/embed/` + id +
+			`" title="YouTube video" allow="fullscreen; picture-in-picture"></iframe>`, true
+	},
+}
+
+var vimeoID = regexp.MustCompile(`^[0-9]+$`)
+
+// Vimeo embeds videos from vimeo.com.
+var Vimeo = EmbedProvider{
+	Name: "vimeo",
+	Match: func(s string) (id string, ok bool) {
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme != "https" && u.Scheme != "http" || strings.TrimPrefix(u.Host, "www.") != "vimeo.com" {
+			return "", false
+		}
+		id = strings.TrimPrefix(u.Path, "/")
+		return id, vimeoID.MatchString(id)
+	},
+	URL: func(id string) string {
+		return "https://vimeo.com/" + url.PathEscape(id)
+	},
+	HTML: func(id string) (string, bool) {
+		if !vimeoID.MatchString(id) {
+			return "", false
+		}
+		return `<iframe class="embed vimeo" src="https://player.vimeo.com/video/` + id +
+			`" title="Vimeo video" allow="fullscreen; picture-in-picture"></iframe>`, true
+	},
+}
+
+// DefaultEmbeds lists the predefined providers. It may be
+// assigned to Extensions.Embeds.
+var DefaultEmbeds = []EmbedProvider{YouTube, Vimeo}
+
+/* linkEmbeds - traverses a list of blocks, replacing bare links
+ * matched by a provider, and links written as @[name](id), by
+ * EMBED elements
+ */
+func (p *Parser) linkEmbeds(list *Node) {
+	for el := list; el != nil; el = el.next {
+		switch el.key {
+		case PARA:
+			if c := el.children; c != nil && c.next == nil && isAutoLink(c) {
+				for i := range p.yy.extension.Embeds {
+					e := &p.yy.extension.Embeds[i]
+					if e.Match == nil {
+						continue
+					}
+					if id, ok := e.Match(c.contents.link.url); ok {
+						if mkEmbed(c, e, id) {
+							break
+						}
+					}
+				}
+			}
+			p.embedLinks(el.children)
+		case PLAIN, H1, H2, H3, H4, H5, H6, DEFTITLE, CITATIONLINE:
+			p.embedLinks(el.children)
+		case NOTE:
+			if el.contents.link == nil {
+				p.linkEmbeds(el.children)
+			}
+			/* referenced notes are handled by processNotes */
+		default:
+			p.linkEmbeds(el.children)
+		}
+	}
+}
+
+/* embedLinks - replaces links written as @[name](id) within a list
+ * of inline elements by EMBED elements
+ */
+func (p *Parser) embedLinks(list *Node) {
+	for el := list; el != nil; el = el.next {
+		next := el.next
+		if el.key != STR || !strings.HasSuffix(el.contents.str, "@") || next == nil || next.key != LINK {
+			p.embedLinks(el.children)
+			continue
+		}
+		l := next.contents.link
+		if l.ref != nil || l.label == nil || l.label.next != nil || l.label.key != STR {
+			continue
+		}
+		for i := range p.yy.extension.Embeds {
+			e := &p.yy.extension.Embeds[i]
+			if e.Name == l.label.contents.str && mkEmbed(next, e, l.url) {
+				el.contents.str = strings.TrimSuffix(el.contents.str, "@")
+				l = next.contents.link
+				l.url = e.URL(l.url)
+				l.label.contents.str = l.url
+				break
+			}
+		}
+	}
+}
+
+/* isAutoLink - reports whether a link's label equals its URL,
+ * as with <https://example.org/>
+ */
+func isAutoLink(el *Node) bool {
+	if el.key != LINK {
+		return false
+	}
+	l := el.contents.link
+	return l.label != nil && l.label.next == nil && l.label.key == STR && l.label.contents.str == l.url
+}
+
+/* mkEmbed - turns a link into an EMBED element of the content
+ * with the given id, keeping the link as fallback; it returns
+ * false if the provider does not accept the id
+ */
+func mkEmbed(el *Node, e *EmbedProvider, id string) bool {
+	html, ok := e.HTML(id)
+	if !ok {
+		return false
+	}
+	l := *el.contents.link /* the link may be shared with a reference */
+	el.key = EMBED
+	el.contents.str = html
+	el.contents.link = &l
+	return true
+}
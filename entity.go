@@ -0,0 +1,80 @@
+package markdown
+
+// Validation of numeric character references.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/* numericEntity - checks a character reference as matched by the
+ * Entity rule. Numeric references to surrogates, control
+ * characters, noncharacters, or code points beyond the Unicode
+ * range are reported and, if ReplaceEntities is set, replaced by
+ * a reference to U+FFFD.
+ */
+func (p *yyParser) numericEntity(s string) string {
+	if !strings.HasPrefix(s, "&#") {
+		return s
+	}
+	num := strings.TrimSuffix(s[2:], ";")
+	var v uint64
+	var err error
+	if num != "" && (num[0] == 'x' || num[0] == 'X') {
+		v, err = strconv.ParseUint(num[1:], 16, 32)
+	} else {
+		v, err = strconv.ParseUint(num, 10, 32)
+	}
+	if err == nil && validCodePoint(rune(v)) {
+		return s
+	}
+	p.diags = append(p.diags, Diagnostic{
+		Line: p.line,
+		Code: "invalid-entity",
+		Msg:  fmt.Sprintf("invalid character reference %q", s),
+	})
+	if p.extension.ReplaceEntities {
+		return "&#xFFFD;"
+	}
+	return s
+}
+
+func validCodePoint(r rune) bool {
+	switch {
+	case r == '\t', r == '\n', r == '\f':
+		return true
+	case r < 0x20, r >= 0x7F && r < 0xA0:
+		return false
+	case r >= 0xD800 && r < 0xE000, r > 0x10FFFF:
+		return false
+	case r >= 0xFDD0 && r < 0xFDF0, r&0xFFFE == 0xFFFE:
+		return false
+	}
+	return true
+}
+
+/* entityLen - returns the length of the character reference
+ * at the start of s, or 0 if there is none
+ */
+func entityLen(s string) int {
+	i := 1
+	if len(s) > 2 && s[1] == '#' {
+		i = 2
+		if s[2] == 'x' || s[2] == 'X' {
+			i = 3
+		}
+	}
+	j := i
+	for j < len(s) && isAlnum(s[j]) {
+		j++
+	}
+	if j == i || j == len(s) || s[j] != ';' {
+		return 0
+	}
+	return j + 1
+}
+
+func isASCIIPunct(c byte) bool {
+	return c > ' ' && c < 0x7F && !isAlnum(c)
+}
@@ -0,0 +1,103 @@
+package markdown
+
+// Numbered example lists, like pandoc's example_lists.
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var (
+	exampleMarker = regexp.MustCompile(`^\(@([A-Za-z0-9_-]*)\)$`)
+	exampleRef    = regexp.MustCompile(`\(@([A-Za-z0-9_-]+)\)`)
+)
+
+/* numberExamples - numbers the items of example lists, marked
+ * `(@)' or `(@label)', consecutively across the whole document,
+ * replacing their markers by `(n)', and replaces references like
+ * `(@label)' in the text by the number of the labeled item
+ */
+func (p *Parser) numberExamples(blocks []*Node) {
+	labels := make(map[string]int)
+	n := 0
+	for _, tree := range blocks {
+		walkElements(tree, func(el *Node) {
+			if el.key != ORDEREDLIST {
+				return
+			}
+			first := true
+			for item := el.children; item != nil; item = item.next {
+				m := exampleMarker.FindStringSubmatch(item.contents.str)
+				if m == nil {
+					first = false
+					continue
+				}
+				n++
+				switch _, dup := labels[m[1]]; {
+				case m[1] == "":
+				case dup:
+					p.yy.diags = append(p.yy.diags, Diagnostic{
+						Line: tree.line,
+						Code: "duplicate-example",
+						Msg:  fmt.Sprintf("example %q defined more than once", m[1]),
+					})
+				default:
+					labels[m[1]] = n
+				}
+				item.contents.str = "(" + strconv.Itoa(n) + ")"
+				if first {
+					/* the list is numbered like a fancy list */
+					el.contents.str = item.contents.str
+				}
+				first = false
+			}
+		})
+	}
+	if n == 0 {
+		return
+	}
+	for _, tree := range blocks {
+		p.exampleRefs(tree, labels, tree.line)
+	}
+}
+
+/* exampleRefs - replaces references to labeled examples within
+ * the text of runs of STR elements
+ */
+func (p *Parser) exampleRefs(list *Node, labels map[string]int, line int) {
+	for el := list; el != nil; el = el.next {
+		switch el.key {
+		case LINK, IMAGE, MEDIA, EMBED:
+			p.exampleRefs(el.contents.link.label, labels, line)
+			continue
+		case STR:
+			last := el
+			text := el.contents.str
+			for last.next != nil && last.next.key == STR {
+				last = last.next
+				text += last.contents.str
+			}
+			repl := exampleRef.ReplaceAllStringFunc(text, func(ref string) string {
+				label := ref[2 : len(ref)-1]
+				n, ok := labels[label]
+				if !ok {
+					p.yy.diags = append(p.yy.diags, Diagnostic{
+						Line: line,
+						Code: "undefined-example",
+						Msg:  fmt.Sprintf("undefined example %q", label),
+					})
+					return ref
+				}
+				return "(" + strconv.Itoa(n) + ")"
+			})
+			if repl != text {
+				el.contents.str = repl
+				el.next = last.next
+			}
+			el = last
+			continue
+		}
+		p.exampleRefs(el.children, labels, line)
+	}
+}
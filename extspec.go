@@ -0,0 +1,94 @@
+package markdown
+
+// Textual form of Extensions.
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParseExtensions returns the Extensions described by spec, a comma
+// separated list of names of boolean fields, lower-cased, like
+// "smart,notes,toc". A name preceded by '-' turns the extension off,
+// which is useful if spec is appended to a default list. Integer
+// fields are given as name=value, e.g. "codetabs=8". Fields holding
+// lists or functions cannot be specified.
+func ParseExtensions(spec string) (x Extensions, err error) {
+	err = x.Set(spec)
+	return
+}
+
+// Set modifies x according to spec, see ParseExtensions.
+func (x *Extensions) Set(spec string) error {
+	v := reflect.ValueOf(x).Elem()
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(item, "=")
+		on := !strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		f := extField(v, name)
+		switch {
+		case !f.IsValid():
+			return fmt.Errorf("unknown extension %q", name)
+		case f.Kind() == reflect.Bool && !hasValue:
+			f.SetBool(on)
+		case f.Kind() == reflect.Int && hasValue && on:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("extension %s: invalid value %q", name, value)
+			}
+			f.SetInt(int64(n))
+		case f.Kind() == reflect.Int && !hasValue && !on:
+			f.SetInt(0)
+		default:
+			return fmt.Errorf("invalid extension specification %q", item)
+		}
+	}
+	return nil
+}
+
+/* extField - returns the boolean or integer field of
+ * Extensions matching name, ignoring case
+ */
+func extField(v reflect.Value, name string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		switch t.Field(i).Type.Kind() {
+		case reflect.Bool, reflect.Int:
+			if strings.EqualFold(t.Field(i).Name, name) {
+				return v.Field(i)
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+// String returns the specification of x understood by
+// ParseExtensions, listing the extensions turned on, and
+// integer fields that are not zero, in the order of their
+// declaration. Fields holding lists or functions are omitted.
+func (x Extensions) String() string {
+	var list []string
+
+	v := reflect.ValueOf(x)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.ToLower(t.Field(i).Name)
+		switch f := v.Field(i); f.Kind() {
+		case reflect.Bool:
+			if f.Bool() {
+				list = append(list, name)
+			}
+		case reflect.Int:
+			if f.Int() != 0 {
+				list = append(list, name+"="+strconv.FormatInt(f.Int(), 10))
+			}
+		}
+	}
+	return strings.Join(list, ",")
+}
@@ -0,0 +1,35 @@
+package markdown
+
+// Front matter.
+
+import (
+	"strings"
+)
+
+// FrontMatter looks for a YAML-like front matter block at the start
+// of src, delimited by lines containing "---", and returns the
+// settings found, by lower-case key, and src with the block
+// replaced by empty lines, so that line numbers of diagnostics
+// remain correct. Only flat "key: value" lines are understood;
+// quotes around a value are removed. If src does not start with
+// a complete block, meta is nil, and rest is src.
+func FrontMatter(src string) (meta map[string]string, rest string) {
+	lines := strings.SplitAfter(src, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, src
+	}
+	meta = make(map[string]string)
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "---" || line == "..." {
+			return meta, strings.Repeat("\n", i+1) + strings.Join(lines[i+1:], "")
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		meta[key] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return nil, src /* no end of the block, no front matter */
+}
@@ -0,0 +1,495 @@
+//go:build ignore
+
+/*
+Gen regenerates the parser from parser.leg, using leg, the
+parser generator of github.com/knieriem/peg, with the options
+the Makefile uses. It is run by
+
+	go generate github.com/knieriem/markdown
+
+leg is looked up in $PATH, unless the environment variable LEG
+names the program to use. It may be installed using
+
+	go install github.com/knieriem/peg/leg@latest
+
+leg writes the whole parser into a single function, with the rules
+as closures sharing the parser's state through local variables of
+that function. To keep compile times down, and grammar changes
+reviewable, gen moves that state into fields and methods of
+yyParser, and splits the rules by area into files:
+
+	parser.blocks.go	block elements, like paragraphs and lists
+	parser.htmlblocks.go	HTML blocks, the rules named HtmlBlock...
+	parser.inlines.go	the rules reachable from Inline
+
+parser.leg.go keeps the rest: the code of parser.leg preceding and
+following the rules, the actions, and the parser's state.
+
+The files are formatted with gofmt, as the ones in the repository
+are. They are only written if leg succeeds, so that a failed run
+does not leave a truncated parser behind.
+
+As this package has no module file, the revision of leg cannot be
+pinned; any revision producing code that passes the tests may be
+used. Since the generated files are also edited along with
+parser.leg, TestGeneratedParser checks that the Go code of the
+grammar's actions, header and trailer is found in them, so that
+regenerating the parser does not lose changes to either side.
+*/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+const (
+	grammar = "parser.leg"
+	output  = "parser.leg.go"
+)
+
+/* area - a part of the grammar, written to a file of its own
+ */
+type area struct {
+	file   string
+	method string
+	doc    string
+}
+
+var (
+	blocks     = &area{"parser.blocks.go", "blockRules", "block elements"}
+	htmlBlocks = &area{"parser.htmlblocks.go", "htmlBlockRules", "HTML blocks"}
+	inlines    = &area{"parser.inlines.go", "inlineRules", "inline elements"}
+
+	areas = []*area{blocks, htmlBlocks, inlines}
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	leg := os.Getenv("LEG")
+	if leg == "" {
+		var err error
+		leg, err = exec.LookPath("leg")
+		if err != nil {
+			log.Fatal("leg not found; install it using\n\tgo install github.com/knieriem/peg/leg@latest")
+		}
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command(leg, "-verbose", "-switch", "-O", "all", grammar)
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Fatalf("%s: %v", leg, err)
+	}
+	if out.Len() == 0 {
+		log.Fatalf("%s: no output", leg)
+	}
+
+	files, err := split(out.Bytes())
+	if err != nil {
+		log.Fatalf("%s: %v", output, err)
+	}
+	names := make([]string, 0, len(files))
+	for name, src := range files {
+		files[name], err = format.Source(src)
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeFile(name, files[name]); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+/* writeFile - replaces a file by way of a temporary one, so that
+ * it is either written completely or not at all
+ */
+func writeFile(name string, src []byte) error {
+	f, err := os.CreateTemp(".", ".parser-*.go")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(src)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	if err == nil {
+		err = os.Chmod(f.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+/* split - turns the output of leg into parser.leg.go and a file
+ * per area, returning the source of each file by name.
+ *
+ * The local variables and functions of Init used by the rules,
+ * and the ones these depend on, are moved out of Init: variables
+ * become fields of yyParser, functions become methods, types and
+ * constants are declared at package level. References to them
+ * are rewritten accordingly. The rules, elements of the array
+ * literal assigned to p.rules, are assigned one by one within a
+ * method per area, which Init calls instead.
+ */
+func split(src []byte) (map[string][]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, output, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	off := func(pos token.Pos) int { return fset.Position(pos).Offset }
+
+	var init *ast.FuncDecl
+	var parserType *ast.StructType
+	var ruleNames []string
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil && d.Name.Name == "Init" {
+				init = d
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch sp := spec.(type) {
+				case *ast.TypeSpec:
+					if st, ok := sp.Type.(*ast.StructType); ok && sp.Name.Name == "yyParser" {
+						parserType = st
+					}
+				case *ast.ValueSpec:
+					if d.Tok == token.CONST && strings.HasPrefix(sp.Names[0].Name, "rule") {
+						ruleNames = append(ruleNames, sp.Names[0].Name)
+					}
+				}
+			}
+		}
+	}
+	if init == nil || parserType == nil || len(init.Recv.List[0].Names) == 0 {
+		return nil, fmt.Errorf("Init or yyParser not found")
+	}
+	recv := init.Recv.List[0].Names[0].Name
+
+	/* the statements of Init declaring local names, and the
+	 * assignment of the rules
+	 */
+	declaredBy := make(map[*ast.Object]ast.Stmt)
+	var rulesStmt *ast.AssignStmt
+	var rules *ast.CompositeLit
+	for _, stmt := range init.Body.List {
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			if s.Tok == token.DEFINE {
+				for _, x := range s.Lhs {
+					if id, ok := x.(*ast.Ident); ok && id.Obj != nil {
+						declaredBy[id.Obj] = s
+					}
+				}
+			} else if sel, ok := s.Lhs[0].(*ast.SelectorExpr); ok && sel.Sel.Name == "rules" {
+				rulesStmt = s
+				rules, _ = s.Rhs[0].(*ast.CompositeLit)
+			}
+		case *ast.DeclStmt:
+			for _, spec := range s.Decl.(*ast.GenDecl).Specs {
+				switch sp := spec.(type) {
+				case *ast.ValueSpec:
+					for _, id := range sp.Names {
+						declaredBy[id.Obj] = s
+					}
+				case *ast.TypeSpec:
+					declaredBy[sp.Name.Obj] = s
+				}
+			}
+		}
+	}
+	if rules == nil || len(rules.Elts) > len(ruleNames) {
+		return nil, fmt.Errorf("rules not found")
+	}
+
+	/* the statements to move: those declaring names used by the
+	 * rules, and, in turn, by the moved statements
+	 */
+	moved := make(map[ast.Stmt]bool)
+	work := []ast.Node{rules}
+	for len(work) != 0 {
+		n := work[0]
+		work = work[1:]
+		ast.Inspect(n, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && id.Obj != nil {
+				if s := declaredBy[id.Obj]; s != nil && !moved[s] {
+					moved[s] = true
+					work = append(work, s)
+				}
+			}
+			return true
+		})
+	}
+
+	/* references to moved variables and functions, which are to
+	 * be prefixed by the receiver
+	 */
+	prefix := make(map[token.Pos]bool)
+	ast.Inspect(init.Body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok || id.Obj == nil || id.Obj.Decl == nil {
+			return true
+		}
+		if s := declaredBy[id.Obj]; s != nil && moved[s] && ofParser(s) && !isDeclIdent(id) {
+			prefix[id.Pos()] = true
+		}
+		return true
+	})
+	text := func(from, to token.Pos) string {
+		var b strings.Builder
+		i := off(from)
+		for _, p := range sortedPos(prefix) {
+			if o := off(p); o >= i && o < off(to) {
+				b.Write(src[i:o])
+				b.WriteString(recv + ".")
+				i = o
+			}
+		}
+		b.Write(src[i:off(to)])
+		return b.String()
+	}
+
+	/* the moved statements, turned into fields, methods, and
+	 * package level declarations
+	 */
+	var fields, decls strings.Builder
+	initStmt := make(map[ast.Stmt]string)
+	for _, stmt := range init.Body.List {
+		if !moved[stmt] {
+			continue
+		}
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			if len(s.Lhs) != 1 || len(s.Rhs) != 1 {
+				return nil, fmt.Errorf("%v: cannot move multiple assignment", fset.Position(s.Pos()))
+			}
+			name := s.Lhs[0].(*ast.Ident).Name
+			switch x := s.Rhs[0].(type) {
+			case *ast.FuncLit:
+				fmt.Fprintf(&decls, "\nfunc (%s *yyParser) %s%s\n", recv, name, text(x.Type.Params.Pos(), x.End()))
+			case *ast.CallExpr:
+				if fn, ok := x.Fun.(*ast.Ident); !ok || fn.Name != "make" {
+					return nil, fmt.Errorf("%v: cannot move %s", fset.Position(s.Pos()), name)
+				}
+				fmt.Fprintf(&fields, "\t%s %s\n", name, text(x.Args[0].Pos(), x.Args[0].End()))
+				initStmt[s] = recv + "." + name + " = " + text(x.Pos(), x.End())
+			default:
+				fmt.Fprintf(&decls, "\nvar %s = %s\n", name, text(x.Pos(), x.End()))
+			}
+		case *ast.DeclStmt:
+			d := s.Decl.(*ast.GenDecl)
+			if d.Tok != token.VAR {
+				fmt.Fprintf(&decls, "\n%s\n", text(d.Pos(), d.End()))
+				continue
+			}
+			for _, spec := range d.Specs {
+				sp := spec.(*ast.ValueSpec)
+				if sp.Values != nil || sp.Type == nil {
+					return nil, fmt.Errorf("%v: cannot move initialized variable", fset.Position(sp.Pos()))
+				}
+				fmt.Fprintf(&fields, "\t%s\n", text(sp.Pos(), sp.End()))
+			}
+		default:
+			return nil, fmt.Errorf("%v: cannot move statement", fset.Position(s.Pos()))
+		}
+	}
+
+	/* the rules, by area */
+	graph := make(map[string][]string)
+	for i, elt := range rules.Elts {
+		ast.Inspect(elt, func(n ast.Node) bool {
+			if x, ok := n.(*ast.IndexExpr); ok {
+				if sel, ok := x.X.(*ast.SelectorExpr); ok && sel.Sel.Name == "rules" {
+					if id, ok := x.Index.(*ast.Ident); ok {
+						graph[ruleNames[i]] = append(graph[ruleNames[i]], id.Name)
+					}
+				}
+			}
+			return true
+		})
+	}
+	areaOf := make(map[string]*area)
+	for _, name := range ruleNames {
+		if strings.HasPrefix(name, "ruleHtmlBlock") {
+			areaOf[name] = htmlBlocks
+		}
+	}
+	if len(graph["ruleInline"]) == 0 {
+		return nil, fmt.Errorf("rule Inline not found")
+	}
+	for work := []string{"ruleInline"}; len(work) != 0; {
+		name := work[0]
+		work = work[1:]
+		if areaOf[name] != nil {
+			continue
+		}
+		areaOf[name] = inlines
+		work = append(work, graph[name]...)
+	}
+	pkgs := make(map[string]string)
+	for _, imp := range f.Imports {
+		path := strings.Trim(imp.Path.Value, `"`)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		pkgs[name] = imp.Path.Value
+	}
+	imports := make(map[*area]map[string]bool)
+	body := make(map[*area]*strings.Builder)
+	for _, a := range areas {
+		body[a] = new(strings.Builder)
+		imports[a] = make(map[string]bool)
+	}
+	prev := rules.Lbrace + 1
+	for i, elt := range rules.Elts {
+		start := elt.Pos()
+		for _, c := range f.Comments {
+			if c.Pos() >= prev && c.End() <= elt.Pos() {
+				start = c.Pos()
+				break
+			}
+		}
+		prev = elt.End()
+		if id, ok := elt.(*ast.Ident); ok && id.Name == "nil" {
+			continue
+		}
+		a := areaOf[ruleNames[i]]
+		if a == nil {
+			a = blocks
+		}
+		ast.Inspect(elt, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil && pkgs[id.Name] != "" {
+					imports[a][id.Name] = true
+				}
+			}
+			return true
+		})
+		fmt.Fprintf(body[a], "\t%s%s.rules[%s] = %s\n", text(start, elt.Pos()), recv, ruleNames[i], text(elt.Pos(), elt.End()))
+	}
+
+	/* parser.leg.go */
+	var b strings.Builder
+	b.Write(src[:off(parserType.Fields.Closing)])
+	b.WriteString(fields.String())
+	b.Write(src[off(parserType.Fields.Closing):off(init.Body.Lbrace)])
+	b.WriteString("{")
+	prev = init.Body.Lbrace + 1
+	for _, stmt := range init.Body.List {
+		if !moved[stmt] && stmt != rulesStmt {
+			continue
+		}
+		b.WriteString(text(prev, stmt.Pos()))
+		prev = stmt.End()
+		switch {
+		case stmt == rulesStmt:
+			for i, a := range areas {
+				if i > 0 {
+					b.WriteString("\n\t")
+				}
+				b.WriteString(recv + "." + a.method + "()")
+			}
+		case initStmt[stmt] != "":
+			b.WriteString(initStmt[stmt])
+		}
+	}
+	b.WriteString(text(prev, init.End()))
+	b.WriteString("\n")
+	b.WriteString(decls.String())
+	b.Write(src[off(init.End()):])
+
+	files := map[string][]byte{output: []byte(b.String())}
+	for _, a := range areas {
+		var b strings.Builder
+		fmt.Fprintf(&b, "/* Rules of the grammar in %s matching %s.\n", grammar, a.doc)
+		fmt.Fprintf(&b, " * Generated by leg, and split off %s by gen.go.\n */\n\npackage %s\n", output, f.Name.Name)
+		for _, name := range sortedNames(imports[a]) {
+			fmt.Fprintf(&b, "\nimport %s %s\n", name, pkgs[name])
+		}
+		fmt.Fprintf(&b, "\nfunc (%s *yyParser) %s() {\n%s}\n", recv, a.method, body[a])
+		files[a.file] = []byte(b.String())
+	}
+	return files, nil
+}
+
+/* ofParser - reports whether a moved statement declares fields
+ * or methods of yyParser, rather than package level names
+ */
+func ofParser(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		switch x := s.Rhs[0].(type) {
+		case *ast.FuncLit:
+			return true
+		case *ast.CallExpr:
+			fn, ok := x.Fun.(*ast.Ident)
+			return ok && fn.Name == "make"
+		}
+	case *ast.DeclStmt:
+		return s.Decl.(*ast.GenDecl).Tok == token.VAR
+	}
+	return false
+}
+
+/* isDeclIdent - reports whether an identifier is declared by the
+ * statement or specification it is part of
+ */
+func isDeclIdent(id *ast.Ident) bool {
+	switch d := id.Obj.Decl.(type) {
+	case *ast.AssignStmt:
+		for _, x := range d.Lhs {
+			if x == ast.Expr(id) {
+				return true
+			}
+		}
+	case *ast.ValueSpec:
+		for _, n := range d.Names {
+			if n == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sortedPos(set map[token.Pos]bool) []token.Pos {
+	list := make([]token.Pos, 0, len(set))
+	for p := range set {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+func sortedNames(set map[string]bool) []string {
+	list := make([]string, 0, len(set))
+	for name := range set {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
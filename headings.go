@@ -0,0 +1,52 @@
+package markdown
+
+// Heading extraction.
+
+// A Heading describes a heading of a document.
+type Heading struct {
+	Level int    // 1 to 6
+	Text  string // plain text, with formatting stripped
+	ID    string // the id used for permalinks and TOC entries, see Slugify
+	Line  int    // line number of the top-level block containing the heading
+}
+
+type headingCollector struct {
+	headings *[]Heading
+	slugify  func(string) string
+	ids      *headingIDs
+}
+
+// HeadingsTo returns a Formatter that, instead of printing
+// the document, appends the headings found to the slice pointed
+// to by headings. It may be used to build navigation structures
+// like breadcrumbs, or a table of contents outside the document.
+func HeadingsTo(headings *[]Heading) Formatter {
+	return HeadingsToSlugify(headings, nil)
+}
+
+// Like HeadingsTo, but ids of headings are derived using slugify,
+// or Slugify, if it is nil. To get the ids of permalinks, pass the
+// HTMLOptions.Slugify used for writing the document. Headings
+// listed in a table of contents keep the ids assigned by the parser
+// using Extensions.Slugify.
+func HeadingsToSlugify(headings *[]Heading, slugify func(string) string) Formatter {
+	return &headingCollector{headings: headings, slugify: slugify, ids: newHeadingIDs(slugify)}
+}
+
+func (f *headingCollector) FormatBlock(tree *Node) {
+	line := tree.line
+	walkElements(tree, func(el *Node) {
+		switch el.key {
+		case H1, H2, H3, H4, H5, H6:
+			h := Heading{Level: 1 + el.key - H1, Text: inlineText(el.children), ID: el.contents.str, Line: line}
+			if h.ID == "" {
+				h.ID = f.ids.make(h.Text)
+			}
+			*f.headings = append(*f.headings, h)
+		}
+	})
+}
+
+func (f *headingCollector) Finish() {
+	f.ids = newHeadingIDs(f.slugify)
+}
@@ -0,0 +1,299 @@
+package markdown
+
+// Filtering of raw HTML.
+
+import (
+	"strings"
+)
+
+// A tag found in raw HTML.
+type htmlTag struct {
+	name        string // lower case
+	closing     bool
+	selfClosing bool
+	attrs       []htmlAttr
+}
+
+type htmlAttr struct {
+	name  string // lower case
+	value string
+	raw   string // the attribute as written, e.g. `href="x"'
+}
+
+/* parseTag - parses a tag at the start of s, which must begin
+ * with '<'. The number of bytes consumed is returned in n.
+ * If s does not start with a tag, ok is false.
+ */
+func parseTag(s string) (t htmlTag, n int, ok bool) {
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		t.closing = true
+		i++
+	}
+	j := i
+	for j < len(s) && isAlnum(s[j]) {
+		j++
+	}
+	if j == i {
+		return
+	}
+	t.name = strings.ToLower(s[i:j])
+	i = j
+	for {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return
+		}
+		switch s[i] {
+		case '>':
+			return t, i + 1, true
+		case '/':
+			t.selfClosing = true
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		a := htmlAttr{name: strings.ToLower(s[start:i])}
+		k := i
+		for k < len(s) && isSpace(s[k]) {
+			k++
+		}
+		if k < len(s) && s[k] == '=' {
+			k++
+			for k < len(s) && isSpace(s[k]) {
+				k++
+			}
+			if k == len(s) {
+				return
+			}
+			if q := s[k]; q == '"' || q == '\'' {
+				end := strings.IndexByte(s[k+1:], q)
+				if end == -1 {
+					return
+				}
+				a.value = s[k+1 : k+1+end]
+				i = k + end + 2
+			} else {
+				v := k
+				for k < len(s) && !isSpace(s[k]) && s[k] != '>' {
+					k++
+				}
+				a.value = s[v:k]
+				i = k
+			}
+		}
+		a.raw = s[start:i]
+		t.attrs = append(t.attrs, a)
+	}
+}
+
+// String returns the tag in HTML syntax.
+func (t *htmlTag) String() string {
+	var b strings.Builder
+
+	b.WriteByte('<')
+	if t.closing {
+		b.WriteByte('/')
+	}
+	b.WriteString(t.name)
+	for _, a := range t.attrs {
+		b.WriteByte(' ')
+		b.WriteString(a.raw)
+	}
+	if t.selfClosing {
+		b.WriteString(" /")
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+/* filterHTML - copies raw HTML, passing each tag to the function fn,
+ * which returns the replacement. Text and comments are copied
+ * unchanged. If fn sets skip, everything up to the matching
+ * closing tag is dropped, too. A '<' not starting a well-formed tag
+ * or a closed comment is escaped, as a browser might still read a
+ * tag from it, like from <img src=x onerror=f() title=">.
+ */
+func filterHTML(s string, fn func(t *htmlTag, raw string) (repl string, skip bool)) string {
+	var b strings.Builder
+
+	for {
+		i := strings.IndexByte(s, '<')
+		if i == -1 {
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		if strings.HasPrefix(s, "<!--") {
+			n := commentLen(s)
+			if n == -1 {
+				b.WriteString("&lt;")
+				s = s[1:]
+				continue
+			}
+			b.WriteString(s[:n])
+			s = s[n:]
+			continue
+		}
+		t, n, ok := parseTag(s)
+		if !ok {
+			b.WriteString("&lt;")
+			s = s[1:]
+			continue
+		}
+		repl, skip := fn(&t, s[:n])
+		b.WriteString(repl)
+		s = s[n:]
+		if skip && !t.closing && !t.selfClosing {
+			s = skipElement(s, t.name)
+		}
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+/* commentLen - returns the length of the comment at the start
+ * of s, which begins with "<!--", or -1, if it is not closed.
+ * Like browsers, not only "-->" ends a comment, but also "--!>",
+ * and "<!-->" and "<!--->" are complete comments.
+ */
+func commentLen(s string) int {
+	switch {
+	case strings.HasPrefix(s, "<!-->"):
+		return 5
+	case strings.HasPrefix(s, "<!--->"):
+		return 6
+	}
+	for i := 4; ; i++ {
+		j := strings.Index(s[i:], "--")
+		if j == -1 {
+			return -1
+		}
+		i += j
+		switch {
+		case strings.HasPrefix(s[i+2:], ">"):
+			return i + 3
+		case strings.HasPrefix(s[i+2:], "!>"):
+			return i + 4
+		}
+	}
+}
+
+/* skipElement - returns the part of s following the
+ * closing tag of the named element
+ */
+func skipElement(s, name string) string {
+	for {
+		i := strings.Index(s, "</")
+		if i == -1 {
+			return ""
+		}
+		t, n, ok := parseTag(s[i:])
+		if ok && t.closing && t.name == name {
+			return s[i+n:]
+		}
+		s = s[i+2:]
+	}
+}
+
+/* isJavascriptURL - returns true if a URL uses the javascript:
+ * scheme, ignoring case, character references like &#x61; or
+ * &colon;, and any whitespace or control characters browsers
+ * would ignore
+ */
+func isJavascriptURL(url string) bool {
+	return strings.HasPrefix(strings.ToLower(trimURL(url)), "javascript:")
+}
+
+/* isScriptAttr - returns true for attributes that may run scripts:
+ * event handlers, URLs using the javascript: scheme, and the srcdoc
+ * attribute of frames, which contains a whole document
+ */
+func isScriptAttr(a *htmlAttr) bool {
+	return strings.HasPrefix(a.name, "on") || a.name == "srcdoc" || isJavascriptURL(a.value)
+}
+
+/* dropAttrs - removes the attributes of t for which drop returns
+ * true, and returns the tag, which is raw, if none is removed
+ */
+func dropAttrs(t *htmlTag, raw string, drop func(a *htmlAttr) bool) string {
+	attrs := t.attrs[:0]
+	for i := range t.attrs {
+		if !drop(&t.attrs[i]) {
+			attrs = append(attrs, t.attrs[i])
+		}
+	}
+	if len(attrs) == len(t.attrs) {
+		return raw
+	}
+	t.attrs = attrs
+	return t.String()
+}
+
+/* filterCSP - removes event handler and style attributes, URLs
+ * using the javascript: scheme, the srcdoc attribute of frames,
+ * which may contain scripts, and script and style elements
+ * from raw HTML
+ */
+func filterCSP(s string) string {
+	return filterHTML(s, func(t *htmlTag, raw string) (string, bool) {
+		switch t.name {
+		case "script", "style":
+			return "", true
+		}
+		return dropAttrs(t, raw, func(a *htmlAttr) bool {
+			return isScriptAttr(a) || a.name == "style"
+		}), false
+	})
+}
+
+/* escapeTags - escapes any tag in raw HTML whose name is not contained
+ * in the allowed list, and removes attributes that may run scripts
+ * from allowed tags. If the list is nil, s is returned unchanged.
+ */
+func escapeTags(s string, allowed []string) string {
+	if allowed == nil {
+		return s
+	}
+	return filterHTML(s, func(t *htmlTag, raw string) (string, bool) {
+		for _, name := range allowed {
+			if strings.EqualFold(name, t.name) {
+				return dropAttrs(t, raw, isScriptAttr), false
+			}
+		}
+		return htmlEscaper.Replace(raw), false
+	})
+}
+
+/* blockTags - returns the list of tags allowed in HTML blocks
+ */
+func (p *yyParser) blockTags() []string {
+	if p.extension.AllowedBlockHTML != nil {
+		return p.extension.AllowedBlockHTML
+	}
+	return p.extension.AllowedHTML
+}
+
+/* attrBoundary - returns true if an attribute name at pos is
+ * preceded by white space, so that it may contain characters like
+ * ':' or '@'
+ */
+func (p *yyParser) attrBoundary(pos int) bool {
+	return pos > 0 && isSpace(p.Buffer[pos-1])
+}
+
+// Escapes text for use in HTML, including attribute values.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
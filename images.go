@@ -0,0 +1,82 @@
+package markdown
+
+// Image extraction.
+
+// An Image describes an image referenced in a document.
+type Image struct {
+	URL   string
+	Title string
+	Alt   string // alternative text, with formatting stripped
+}
+
+type imageCollector struct {
+	images *[]Image
+	seen   map[*Node]bool /* see walkContent */
+}
+
+// ImagesTo returns a Formatter that, instead of printing
+// the document, appends the images found to the slice pointed
+// to by images. It may be used to audit documents, for example
+// to find images missing an alternative text:
+//
+//	var images []markdown.Image
+//	p.Markdown(r, markdown.ImagesTo(&images))
+func ImagesTo(images *[]Image) Formatter {
+	return &imageCollector{images: images, seen: make(map[*Node]bool)}
+}
+
+func (f *imageCollector) FormatBlock(tree *Node) {
+	walkContent(tree, f.seen, func(el *Node) {
+		if el.key == IMAGE {
+			*f.images = append(*f.images, newImage(el))
+		}
+	})
+}
+
+func (f *imageCollector) Finish() {
+	f.seen = make(map[*Node]bool)
+}
+
+func newImage(el *Node) Image {
+	l := el.contents.link
+	return Image{URL: l.url, Title: l.title, Alt: inlineText(l.label)}
+}
+
+/* walkElements - calls fn for each element of a list, and,
+ * recursively, for its children and link labels
+ */
+func walkElements(list *Node, fn func(*Node)) {
+	for ; list != nil; list = list.next {
+		fn(list)
+		switch list.key {
+		case LINK, IMAGE, MEDIA, EMBED:
+			walkElements(list.contents.link.label, fn)
+		}
+		walkElements(list.children, fn)
+	}
+}
+
+/* walkContent - like walkElements, but visits each element of
+ * a document's text once: tables of contents, which repeat the
+ * headings, and note definitions are skipped, while the contents
+ * of a note are visited at its first reference, recorded in seen
+ */
+func walkContent(list *Node, seen map[*Node]bool, fn func(*Node)) {
+	for ; list != nil; list = list.next {
+		switch list.key {
+		case TOC:
+			continue
+		case NOTE:
+			if list.contents.str != "" || seen[list.children] {
+				continue
+			}
+			seen[list.children] = true
+		}
+		fn(list)
+		switch list.key {
+		case LINK, IMAGE, MEDIA, EMBED:
+			walkContent(list.contents.link.label, seen, fn)
+		}
+		walkContent(list.children, seen, fn)
+	}
+}
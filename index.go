@@ -0,0 +1,47 @@
+package markdown
+
+// Index terms.
+
+import (
+	"strconv"
+)
+
+// An IndexTerm describes an occurrence of a term marked using
+// the Index extension.
+type IndexTerm struct {
+	Term string
+	Line int    // line number of the top-level block containing the term
+	ID   string // id of the anchor the HTML writer places at the term, without IDPrefix
+}
+
+// IndexTerms returns the index terms of the document, in the
+// order of their occurrence.
+func (d *Document) IndexTerms() []IndexTerm {
+	var terms []IndexTerm
+	seen := make(map[*Node]bool)
+	for _, tree := range d.blocks {
+		walkIndexTerms(tree, seen, func(el *Node) {
+			terms = append(terms, IndexTerm{Term: el.contents.str, Line: tree.line, ID: indexTermID(len(terms) + 1)})
+		})
+	}
+	return terms
+}
+
+/* walkIndexTerms - calls fn for each index term in a list of
+ * blocks, see walkContent; this is the numbering shared by
+ * IndexTerms and the HTML writer
+ */
+func walkIndexTerms(list *Node, seen map[*Node]bool, fn func(*Node)) {
+	walkContent(list, seen, func(el *Node) {
+		if el.key == INDEXTERM {
+			fn(el)
+		}
+	})
+}
+
+/* indexTermID - returns the id of the n-th index term
+ * of a document, counting from 1
+ */
+func indexTermID(n int) string {
+	return "idx" + strconv.Itoa(n)
+}
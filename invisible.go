@@ -0,0 +1,54 @@
+package markdown
+
+// Handling of invisible characters.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policies for invisible formatting characters in text,
+// see HTMLOptions.Invisible.
+const (
+	InvisibleKeep  = iota // characters are output unchanged
+	InvisibleStrip        // characters are removed
+	InvisibleShow         // characters are replaced by a visible notation, like <U+202E>
+)
+
+/* isInvisible - returns true for zero-width characters,
+ * and controls of the bidirectional algorithm, which may
+ * be used to make text appear different from what it is
+ */
+func isInvisible(r rune) bool {
+	switch {
+	case r >= 0x200B && r <= 0x200F: /* zero-width space, non-joiner, joiner; LRM, RLM */
+	case r >= 0x202A && r <= 0x202E: /* bidi embeddings and overrides */
+	case r >= 0x2060 && r <= 0x2064: /* word joiner, invisible operators */
+	case r >= 0x2066 && r <= 0x2069: /* bidi isolates */
+	case r == 0x061C: /* Arabic letter mark */
+	case r == 0x180E: /* Mongolian vowel separator */
+	case r == 0xFEFF: /* zero-width no-break space */
+	default:
+		return false
+	}
+	return true
+}
+
+/* applyInvisible - strips invisible characters from s, or
+ * replaces them by a visible notation, according to policy
+ */
+func applyInvisible(s string, policy int) string {
+	if policy == InvisibleKeep || strings.IndexFunc(s, isInvisible) == -1 {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case !isInvisible(r):
+			b.WriteRune(r)
+		case policy == InvisibleShow:
+			fmt.Fprintf(&b, "<U+%04X>", r)
+		}
+	}
+	return b.String()
+}
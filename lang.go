@@ -0,0 +1,46 @@
+package markdown
+
+// Language annotations.
+
+import (
+	"regexp"
+)
+
+var langAttr = regexp.MustCompile(`^\{lang=([A-Za-z]{2,8}(-[A-Za-z0-9]{1,8})*)\}$`)
+
+// Key of the language attached to a node.
+type langKey struct{}
+
+// Lang returns the language of a paragraph or heading, as set
+// using the Lang extension, like "de" or "en-GB", or an empty
+// string.
+func (n *Node) Lang() string {
+	s, _ := n.Data(langKey{}).(string)
+	return s
+}
+
+/* splitLang - if a paragraph or heading ends with an attribute like
+ * {lang=de}, separated by a space or line break, the attribute
+ * is removed from the inlines, and the language attached to
+ * the block
+ */
+func splitLang(block *Node) {
+	var sep **Node /* pointer to the last separator */
+	for p := &block.children; *p != nil; p = &(*p).next {
+		switch (*p).key {
+		case SPACE, LINEBREAK:
+			if (*p).next != nil {
+				sep = p
+			}
+		}
+	}
+	if sep == nil {
+		return
+	}
+	m := langAttr.FindStringSubmatch(inlineText((*sep).next))
+	if m == nil {
+		return
+	}
+	*sep = nil
+	block.SetData(langKey{}, m[1])
+}
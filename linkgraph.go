@@ -0,0 +1,69 @@
+package markdown
+
+// Link graphs.
+
+import (
+	"net/url"
+)
+
+// A LinkGraph describes the outbound links of a document, and
+// the anchors it defines, so that links between the documents
+// of a corpus, like a wiki, can be determined.
+type LinkGraph struct {
+	Links   []Link   // targets of links and images, resolved against a base URL
+	Anchors []string // ids of headings, see Heading
+}
+
+// LinkGraph returns the links and anchors of the document. URLs
+// are resolved against base, typically the URL of the document
+// itself, so that relative links, and links to fragments like
+// "#intro", become absolute. If base is nil, or a URL cannot be
+// parsed, it is kept as written. URLs resolving to the same
+// target are merged. Ids of headings are derived using the
+// parser's Extensions.Slugify.
+func (d *Document) LinkGraph(base *url.URL) LinkGraph {
+	var g LinkGraph
+	var links []Link
+	var headings []Heading
+	d.Render(LinksTo(&links))
+	d.Render(HeadingsToSlugify(&headings, d.slugify))
+
+	index := make(map[string]int)
+	for _, l := range links {
+		target := l.URL
+		if u, err := url.Parse(target); err == nil && base != nil {
+			target = base.ResolveReference(u).String()
+		}
+		i, ok := index[target]
+		if !ok {
+			i = len(g.Links)
+			index[target] = i
+			g.Links = append(g.Links, Link{URL: target})
+		}
+		g.Links[i].Lines = mergeLines(g.Links[i].Lines, l.Lines)
+	}
+	for _, h := range headings {
+		g.Anchors = append(g.Anchors, h.ID)
+	}
+	return g
+}
+
+/* mergeLines - merges two sorted lists of line numbers,
+ * dropping duplicates
+ */
+func mergeLines(a, b []int) []int {
+	var m []int
+	for len(a) != 0 || len(b) != 0 {
+		var n int
+		switch {
+		case len(b) == 0 || len(a) != 0 && a[0] <= b[0]:
+			n, a = a[0], a[1:]
+		default:
+			n, b = b[0], b[1:]
+		}
+		if len(m) == 0 || m[len(m)-1] != n {
+			m = append(m, n)
+		}
+	}
+	return m
+}
@@ -0,0 +1,63 @@
+package markdown
+
+// Link extraction.
+
+// A Link describes a URL referenced by a document,
+// either by a link, or by an image.
+type Link struct {
+	URL   string
+	Lines []int // line numbers of the top-level blocks referencing the URL
+}
+
+type linkCollector struct {
+	links *[]Link
+	index map[string]int
+	seen  map[*Node]bool /* see walkContent */
+}
+
+// LinksTo returns a Formatter that, instead of printing the
+// document, appends each unique URL found in links and images
+// to the slice pointed to by links. This allows, for instance,
+// to check whether link targets are alive before the document
+// is rendered. The results may be fed back into rendering
+// using HTMLOptions.LinkClass:
+//
+//	var links []markdown.Link
+//	p.Markdown(bytes.NewReader(doc), markdown.LinksTo(&links))
+//	dead := check(links)
+//	p.Markdown(bytes.NewReader(doc), markdown.ToHTMLOpt(w, &markdown.HTMLOptions{
+//		LinkClass: func(url string) string {
+//			if dead[url] {
+//				return "dead-link"
+//			}
+//			return ""
+//		},
+//	}))
+func LinksTo(links *[]Link) Formatter {
+	return &linkCollector{links: links, index: make(map[string]int), seen: make(map[*Node]bool)}
+}
+
+func (f *linkCollector) FormatBlock(tree *Node) {
+	line := tree.line
+	walkContent(tree, f.seen, func(el *Node) {
+		if el.key != LINK && el.key != IMAGE && el.key != MEDIA && el.key != EMBED {
+			return
+		}
+		url := el.contents.link.url
+		i, ok := f.index[url]
+		if !ok {
+			i = len(*f.links)
+			f.index[url] = i
+			*f.links = append(*f.links, Link{URL: url})
+		}
+		l := &(*f.links)[i]
+		if n := len(l.Lines); n == 0 || l.Lines[n-1] != line {
+			l.Lines = append(l.Lines, line)
+		}
+	})
+}
+
+func (f *linkCollector) Finish() {
+	f.index = make(map[string]int)
+	f.seen = make(map[*Node]bool)
+}
@@ -0,0 +1,84 @@
+package markdown
+
+// Accessibility checks.
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Link texts that do not describe the link target.
+var vagueLinkTexts = map[string]bool{
+	"click here": true,
+	"here":       true,
+	"link":       true,
+	"more":       true,
+	"read more":  true,
+	"this":       true,
+}
+
+type linter struct {
+	diags *[]Diagnostic
+	line  int
+	level int // level of the previous heading
+}
+
+// Lint returns a Formatter that, instead of printing the
+// document, checks it for accessibility problems, and appends
+// diagnostics to the slice pointed to by diags. Problems reported
+// are jumps in heading levels, like from H1 to H3, links with
+// empty or non-descriptive text, like "click here", and images
+// without alternative text.
+func Lint(diags *[]Diagnostic) Formatter {
+	return &linter{diags: diags}
+}
+
+func (f *linter) FormatBlock(tree *Node) {
+	f.line = tree.line
+	walkElements(tree, f.check)
+}
+
+func (f *linter) Finish() {
+	f.level = 0
+}
+
+func (f *linter) report(code, format string, arg ...interface{}) {
+	*f.diags = append(*f.diags, Diagnostic{Line: f.line, Code: code, Msg: fmt.Sprintf(format, arg...)})
+}
+
+func (f *linter) check(el *Node) {
+	switch el.key {
+	case H1, H2, H3, H4, H5, H6:
+		level := el.key - H1 + 1
+		if f.level != 0 && level > f.level+1 {
+			f.report("heading-jump", "heading level jumps from %d to %d", f.level, level)
+		}
+		f.level = level
+	case LINK:
+		l := el.contents.link
+		text := strings.ToLower(strings.TrimSpace(inlineText(l.label)))
+		switch {
+		case text == "" && !containsKey(l.label, IMAGE):
+			f.report("empty-link", "link to %q has no text", l.url)
+		case vagueLinkTexts[text]:
+			f.report("vague-link", "link text %q does not describe link to %q", text, l.url)
+		}
+	case IMAGE:
+		l := el.contents.link
+		if strings.TrimSpace(inlineText(l.label)) == "" {
+			f.report("missing-alt", "image %q has no alternative text", l.url)
+		}
+	}
+}
+
+/* containsKey - returns true if an element of the given kind
+ * is part of list
+ */
+func containsKey(list *Node, key int) (found bool) {
+	walkElements(list, func(el *Node) {
+		if el.key == key {
+			found = true
+		}
+	})
+	return
+}
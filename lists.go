@@ -0,0 +1,54 @@
+package markdown
+
+// Support functions for ordered lists.
+
+import (
+	"strconv"
+	"strings"
+)
+
+var romanDigits = map[byte]int{
+	'i': 1, 'v': 5, 'x': 10, 'l': 50, 'c': 100, 'd': 500, 'm': 1000,
+}
+
+/* parseEnumerator - interprets the marker of an ordered list item,
+ * like `3.', `iv)', or `(B)'. The list type is returned as used by
+ * the type attribute of HTML's <ol>: one of '1', 'a', 'A', 'i', 'I'.
+ * A single letter other than `i' is considered alphabetic, longer
+ * sequences of roman digits are considered roman numerals.
+ */
+func parseEnumerator(marker string) (typ byte, n int) {
+	s := strings.TrimRight(strings.TrimLeft(marker, "("), ".)")
+	if s == "" {
+		return '1', 1
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return '1', n
+	}
+	lower := strings.ToLower(s)
+	if len(s) == 1 && lower != "i" {
+		typ = 'a'
+		n = int(lower[0]-'a') + 1
+	} else {
+		typ = 'i'
+		n = romanValue(lower)
+	}
+	if s != lower {
+		typ -= 'a' - 'A'
+	}
+	return
+}
+
+/* romanValue - returns the value of a lower-case roman numeral
+ */
+func romanValue(s string) (n int) {
+	for i := 0; i < len(s); i++ {
+		v := romanDigits[s[i]]
+		if i+1 < len(s) && v < romanDigits[s[i+1]] {
+			n -= v
+		} else {
+			n += v
+		}
+	}
+	return
+}
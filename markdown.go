@@ -19,20 +19,35 @@ package markdown
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"log"
+	"regexp"
 	"strings"
 )
 
-const (
-	// If you get a build error message saying that
-	// parserIfaceVersion_N is undefined, parser.leg.go
-	// either is not present or it is out of date. You should
-	// rebuild it using
-	//	make nuke
-	//	make parser
-	needParserIfaceVersion = parserIfaceVersion_18
-)
+// ParserInterfaceVersion is the version of the interface between
+// the parser generated from parser.leg and the rest of the package,
+// i.e. of the functions, fields, and constants used by the actions
+// of the grammar. It is increased whenever this interface changes,
+// so that tools generating or modifying the grammar can check
+// whether their output is compatible.
+const ParserInterfaceVersion = 20
+
+//go:generate go run gen.go
+
+// If you get a build error message saying that parserIfaceVersion
+// is undefined, or that an index is out of range, parser.leg.go,
+// or one of the files holding the rules, parser.*.go, is either
+// not present or out of date. You should rebuild them using
+//
+//	go generate
+//
+// or using the Makefile
+//
+//	make nuke
+//	make parser
+var _ = [1]struct{}{}[ParserInterfaceVersion-parserIfaceVersion]
 
 // Markdown Extensions.
 type Extensions struct {
@@ -42,11 +57,157 @@ type Extensions struct {
 	FilterStyles bool
 	Strike       bool
 	Dlists       bool
+	TOC          bool // replace a [TOC] or {{TOC}} paragraph by a table of contents
+	Citations    bool // a blockquote's final line starting with "-- " is an attribution
+	FancyLists   bool // enumerators like a., iv), or (B) in ordered lists
+	LaxSublists  bool // sublists may be indented by less than four spaces
+	Lang         bool // a trailing {lang=de} sets the language of a paragraph or heading
+	Index        bool // [[term]] and \index{term} mark index terms, see Document.IndexTerms
+	Spoilers     bool // ||text|| and >!text!< hide text until it is revealed
+	Containers   bool // ::: name {attributes} ... ::: fences a CONTAINER block
+	Comments     bool // lines starting with %% or // are COMMENT elements, which are not printed
+	Media        bool // images with a video or audio URL, like .mp4 or .mp3, are MEDIA elements
+	Examples     bool // (@) and (@label) items are numbered across the document; (@label) in text refers to them
+	GridTables   bool // tables framed by +---+ borders, whose cells may hold blocks, like lists or code
+
+	// If BlocksOnly is set, only the block structure of a document
+	// is recognized; the text of paragraphs, headings, and the
+	// like is kept as is, without looking for emphasis, links, or
+	// other inline elements. This is faster, and useful for
+	// applications doing their own inline processing.
+	BlocksOnly bool
+
+	// If NoIntraEmphasis is set, asterisks within words, like
+	// in snake*case*words, do not start emphasis, as it is
+	// already the case for underscores.
+	NoIntraEmphasis bool
+
+	// If AllEscapes is set, any ASCII punctuation character, like
+	// ~, ", or ', may be escaped by a backslash, as in CommonMark,
+	// not only those recognized by the original Markdown.
+	AllEscapes bool
+
+	// Further typographic replacements, applied if Smart is set.
+	// They are controlled separately, because they might corrupt
+	// text like code that has not been marked as such.
+	Primes    bool // 5'10" -> 5′10″
+	Arrows    bool // ->, <-, <->, =>, <=> -> →, ←, ↔, ⇒, ⇔
+	Symbols   bool // (c), (r), (tm) -> ©, ®, ™
+	Fractions bool // 1/2, 3/4, ... -> ½, ¾, ...
+
+	// If AllowedHTML is not nil, and FilterHTML is not set,
+	// raw HTML tags not contained in the list, like "br" or "kbd",
+	// are escaped, so that they appear as text in the output.
+	// Attributes of allowed tags that may run scripts, like event
+	// handlers or javascript: URLs, are removed.
+	// If AllowedBlockHTML is not nil, it replaces AllowedHTML
+	// for HTML blocks, so that, for example, <sup> and <abbr> may
+	// be allowed within paragraphs, while blocks like <div> or
+	// <table> are escaped, as with an empty AllowedBlockHTML.
+	// If FilterHTMLBlocks is set, HTML blocks are dropped, while
+	// inline HTML is kept, subject to AllowedHTML.
+	AllowedHTML      []string
+	AllowedBlockHTML []string
+	FilterHTMLBlocks bool
+
+	// How to handle <script> elements, one of ScriptsDefault,
+	// ScriptsDrop, ScriptsPass, or ScriptsCollect. By default,
+	// they are treated like any other raw HTML, so that they are
+	// only removed together with benign markup, by FilterHTML.
+	Scripts int
+
+	// Autolinks like <http://example.org/>. If AutoLinkSchemes
+	// is not nil, only URLs with one of the listed schemes, like
+	// "http" or "https", become links. AutoLinkFilter, if not nil,
+	// may reject a URL, or return a rewritten version. Rejected
+	// autolinks appear as text. If IDNDisplay is set, host names
+	// encoded as punycode are shown in Unicode in the link text.
+	AutoLinkSchemes []string
+	AutoLinkFilter  func(url string) (newURL string, ok bool)
+	IDNDisplay      bool
+
+	// If Tags is set, hashtags like #golang, and mentions like
+	// @alice, become TAG and MENTION elements, see Document.Tags.
+	// A name starts with a letter or an underscore. ResolveTag,
+	// if not nil, is called with the element's key and the name,
+	// without '#' or '@', and returns the URL the element links to.
+	// Matches it rejects appear as text.
+	Tags       bool
+	ResolveTag func(key int, name string) (url string, ok bool)
+
+	// If AutoRefs and AutoRefURL are not nil, matches of AutoRefs
+	// in text, like issue numbers or commit hashes, become links.
+	// A match must not be preceded or followed by a letter or
+	// digit. AutoRefURL is called with the matched text and
+	// returns the URL to link to, or false if it is not a valid
+	// reference. See also DefaultAutoRefs.
+	AutoRefs   *regexp.Regexp
+	AutoRefURL func(ref string) (url string, ok bool)
+
+	// If Embeds is not nil, links to content of the listed
+	// providers, like YouTube videos, become EMBED elements,
+	// written as <iframe> or similar markup in HTML, and as links
+	// by other formatters. Links are recognized if written as
+	// @[name](id), like @[youtube](dQw4w9WgXcQ), or as bare links,
+	// i.e. autolinks forming a paragraph on their own, that the
+	// provider's Match function accepts. See DefaultEmbeds.
+	Embeds []EmbedProvider
+
+	// Numeric character references to code points not allowed
+	// in HTML, like &#0; or &#xD800;, are always reported as
+	// diagnostics. If ReplaceEntities is set, they are replaced
+	// by a reference to U+FFFD, the replacement character.
+	ReplaceEntities bool
+
+	// How tabs inside code blocks are treated. If CodeTabs is 0,
+	// they are expanded to tab stops every four columns, like
+	// anywhere else in the document. If it is KeepTabs, they are
+	// preserved; a positive value expands them to tab stops every
+	// CodeTabs columns, counted from the start of the code. Tabs
+	// outside of lines indented like code are always expanded to
+	// tab stops every four columns, so that CodeTabs does not
+	// change how a document is structured.
+	CodeTabs int
+
+	// If ExactCode is set, white space on otherwise blank lines
+	// within code blocks is preserved. Together with CodeTabs set
+	// to KeepTabs, the contents of a code block, apart from its
+	// indentation, are reproduced byte by byte, including trailing
+	// spaces and carriage returns.
+	ExactCode bool
+
+	// Maximum nesting depth of emphasis, strong, and strike-through
+	// elements. Markers beyond that depth are kept literally, which
+	// avoids excessive backtracking on input like many nested
+	// asterisks. If MaxNesting is 0, DefaultMaxNesting is used;
+	// negative values mean no limit.
+	MaxNesting int
+
+	// Slugify derives the ids of headings listed in a table of
+	// contents from their text. If nil, the GitHub-compatible
+	// function Slugify is used; other platforms, like GitLab or
+	// Hugo, have different rules.
+	Slugify func(string) string
+
+	// How to handle reference labels defined more than once,
+	// one of DupRefFirst (default), DupRefLast, DupRefNone.
+	// In any case, duplicates are reported as diagnostics.
+	DupRefs int
+
+	// How to handle note labels defined more than once,
+	// using the same constants as DupRefs. Duplicates are
+	// reported as diagnostics as well.
+	DupNotes int
 }
 
 type Parser struct {
 	yy           yyParser
 	preformatBuf *bytes.Buffer
+	parallel     int       /* see SetParallel */
+	sub          []*Parser /* parsers of the chunks of a document */
+	stats        ParseStats
+	src          sourceLines /* the top-level block being processed */
+	stop         func() bool /* if not nil, parsing ends after a block for which it returns true */
 }
 
 // NewParser creates an instance of a parser. It can be reused
@@ -68,43 +229,266 @@ func NewParser(x *Extensions) (p *Parser) {
 // method is called, which may, for example, print footnotes.
 // A Formatter can be reused.
 type Formatter interface {
-	FormatBlock(*element)
+	FormatBlock(*Node)
 	Finish()
 }
 
 // Markdown parses input from an io.Reader into a tree, and sends
-// parsed blocks to a Formatter
-func (p *Parser) Markdown(src io.Reader, f Formatter) {
-	s := p.preformat(src)
+// parsed blocks to a Formatter. If the Formatter has an Err method,
+// like the formatters of this package, reporting the first error
+// writing its output, parsing stops once an error has occurred,
+// and the error is returned. Writers like bufio.Writer may report
+// errors not until they are flushed.
+func (p *Parser) Markdown(src io.Reader, f Formatter) error {
+	return p.MarkdownContext(context.Background(), src, f)
+}
 
+// MarkdownContext is like Markdown, but also stops parsing once
+// ctx is done, which is checked between blocks, and returns
+// ctx.Err() then. This allows, for example, HTTP handlers to
+// abandon the work if a client has disconnected. The Formatter's
+// Finish method is called in any case.
+func (p *Parser) MarkdownContext(ctx context.Context, src io.Reader, f Formatter) error {
+	return p.markdown(ctx, p.preformat(src), f)
+}
+
+// MarkdownBytes is like Markdown, but parses data, avoiding
+// the copies made while reading input from an io.Reader. This
+// reduces the memory needed for large documents, especially if
+// data is a memory-mapped file. Data is not retained.
+func (p *Parser) MarkdownBytes(data []byte, f Formatter) error {
+	return p.markdown(context.Background(), p.preformatBytes(data), f)
+}
+
+/* markdown - parses s, as returned by preformat, and sends
+ * the blocks to f, see MarkdownContext
+ */
+func (p *Parser) markdown(ctx context.Context, s string, f Formatter) error {
+	ef, _ := f.(errFormatter)
+	done := ctx.Done()
+	if ef != nil || done != nil {
+		p.stop = func() bool {
+			select {
+			case <-done:
+				return true
+			default:
+			}
+			return ef != nil && ef.Err() != nil
+		}
+	}
+	p.parse(s, f.FormatBlock, false)
+	p.stop = nil
+	f.Finish()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if ef != nil {
+		return ef.Err()
+	}
+	return nil
+}
+
+// A Formatter reporting errors writing its output.
+type errFormatter interface {
+	Formatter
+	Err() error
+}
+
+/* parse - parses s, as returned by preformat, passing each
+ * top-level block to fn. If keep is set, the blocks remain
+ * valid after fn has returned.
+ */
+func (p *Parser) parse(s string, fn func(*Node), keep bool) {
+	p.yy.diags = nil
+	p.stats = ParseStats{}
+	p.yy.state.heap.rewind()
+
+	/* References and notes are collected first, so that the
+	 * blocks of the document may then be parsed in parallel.
+	 */
+	src := sourceLines{lines: strings.Split(s, "\n"), first: 1, next: 1}
 	p.parseRule(ruleReferences, s)
+	p.checkReferences(src)
+	p.yy.indexReferences()
+	p.yy.noteIndex = nil
 	if p.yy.extension.Notes {
 		p.parseRule(ruleNotes, s)
+		p.yy.indexNotes()
+		p.yy.checkNotes(src)
+		p.processNotes(src)
 	}
 	p.yy.state.heap.Reset()
+	if p.stop != nil && p.stop() {
+		return
+	}
+
+	/* If a table of contents, or the numbering of examples, is
+	 * requested, blocks are collected until the whole document
+	 * has been parsed, so that all headings and examples are known.
+	 */
+	toc := p.yy.extension.TOC
+	examples := p.yy.extension.Examples
+	keep = keep || toc || examples
+	var blocks []*Node
+
+	if chunks := p.splitChunks(s); len(chunks) > 1 {
+		blocks = p.parseChunks(chunks)
+	} else {
+		p.parseBlocks(s, 1, func(tree *Node) {
+			if keep {
+				blocks = append(blocks, tree)
+			} else {
+				fn(tree)
+			}
+		}, keep)
+	}
+	if toc {
+		p.makeTOC(blocks)
+	}
+	if examples {
+		p.numberExamples(blocks)
+	}
+	for _, tree := range blocks {
+		fn(tree)
+		if p.stop != nil && p.stop() {
+			break
+		}
+	}
+}
+
+/* processNotes - parses the blocks of the contents of all notes
+ * once, before the document's blocks are parsed. The contents
+ * are shared by all references to a note, which, with SetParallel,
+ * may be parsed concurrently. The lines of the contents are looked
+ * up in src, starting at the line of each note's definition.
+ */
+func (p *Parser) processNotes(src sourceLines) {
+	p.src = src
+	for el := p.yy.notes; el != nil; el = el.next {
+		if el.line > 0 {
+			p.src.next = el.line
+		}
+		p.yy.line = el.line
+		el.children = p.processRawBlocks(el.children, 0)
+		if p.yy.extension.Embeds != nil {
+			p.linkEmbeds(el.children)
+		}
+	}
+	p.yy.breakNoteCycles()
+	p.yy.state.heap.hasGlobals = true
+}
 
+/* parseBlocks - parses the top-level blocks of s, the first line
+ * of which has the given number, passing each to fn
+ */
+func (p *Parser) parseBlocks(s string, line int, fn func(*Node), keep bool) {
 	for {
+		block := s
+		p.yy.line = line + blankLines(block)
 		tree := p.parseRule(ruleDocblock, s)
 		if tree == nil {
 			break
 		}
 		s = p.yy.ResetBuffer("")
-		tree = p.processRawBlocks(tree)
-		f.FormatBlock(tree)
+		block = block[:len(block)-len(s)]
+		tree.line = p.yy.line
+		p.stats.Blocks++
+		p.src = sourceLines{lines: strings.Split(block, "\n"), first: line, next: tree.line}
+		line += strings.Count(block, "\n")
+		tree = p.processRawBlocks(tree, 0)
+		if p.autoRefsEnabled() {
+			p.linkAutoRefs(tree)
+		}
+		if p.yy.extension.Embeds != nil {
+			p.linkEmbeds(tree)
+		}
+		if keep {
+			p.yy.state.heap.hasGlobals = true
+		}
+		fn(tree)
 
 		p.yy.state.heap.Reset()
+		if p.stop != nil && p.stop() {
+			break
+		}
 	}
-	f.Finish()
 }
 
-func (p *Parser) parseRule(rule int, s string) (tree *element) {
+// InlineMarkdown parses s as inline content only, like the text of a
+// paragraph, and writes it to w in HTML format, without surrounding
+// <p> tags. This is useful for titles, captions, or table cells
+// supplied separately. Block structure, like headings or lists, is
+// not recognized; paragraphs separated by blank lines are written
+// on separate lines. As there are no reference definitions, only
+// inline links are resolved.
+func (p *Parser) InlineMarkdown(s string, w Writer) {
+	p.yy.diags = nil
+	p.yy.references = nil
+	p.yy.notes = nil
+	p.yy.refIndex = nil
+	p.yy.noteIndex = nil
+	p.yy.line = 1
+	p.yy.state.heap.rewind()
+
+	f := ToHTML(w).(*htmlOut)
+	s = p.preformat(strings.NewReader(s))
+	for sep := ""; ; sep = "\n" {
+		s = strings.TrimLeft(s, "\r\n")
+		if strings.TrimSpace(s) == "" {
+			break
+		}
+		tree := p.parseRule(ruleInlineDoc, s)
+		s = p.yy.ResetBuffer("")
+		if tree == nil {
+			break
+		}
+		if p.autoRefsEnabled() {
+			p.linkAutoRefs(tree)
+		}
+		if p.yy.extension.Embeds != nil {
+			p.embedLinks(tree)
+		}
+		f.s(sep).elist(tree)
+		p.yy.state.heap.Reset()
+	}
+}
+
+// ParseStats describes the work done by a Markdown or Parse call.
+type ParseStats struct {
+	Blocks int // top-level blocks parsed
+
+	// The contents of list items, blockquotes, and notes are
+	// parsed again, as RAW text, once the enclosing block is
+	// complete. These secondary parses reuse the state of the
+	// parser, as it is done parsing the enclosing block then.
+	RawParses int
+
+	// Parser instances initialized, which is costly. This only
+	// happens the first time parsers for the chunks of a document
+	// are needed, see SetParallel.
+	Inits int
+}
+
+// Stats returns statistics about the previous Markdown or Parse call.
+func (p *Parser) Stats() ParseStats {
+	return p.stats
+}
+
+// Diagnostics returns the problems found during the
+// previous Markdown call, like references to undefined
+// links or notes.
+func (p *Parser) Diagnostics() []Diagnostic {
+	return p.yy.diags
+}
+
+func (p *Parser) parseRule(rule int, s string) (tree *Node) {
 	old := p.yy.ResetBuffer(s)
 	if old != "" && strings.Trim(old, "\r\n ") != "" {
 		log.Fatalln("Buffer not empty", "["+old+"]")
 	}
 	err := p.yy.Parse(rule)
 	switch rule {
-	case ruleDoc, ruleDocblock:
+	case ruleDoc, ruleDocblock, ruleInlineDoc:
 		if err == nil {
 			tree = p.yy.state.tree
 		}
@@ -113,49 +497,236 @@ func (p *Parser) parseRule(rule int, s string) (tree *element) {
 	return
 }
 
+/* blankLines - returns the number of blank lines at the start of s
+ */
+func blankLines(s string) (n int) {
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i == -1 || strings.TrimSpace(s[:i]) != "" {
+			return
+		}
+		s = s[i+1:]
+		n++
+	}
+}
+
 /* process_raw_blocks - traverses an element list, replacing any RAW elements with
  * the result of parsing them as markdown text, and recursing into the children
  * of parent elements.  The result should be a tree of elements without any RAWs.
+ * The depth of lists contained in the element list is set to listDepth.
+ * Blocks parsed from RAW text get the number of the line they start on,
+ * as far as it can be found in the source of the top-level block, see p.src.
  */
-func (p *Parser) processRawBlocks(input *element) *element {
+func (p *Parser) processRawBlocks(input *Node, listDepth int) *Node {
 
 	for current := input; current != nil; current = current.next {
+		depth := listDepth
+		if current.line > 0 {
+			p.src.next = current.line
+		}
+		switch current.key {
+		case BLOCKQUOTE:
+			if p.yy.extension.Citations {
+				p.splitCitation(current)
+			}
+		case BULLETLIST, ORDEREDLIST, DEFINITIONLIST:
+			current.depth = listDepth
+			depth++
+		case PARA, H1, H2, H3, H4, H5, H6:
+			if p.yy.extension.Lang {
+				splitLang(current)
+			}
+		case NOTE:
+			if current.contents.link != nil {
+				/* the contents of a referenced note have been
+				 * processed by processNotes
+				 */
+				continue
+			}
+		}
+		end := 0
 		if current.key == RAW {
 			/* \001 is used to indicate boundaries between nested lists when there
 			 * is no blank line.  We split the string by \001 and parse
 			 * each chunk separately.
 			 */
+			var line int
+			line, end = p.src.find(current.contents.str)
 			current.key = LIST
 			current.children = nil
 			listEnd := &current.children
 			for _, contents := range strings.Split(current.contents.str, "\001") {
-				if list := p.parseRule(ruleDoc, contents); list != nil {
+				p.stats.RawParses++
+				if list := p.parseRaw(contents, line); list != nil {
 					*listEnd = list
 					for list.next != nil {
 						list = list.next
 					}
 					listEnd = &list.next
 				}
+				if line > 0 {
+					line += strings.Count(contents, "\n")
+				}
 			}
 			current.contents.str = ""
 		}
 		if current.children != nil {
-			current.children = p.processRawBlocks(current.children)
+			current.children = p.processRawBlocks(current.children, depth)
+		}
+		if end > 0 {
+			p.src.next = end
 		}
 	}
 	return input
 }
 
+/* parseRaw - parses the blocks of RAW text starting at the given
+ * line, if it is known, and returns them as a list
+ */
+func (p *Parser) parseRaw(s string, line int) (list *Node) {
+	tail := &list
+	for {
+		text := s
+		if line > 0 {
+			p.yy.line = line + blankLines(text)
+		}
+		block := p.parseRule(ruleDocblock, s)
+		if block == nil {
+			break
+		}
+		s = p.yy.ResetBuffer("")
+		if line > 0 {
+			block.line = p.yy.line
+			line += strings.Count(text[:len(text)-len(s)], "\n")
+		}
+		*tail = block
+		tail = &block.next
+	}
+	return
+}
+
+/* sourceLines - the lines of a top-level block, in which the
+ * line numbers of the RAW texts it contains are looked up
+ */
+type sourceLines struct {
+	lines []string
+	first int /* number of the first line */
+	next  int /* number of the line where the search for the next RAW text starts */
+}
+
+/* find - returns the number of the line where raw text starts,
+ * searching from s.next, and the number of the line following
+ * its last non-blank line, or zeros, if it is not found. Each
+ * line of the text is the end of a source line, as only list
+ * markers, indentation, or '>' have been removed from them.
+ */
+func (s *sourceLines) find(raw string) (start, end int) {
+	lines := strings.Split(strings.Replace(raw, "\001", "", -1), "\n")
+	first, last := -1, -1
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			if first == -1 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first == -1 {
+		return 0, 0
+	}
+	j := s.next - s.first
+	if j < 0 {
+		j = 0
+	}
+	for ; j < len(s.lines); j++ {
+		if strings.TrimSpace(s.lines[j]) != "" && strings.HasSuffix(s.lines[j], lines[first]) {
+			start = s.first + j - first
+			return start, start + last + 1
+		}
+	}
+	return 0, 0
+}
+
+/* findDef - returns the number of the line where a reference or
+ * note definition starts, searching from s.next, and continues the
+ * next search behind it. Match is called for each line starting
+ * with '[' after up to three spaces, joined with up to two following
+ * non-blank lines, as a definition may span lines. Zero is returned,
+ * if no line matches.
+ */
+func (s *sourceLines) findDef(match func(text string) bool) int {
+	j := s.next - s.first
+	if j < 0 {
+		j = 0
+	}
+	for ; j < len(s.lines); j++ {
+		t := strings.TrimLeft(s.lines[j], " ")
+		if len(s.lines[j])-len(t) > 3 || !strings.HasPrefix(t, "[") {
+			continue
+		}
+		end := j + 1
+		for end < len(s.lines) && end < j+3 && strings.TrimSpace(s.lines[end]) != "" {
+			end++
+		}
+		if match(strings.Join(append([]string{t}, s.lines[j+1:end]...), "\n")) {
+			s.next = s.first + j + 1
+			return s.first + j
+		}
+	}
+	return 0
+}
+
+/* splitCitation - if the last line of a blockquote's raw contents
+ * starts with "-- ", it is removed from the raw text, parsed separately,
+ * and appended to the blockquote's children as CITATIONLINE element.
+ */
+func (p *Parser) splitCitation(quote *Node) {
+	raw := quote.children
+	if raw == nil || raw.key != RAW {
+		return
+	}
+	s := strings.TrimRight(raw.contents.str, "\n ")
+	i := strings.LastIndex(s, "\n")
+	if i == -1 {
+		return
+	}
+	line := s[i+1:]
+	if !strings.HasPrefix(line, "-- ") {
+		return
+	}
+	raw.contents.str = s[:i+1] + "\n"
+
+	cite := p.yy.mkElem(CITATIONLINE)
+	if block := p.parseRule(ruleDoc, strings.TrimSpace(line[3:])+"\n"); block != nil {
+		cite.children = block.children
+	}
+	raw.next = cite
+}
+
 const (
 	TABSTOP = 4
 )
 
+const DefaultMaxNesting = 16
+
+const KeepTabs = -1 // value of Extensions.CodeTabs
+
 /* preformat - allocate and copy text buffer while
  * performing tab expansion.
+ *
+ * If tabs in code blocks are not to be expanded to the
+ * default tab stops, tabs are kept only where they may be part
+ * of a code block: within the indentation of a line, if they
+ * start at a tab stop, so that indentation is recognized
+ * properly, and after the indentation of lines indented by four
+ * columns or more, not counting blockquote markers. Other tabs,
+ * like those following a '>', or within text, are expanded as
+ * usual, so that the structure of the document does not depend
+ * on CodeTabs.
  */
 func (p *Parser) preformat(r io.Reader) (s string) {
-	charstotab := TABSTOP
 	buf := make([]byte, 32768)
+	t := tabExpander{charstotab: TABSTOP, keep: p.yy.extension.CodeTabs != 0, lead: true}
 
 	b := p.preformatBuf
 	b.Reset()
@@ -164,29 +735,148 @@ func (p *Parser) preformat(r io.Reader) (s string) {
 		if err != nil {
 			break
 		}
-		i0 := 0
-		for i, c := range buf[:n] {
-			switch c {
-			case '\t':
-				b.Write(buf[i0:i])
-				for ; charstotab > 0; charstotab-- {
-					b.WriteByte(' ')
-				}
-				i0 = i + 1
-			case '\n':
-				b.Write(buf[i0 : i+1])
-				i0 = i + 1
-				charstotab = TABSTOP
-			default:
-				charstotab--
+		t.expand(b, buf[:n])
+	}
+
+	b.WriteString("\n\n")
+	return b.String()
+}
+
+/* preformatBytes - like preformat, but reads the input from
+ * data, building the result in place, without intermediate
+ * buffers
+ */
+func (p *Parser) preformatBytes(data []byte) string {
+	t := tabExpander{charstotab: TABSTOP, keep: p.yy.extension.CodeTabs != 0, lead: true}
+
+	var b strings.Builder
+	b.Grow(len(data) + 2)
+	t.expand(&b, data)
+	b.WriteString("\n\n")
+	return b.String()
+}
+
+/* tabExpander - expands tabs to spaces, keeping
+ * its state across the chunks of input it is fed
+ */
+type tabExpander struct {
+	charstotab int
+	keep       bool /* keep tabs that may be part of a code block */
+	lead       bool /* within the indentation of a line, or its blockquote markers */
+	quoted     bool /* a blockquote marker has been seen within the indentation */
+	indent     int  /* columns of white space since the line start or the last blockquote marker */
+	code       bool /* the line is indented like a code block's line */
+}
+
+func (t *tabExpander) expand(b interface {
+	Write([]byte) (int, error)
+	WriteByte(byte) error
+}, buf []byte) {
+	i0 := 0
+	for i, c := range buf {
+		switch c {
+		case '\t':
+			keep := t.code
+			if t.lead {
+				t.indent += t.charstotab
+				keep = t.keep && !t.quoted && t.charstotab == TABSTOP
 			}
-			if charstotab == 0 {
-				charstotab = TABSTOP
+			if keep {
+				t.charstotab = TABSTOP
+				break
 			}
+			b.Write(buf[i0:i])
+			for ; t.charstotab > 0; t.charstotab-- {
+				b.WriteByte(' ')
+			}
+			i0 = i + 1
+		case '\n':
+			b.Write(buf[i0 : i+1])
+			i0 = i + 1
+			t.charstotab = TABSTOP
+			t.lead = true
+			t.quoted = false
+			t.indent = 0
+			t.code = false
+		case ' ':
+			t.charstotab--
+			t.indent++
+		case '>':
+			t.charstotab--
+			if t.lead && t.codeIndent() < TABSTOP {
+				t.quoted = true
+				t.indent = 0
+				break
+			}
+			t.endLead()
+		default:
+			t.charstotab--
+			t.endLead()
+		}
+		if t.charstotab == 0 {
+			t.charstotab = TABSTOP
 		}
-		b.Write(buf[i0:n])
 	}
+	b.Write(buf[i0:])
+}
 
-	b.WriteString("\n\n")
+/* codeIndent - returns the indentation of a line's contents,
+ * as far as it has been seen, without the space that may follow
+ * a blockquote marker
+ */
+func (t *tabExpander) codeIndent() int {
+	if t.quoted && t.indent > 0 {
+		return t.indent - 1
+	}
+	return t.indent
+}
+
+/* endLead - notes the end of a line's indentation
+ */
+func (t *tabExpander) endLead() {
+	if t.lead {
+		t.lead = false
+		t.code = t.keep && t.codeIndent() >= TABSTOP
+	}
+}
+
+/* stripIndent - removes the indentation of a code block's line,
+ * as matched by Indent, or any shorter run of spaces
+ */
+func stripIndent(s string) string {
+	if strings.HasPrefix(s, "\t") {
+		return s[1:]
+	}
+	i := 0
+	for i < TABSTOP && i < len(s) && s[i] == ' ' {
+		i++
+	}
+	return s[i:]
+}
+
+/* expandTabs - replaces tabs by spaces up to the next
+ * multiple of width columns
+ */
+func expandTabs(s string, width int) string {
+	if strings.IndexByte(s, '\t') == -1 {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		switch r {
+		case '\t':
+			n := width - col%width
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		case '\n':
+			col = 0
+			b.WriteByte('\n')
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
 	return b.String()
 }
@@ -2,11 +2,17 @@ package markdown
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"unicode/utf8"
 )
 
 // for each pair of .text/.html files in the given subdirectory
@@ -102,3 +108,2574 @@ func TestTrailingWhitespaceBug(t *testing.T) {
 	p := NewParser(nil)
 	p.Markdown(strings.NewReader(input), ToHTML(&buf))
 }
+
+func TestTOC(t *testing.T) {
+	const input = `# Intro
+
+[TOC]
+
+## Getting *started*
+
+### Details
+
+## Getting started
+
+# Other
+`
+	const expected = `<h1 id="intro">Intro</h1>
+
+<div class="toc">
+<ul>
+<li><a href="#intro">Intro</a>
+
+<ul>
+<li><a href="#getting-started">Getting <em>started</em></a>
+
+<ul>
+<li><a href="#details">Details</a></li>
+</ul></li>
+<li><a href="#getting-started-1">Getting started</a></li>
+</ul></li>
+<li><a href="#other">Other</a></li>
+</ul>
+</div>
+
+<h2 id="getting-started">Getting <em>started</em></h2>
+
+<h3 id="details">Details</h3>
+
+<h2 id="getting-started-1">Getting started</h2>
+
+<h1 id="other">Other</h1>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{TOC: true})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestPermalinks(t *testing.T) {
+	const input = "# Intro\n\n## Intro\n"
+	tests := []struct {
+		opt      HTMLOptions
+		expected string
+	}{
+		{HTMLOptions{Permalinks: true},
+			`<h1 id="intro">Intro <a class="anchor" href="#intro">¶</a></h1>
+
+<h2 id="intro-1">Intro <a class="anchor" href="#intro-1">¶</a></h2>
+`},
+		{HTMLOptions{Permalinks: true, PermalinkSymbol: "#", PermalinkBefore: true},
+			`<h1 id="intro"><a class="anchor" href="#intro">#</a> Intro</h1>
+
+<h2 id="intro-1"><a class="anchor" href="#intro-1">#</a> Intro</h2>
+`},
+	}
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	for i := range tests {
+		tt := &tests[i]
+		buf.Reset()
+		p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &tt.opt))
+		if s := buf.String(); s != tt.expected {
+			t.Errorf("#%d: unexpected output:\n%s", i, s)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	for in, out := range map[string]string{
+		"Getting started":    "getting-started",
+		"A -- B":             "a----b",
+		"What's new? (v1.0)": "whats-new-v10",
+		"snake_case Über":    "snake_case-über",
+		"!!!":                "section",
+	} {
+		if s := Slugify(in); s != out {
+			t.Errorf("Slugify(%q) = %q, want %q", in, s, out)
+		}
+	}
+
+	const input = "[TOC]\n\n# Intro Text\n\n## Intro Text\n"
+	upper := func(s string) string { return strings.ToUpper(strings.Replace(s, " ", "_", -1)) }
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{TOC: true, Slugify: upper})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if s := buf.String(); !strings.Contains(s, `<a href="#INTRO_TEXT-1">`) || !strings.Contains(s, `<h2 id="INTRO_TEXT-1">`) {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+	buf.Reset()
+	p = NewParser(nil)
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{Permalinks: true, Slugify: upper}))
+	if s := buf.String(); !strings.Contains(s, `<h1 id="INTRO_TEXT">`) {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestHTMLClasses(t *testing.T) {
+	const input = "# Title\n\n> quote\n\n* item\n\n---\n"
+	const expected = `<h1 class="title">Title</h1>
+
+<blockquote class="quote">
+<p>quote</p>
+</blockquote>
+
+<ul class="list">
+<li>item</li>
+</ul>
+
+<hr class="rule" />
+`
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{
+		Classes: map[int]string{
+			H1:         "title",
+			BLOCKQUOTE: "quote",
+			BULLETLIST: "list",
+			HRULE:      "rule",
+		},
+	}))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestCitations(t *testing.T) {
+	const input = "> To be, or not\n> to be.\n>\n> -- *William* Shakespeare\n"
+	const expected = `<figure>
+<blockquote>
+<p>To be, or not
+to be.</p>
+</blockquote>
+<figcaption><cite><em>William</em> Shakespeare</cite></figcaption>
+</figure>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{Citations: true})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestFancyLists(t *testing.T) {
+	const input = "iii. three\niv. four\n\ntext\n\n(B) b\n(C) c\n"
+	const expected = `<ol type="i" start="3">
+<li>three</li>
+<li>four</li>
+</ol>
+
+<p>text</p>
+
+<ol type="A" start="2">
+<li>b</li>
+<li>c</li>
+</ol>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{FancyLists: true})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestListValues(t *testing.T) {
+	const input = "1. one\n5. five\n6. six\n9. nine\n"
+	const expected = `<ol>
+<li>one</li>
+<li value="5">five</li>
+<li>six</li>
+<li value="9">nine</li>
+</ol>
+`
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{ListValues: true}))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+// A Formatter collecting the depths of the lists it encounters.
+type listDepths []int
+
+func (d *listDepths) FormatBlock(tree *Node) {
+	for el := tree; el != nil; el = el.next {
+		switch el.key {
+		case BULLETLIST, ORDEREDLIST:
+			*d = append(*d, el.depth)
+		}
+		d.FormatBlock(el.children)
+	}
+}
+func (d *listDepths) Finish() {}
+
+func TestLaxSublists(t *testing.T) {
+	const input = "1. one\n   1. sub\n      * deeper\n2. two\n"
+	const expected = `<ol>
+<li>one
+
+<ol>
+<li>sub
+
+<ul>
+<li>deeper</li>
+</ul></li>
+</ol></li>
+<li>two</li>
+</ol>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{LaxSublists: true})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+
+	var d listDepths
+	p.Markdown(strings.NewReader(input), &d)
+	if fmt.Sprint(d) != "[0 1 2]" {
+		t.Errorf("unexpected list depths: %v", d)
+	}
+}
+
+func TestNoteMultipleRefs(t *testing.T) {
+	const input = "A[^a] and B[^a].\n\n[^a]: Note a.\n"
+	const expected = `<p>A<a class="noteref" id="fnref1" href="#fn1" title="Jump to note 1">[1]</a> and B<a class="noteref" id="fnref1-2" href="#fn1" title="Jump to note 1">[1]</a>.</p>
+
+<hr/>
+<ol id="notes">
+
+<li id="fn1">
+<p>Note a.</p> <a href="#fnref1" title="Jump back to reference 1">[back<sup>1</sup>]</a> <a href="#fnref1-2" title="Jump back to reference 2">[back<sup>2</sup>]</a>
+</li>
+
+</ol>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{Notes: true})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestImages(t *testing.T) {
+	const input = "![A *cat*](cat.png \"Cat\") and ![](dog.png)\n\n* ![x][ref]\n\n[ref]: x.png\n"
+	expected := []Image{
+		{URL: "cat.png", Title: "Cat", Alt: "A cat"},
+		{URL: "dog.png"},
+		{URL: "x.png", Alt: "x"},
+	}
+	var images []Image
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), ImagesTo(&images))
+	if fmt.Sprint(images) != fmt.Sprint(expected) {
+		t.Errorf("unexpected images: %v", images)
+	}
+
+	var missing []Image
+	var buf bytes.Buffer
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{
+		MissingAlt: func(img Image) { missing = append(missing, img) },
+	}))
+	if len(missing) != 1 || missing[0].URL != "dog.png" {
+		t.Errorf("unexpected images missing alt text: %v", missing)
+	}
+}
+
+func TestHeadings(t *testing.T) {
+	const input = "# The *big* `picture`\n\nText.\n\n## Don't -- stop...\n\n> ### Quoted\n\n## Don't -- stop...\n"
+	expected := []Heading{
+		{Level: 1, Text: "The big picture", ID: "the-big-picture", Line: 1},
+		{Level: 2, Text: "Don’t — stop…", ID: "dont--stop", Line: 5},
+		{Level: 3, Text: "Quoted", ID: "quoted", Line: 7},
+		{Level: 2, Text: "Don’t — stop…", ID: "dont--stop-1", Line: 9},
+	}
+	var headings []Heading
+	p := NewParser(&Extensions{Smart: true})
+	p.Markdown(strings.NewReader(input), HeadingsTo(&headings))
+	if fmt.Sprint(headings) != fmt.Sprint(expected) {
+		t.Errorf("unexpected headings: %v", headings)
+	}
+}
+
+func TestHeadingsSlugify(t *testing.T) {
+	const input = "# Intro\n\n## More Text\n"
+	slugify := func(s string) string { return "h-" + strings.ToLower(strings.ReplaceAll(s, " ", "_")) }
+	ids := func(headings []Heading) (s []string) {
+		for _, h := range headings {
+			s = append(s, h.ID)
+		}
+		return
+	}
+
+	/* the ids of headings match those of permalinks */
+	var headings []Heading
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), HeadingsToSlugify(&headings, slugify))
+	var buf bytes.Buffer
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{Permalinks: true, Slugify: slugify}))
+	for _, id := range ids(headings) {
+		if !strings.Contains(buf.String(), `id="`+id+`"`) {
+			t.Errorf("id %q not found in %s", id, buf.String())
+		}
+	}
+	if s := fmt.Sprint(ids(headings)); s != "[h-intro h-more_text]" {
+		t.Errorf("unexpected ids: %s", s)
+	}
+
+	/* with a table of contents, ids are assigned by the parser */
+	headings = nil
+	p = NewParser(&Extensions{TOC: true, Slugify: slugify})
+	p.Markdown(strings.NewReader("[TOC]\n\n"+input), HeadingsTo(&headings))
+	if s := fmt.Sprint(ids(headings)); s != "[h-intro h-more_text]" {
+		t.Errorf("unexpected ids with TOC: %s", s)
+	}
+
+	/* LinkGraph uses the parser's Slugify */
+	doc := NewParser(&Extensions{Slugify: slugify}).Parse(strings.NewReader(input))
+	if s := fmt.Sprint(doc.LinkGraph(nil).Anchors, doc.Clone().LinkGraph(nil).Anchors); s != "[h-intro h-more_text] [h-intro h-more_text]" {
+		t.Errorf("unexpected anchors: %s", s)
+	}
+}
+
+type lineKey struct{}
+
+// A formatter annotating blocks, and one reading the annotations.
+type annotator struct{ next Formatter }
+
+func (f annotator) FormatBlock(tree *Node) {
+	for b := tree; b != nil; b = b.next {
+		b.SetData(lineKey{}, b.line)
+	}
+	f.next.FormatBlock(tree)
+}
+func (f annotator) Finish() { f.next.Finish() }
+
+type annotationReader []interface{}
+
+func (f *annotationReader) FormatBlock(tree *Node) {
+	for b := tree; b != nil; b = b.next {
+		*f = append(*f, b.Data(lineKey{}), b.Data("other"))
+	}
+}
+func (f *annotationReader) Finish() {}
+
+func TestNodeData(t *testing.T) {
+	var r annotationReader
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader("# Head\n\nText\n"), annotator{&r})
+	if s := fmt.Sprint(r); s != "[1 <nil> 3 <nil>]" {
+		t.Errorf("unexpected annotations: %s", s)
+	}
+	var n Node
+	n.SetData(lineKey{}, 1)
+	n.SetData(lineKey{}, nil)
+	if n.Data(lineKey{}) != nil {
+		t.Error("annotation not removed")
+	}
+}
+
+// A formatter recording a trace of Walk calls.
+type walkTracer struct {
+	trace []string
+	done  bool
+}
+
+func (f *walkTracer) FormatBlock(tree *Node) {
+	for b := tree; b != nil && !f.done; b = b.next {
+		f.done = Walk(b, func(n *Node, entering bool) WalkStatus {
+			s := fmt.Sprint(n.key)
+			if n.key == STR {
+				s = n.contents.str
+			}
+			if !entering {
+				s = "/" + s
+			}
+			f.trace = append(f.trace, s)
+			switch {
+			case n.key == BULLETLIST:
+				return SkipChildren
+			case n.contents.str == "f":
+				return Terminate
+			}
+			return GoToNext
+		}) == Terminate
+	}
+}
+func (f *walkTracer) Finish() {}
+
+func TestWalk(t *testing.T) {
+	var w walkTracer
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader("A *b [c](x)*\n\n* e\n\nf g\n"), &w)
+
+	end := func(key int) string { return "/" + fmt.Sprint(key) }
+	expected := fmt.Sprint([]interface{}{
+		PARA, "A", "/A", SPACE, end(SPACE),
+		EMPH, "b", "/b", SPACE, end(SPACE), LINK, "c", "/c", end(LINK), end(EMPH),
+		end(PARA),
+		BULLETLIST, end(BULLETLIST),
+		PARA, "f",
+	})
+	if s := fmt.Sprint(w.trace); s != expected {
+		t.Errorf("unexpected trace:\n%s\nwant\n%s", s, expected)
+	}
+}
+
+// A formatter restructuring blocks before printing them.
+type restructurer struct{ Formatter }
+
+func (f restructurer) FormatBlock(tree *Node) {
+	for b := tree; b != nil; b = b.Next() {
+		if b.Key() != PARA {
+			continue
+		}
+		var next *Node
+		for c := b.FirstChild(); c != nil; c = next {
+			next = c.Next()
+			switch {
+			case c.Key() == LINK:
+				c.SetURL("https://example.org/" + c.URL())
+			case c.Text() == "old":
+				b.ReplaceChild(c, NewNode(EMPH, NewText("new")))
+			case c.Text() == "gone":
+				b.RemoveChild(c)
+			}
+		}
+		b.PrependChild(NewText("> "))
+		b.AppendChild(NewImage("i.png", "", NewText("i")))
+		b.InsertAfter(NewList(BULLETLIST,
+			[]*Node{NewNode(PLAIN, NewLink("x", "X", NewText("a")))},
+			[]*Node{NewNode(PLAIN, NewText("b"))},
+		))
+		b = b.Next()
+	}
+	f.Formatter.FormatBlock(tree)
+}
+
+func TestNodeMutation(t *testing.T) {
+	const expected = `<p>&gt; <a href="https://example.org/x">link</a> <em>new</em> <img src="i.png" alt="i" /></p>
+
+<ul>
+<li><a href="x" title="X">a</a></li>
+<li>b</li>
+</ul>
+`
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader("[link](x) old gone\n"), restructurer{ToHTML(&buf)})
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+
+	n := NewNode(PARA)
+	if n.RemoveChild(NewText("x")) {
+		t.Error("removed a node that is not a child")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("inserting a node with siblings did not panic")
+		}
+	}()
+	a := NewText("a")
+	a.InsertAfter(NewText("b"))
+	n.AppendChild(a)
+}
+
+func TestDocumentClone(t *testing.T) {
+	const input = "A[^1] and [B][] and again[^1].\n\n[^1]: The note.\n\n[B]: /b\n"
+	p := NewParser(&Extensions{Notes: true})
+	doc := p.Parse(strings.NewReader(input))
+
+	var want bytes.Buffer
+	p.Markdown(strings.NewReader(input), ToHTML(&want))
+
+	// Parsing another document must not affect doc.
+	p.Markdown(strings.NewReader("*Other* text[^x].\n\n[^x]: Other note.\n"), ToHTML(new(bytes.Buffer)))
+
+	clone := doc.Clone()
+	for _, b := range clone.Blocks() {
+		Walk(b, func(n *Node, entering bool) WalkStatus {
+			if entering && n.Key() == LINK {
+				n.SetURL("https://example.org" + n.URL())
+			}
+			return GoToNext
+		})
+	}
+
+	var buf bytes.Buffer
+	doc.Render(ToHTML(&buf))
+	if s := buf.String(); s != want.String() {
+		t.Errorf("unexpected output of document:\n%s\nwant:\n%s", s, want.String())
+	}
+	buf.Reset()
+	clone.Render(ToHTML(&buf))
+	expected := strings.Replace(want.String(), `href="/b"`, `href="https://example.org/b"`, 1)
+	if expected == want.String() {
+		t.Fatalf("link not found in output:\n%s", expected)
+	}
+	if s := buf.String(); s != expected || strings.Count(s, "The note.") != 1 {
+		t.Errorf("unexpected output of clone:\n%s", s)
+	}
+}
+
+func TestDocumentDetach(t *testing.T) {
+	const input = "# Head\n\nA [link](/x)[^1], again[^1].\n\n[^1]: Note.\n"
+	p := NewParser(&Extensions{Notes: true})
+	doc := p.Parse(strings.NewReader(input))
+	var want, buf bytes.Buffer
+	doc.Render(ToHTML(&want))
+
+	doc.Detach()
+	inHeap := func(n *Node) bool {
+		for _, row := range p.yy.state.heap.rows {
+			for i := range row {
+				if n == &row[i] {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, b := range doc.Blocks() {
+		Walk(b, func(n *Node, entering bool) WalkStatus {
+			if inHeap(n) {
+				t.Errorf("node of kind %d still allocated by the parser", n.key)
+			}
+			return GoToNext
+		})
+	}
+	doc.Render(ToHTML(&buf))
+	if buf.String() != want.String() {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want.String())
+	}
+}
+
+func TestHeapReuse(t *testing.T) {
+	/* the parser's heap does not grow with the number of documents */
+	input := strings.Repeat("A [link](/x)[^1], *again*[^1].\n\n", 100) + "[^1]: Note.\n"
+	p := NewParser(&Extensions{Notes: true})
+	var rows []int
+	for i := 0; i < 50; i++ {
+		p.Parse(strings.NewReader(input)).Detach()
+		p.Markdown(strings.NewReader(input), ToHTML(new(bytes.Buffer)))
+		if i == 1 || i == 49 {
+			rows = append(rows, len(p.yy.state.heap.rows))
+		}
+	}
+	if rows[1] != rows[0] {
+		t.Errorf("heap grows from %d to %d rows", rows[0], rows[1])
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	src := []byte("*text*\n")
+	key := CacheKey(src, nil, nil)
+	if len(key) != 64 {
+		t.Errorf("unexpected key %q", key)
+	}
+	same := []string{
+		CacheKey(src, &Extensions{}, &HTMLOptions{}),
+		CacheKey(src, &Extensions{MaxNesting: DefaultMaxNesting}, &HTMLOptions{PermalinkSymbol: "¶", FirstNote: 1}),
+		CacheKey(src, nil, &HTMLOptions{Text: strings.ToUpper, Classes: map[int]string{}}),
+	}
+	for i, k := range same {
+		if k != key {
+			t.Errorf("key %d differs", i)
+		}
+	}
+	classes := func() map[int]string {
+		return map[int]string{PARA: "p", BLOCKQUOTE: "q", LISTITEM: "li", H1: "h"}
+	}
+	differ := []string{
+		CacheKey([]byte("*text*"), nil, nil),
+		CacheKey(src, &Extensions{Smart: true}, nil),
+		CacheKey(src, &Extensions{AllowedHTML: []string{"br"}}, nil),
+		CacheKey(src, &Extensions{AllowedBlockHTML: []string{}}, nil),
+		CacheKey(src, &Extensions{Embeds: DefaultEmbeds}, nil),
+		CacheKey(src, nil, &HTMLOptions{Permalinks: true}),
+		CacheKey(src, nil, &HTMLOptions{URLs: &URLPolicy{}}),
+		CacheKey(src, nil, &HTMLOptions{Classes: classes()}),
+	}
+	for i, k := range differ {
+		if k == key {
+			t.Errorf("key %d does not differ", i)
+		}
+	}
+	if CacheKey(src, nil, &HTMLOptions{Classes: classes()}) != differ[len(differ)-1] {
+		t.Error("key depends on map order")
+	}
+}
+
+func TestParseExtensions(t *testing.T) {
+	x, err := ParseExtensions("smart, notes,TOC,strike,-strike,codetabs=8,maxnesting=-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !x.Smart || !x.Notes || !x.TOC || x.Strike || x.CodeTabs != 8 || x.MaxNesting != -1 {
+		t.Errorf("unexpected extensions: %+v", x)
+	}
+	const spec = "smart,notes,toc,codetabs=8,maxnesting=-1"
+	if s := x.String(); s != spec {
+		t.Errorf("unexpected specification: %q", s)
+	}
+	if y, _ := ParseExtensions(spec); y.String() != spec {
+		t.Errorf("specification does not round-trip: %q", y.String())
+	}
+	for _, bad := range []string{"bogus", "smart=1", "codetabs", "codetabs=x", "allowedhtml", "-codetabs=2"} {
+		if _, err := ParseExtensions(bad); err == nil {
+			t.Errorf("no error for %q", bad)
+		}
+	}
+}
+
+func TestDocumentTitle(t *testing.T) {
+	p := NewParser(&Extensions{Smart: true})
+	for input, title := range map[string]string{
+		"Intro\n\n> # Quoted\n\nThe *real* \"title\"\n====\n\n# Other\n": "The real “title”",
+		"## Sub\n\nText\n": "",
+	} {
+		if s := p.Parse(strings.NewReader(input)).Title(); s != title {
+			t.Errorf("unexpected title %q, want %q", s, title)
+		}
+	}
+}
+
+func TestFrontMatterTitle(t *testing.T) {
+	const body = "# Heading\n\nText\n"
+	p := NewParser(nil)
+	for input, title := range map[string]string{
+		"---\nTitle: \"From meta\"\nsmart: true\n---\n" + body: "From meta",
+		"---\ntitle:\n...\n" + body:                            "Heading",
+		"---\ntitle: unterminated\n" + body:                    "Heading",
+		body:                                                   "Heading",
+	} {
+		meta, rest := FrontMatter(input)
+		if strings.Count(rest, "\n") != strings.Count(input, "\n") || !strings.HasSuffix(rest, body) {
+			t.Errorf("unexpected rest %q", rest)
+		}
+		if s := p.Parse(strings.NewReader(rest)).TitleFrom(meta); s != title {
+			t.Errorf("unexpected title %q, want %q", s, title)
+		}
+	}
+	if meta, _ := FrontMatter("---\nsmart: 'yes'\n---\n"); len(meta) != 1 || meta["smart"] != "yes" {
+		t.Errorf("unexpected front matter %q", meta)
+	}
+}
+
+func TestOpenGraph(t *testing.T) {
+	const input = "# Trip\n\n* ![Map](map.png)\n\nWe *went*\n  to [the sea][].\n\n![Beach](beach.jpg)\n\n[the sea]: http://sea/\n"
+	og := NewParser(nil).Parse(strings.NewReader(input)).OpenGraph()
+	expected := OpenGraph{Title: "Trip", Description: "We went to the sea.", Image: "map.png"}
+	if og != expected {
+		t.Errorf("unexpected metadata: %+v", og)
+	}
+}
+
+func TestNotePopoversNested(t *testing.T) {
+	const input = "Text[^a] and[^b].\n\n[^a]: Outer[^c].\n\n[^b]: B.\n\n[^c]: Inner.\n"
+	var buf bytes.Buffer
+	NewParser(&Extensions{Notes: true}).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{NotePopovers: true}))
+	s := buf.String()
+	const popover = `data-note="&lt;p&gt;Outer&lt;a class=&quot;noteref&quot; href=&quot;#fn2&quot; title=&quot;Jump to note 2&quot;&gt;[2]&lt;/a&gt;.&lt;/p&gt;">[1]</a>`
+	if !strings.Contains(s, popover) {
+		t.Errorf("unexpected popover of a nested note:\n%s", s)
+	}
+	if n := strings.Count(s, `id="fnref2"`); n != 1 {
+		t.Errorf("fnref2 used %d times:\n%s", n, s)
+	}
+	if !strings.Contains(s, `<li id="fn3">`+"\n<p>B.</p>") {
+		t.Errorf("unexpected numbering of notes:\n%s", s)
+	}
+}
+
+func TestNotePopovers(t *testing.T) {
+	const input = "A[^1].\n\n[^1]: The \"*note*\" & more.\n"
+	const expected = `<p>A<a class="noteref" id="fnref1" href="#fn1" title="Jump to note 1" data-note="&lt;p&gt;The &amp;quot;&lt;em&gt;note&lt;/em&gt;&amp;quot; &amp;amp; more.&lt;/p&gt;">[1]</a>.</p>`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{Notes: true})
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{NotePopovers: true}))
+	if s := buf.String(); !strings.HasPrefix(s, expected+"\n") || strings.Count(s, "The &quot;<em>note</em>&quot;") != 1 {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestNoteMarkers(t *testing.T) {
+	const input = "A[^note-label], b^[inline][^x].\n\n[^note-label]: One.\n\n[^x]: Two.\n"
+	for _, tc := range []struct {
+		marker func(int, string) string
+		refs   string
+		attrs  string
+	}{
+		{NoteLabels, "[note-label] [2] [x]", "[note-label] [2] [x]"},
+		{NoteSymbols, "* † ‡", "* † ‡"},
+	} {
+		var buf bytes.Buffer
+		p := NewParser(&Extensions{Notes: true})
+		p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{NoteMarker: tc.marker}))
+		s := buf.String()
+		var refs, attrs []string
+		for _, m := range regexp.MustCompile(`title="Jump to note \d">([^<]*)</a>`).FindAllStringSubmatch(s, -1) {
+			refs = append(refs, m[1])
+		}
+		for _, m := range regexp.MustCompile(`<li id="fn\d" data-marker="([^"]*)">`).FindAllStringSubmatch(s, -1) {
+			attrs = append(attrs, m[1])
+		}
+		if strings.Join(refs, " ") != tc.refs || strings.Join(attrs, " ") != tc.attrs {
+			t.Errorf("unexpected output:\n%s", s)
+		}
+	}
+	if s := NoteSymbols(8, ""); s != "††" {
+		t.Errorf("unexpected 8th symbol: %q", s)
+	}
+}
+
+func TestSectionNotes(t *testing.T) {
+	const input = "# One\n\nA[^a] b[^b].\n\n## Sub\n\nC[^a].\n\n# Two\n\nD[^a].\n\n[^a]: Note a.\n\n[^b]: Note b.\n"
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{Notes: true})
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{SectionNotes: 1}))
+	s := buf.String()
+	i := strings.Index(s, "<h1>Two</h1>")
+	if i == -1 || strings.Count(s[:i], "<li id=") != 2 || !strings.Contains(s[:i], `<ol id="notes">`) ||
+		!strings.Contains(s[i:], `<ol id="notes2" start="3">`+"\n\n"+`<li id="fn3">`) || strings.Count(s[i:], "<li id=") != 1 {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestQuoteNotes(t *testing.T) {
+	const input = "> Quoted[^a] text^[inline].\n>\n> > Inner[^b].\n\nOut[^a].\n\n[^a]: Note a.\n\n[^b]: Note b.\n"
+	const expected = `<blockquote>
+<p>Quoted<sup class="noteref">1</sup> text<sup class="noteref">2</sup>.</p>
+
+<blockquote>
+<p>Inner<sup class="noteref">1</sup>.</p>
+
+<aside class="notes">
+<ol>
+<li>
+<p>Note b.</p>
+</li>
+</ol>
+</aside>
+</blockquote>
+
+<aside class="notes">
+<ol>
+<li>
+<p>Note a.</p>
+</li>
+<li>inline</li>
+</ol>
+</aside>
+</blockquote>
+
+<p>Out<a class="noteref" id="fnref1" href="#fn1" title="Jump to note 1">[1]</a>.</p>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{Notes: true})
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{QuoteNotes: true}))
+	if s := buf.String(); !strings.HasPrefix(s, expected) || strings.Count(s, "<li id=") != 1 {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestDialects(t *testing.T) {
+	if s := fmt.Sprint(Dialects()); s != "[gfm-like original pandoc-like]" {
+		t.Errorf("unexpected dialects: %s", s)
+	}
+	d, ok := LookupDialect("gfm-like")
+	if !ok {
+		t.Fatal("gfm-like not found")
+	}
+	var buf bytes.Buffer
+	d.NewParser().Markdown(strings.NewReader("# Go\n\nsnake*case*words ~~x~~\n"), d.ToHTML(&buf))
+	const expected = `<h1 id="go">Go <a class="anchor" href="#go">¶</a></h1>
+
+<p>snake*case*words <del>x</del></p>
+`
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+	if _, ok := LookupDialect("commonmark"); ok {
+		t.Error("unexpected dialect commonmark")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	x := Extensions{Smart: true, Primes: true, Notes: true}
+	if err := x.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	x = Extensions{Primes: true}
+	err, ok := x.Validate().(*ExtensionError)
+	if !ok || fmt.Sprint(err.Options) != "[primes smart]" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	x = Extensions{BlocksOnly: true, Strike: true, DupRefs: 5}
+	list, ok := x.Validate().(ExtensionErrors)
+	if !ok || len(list) != 2 || list.Error() != "extensions blocksonly, strike: inline syntax is not recognized with blocksonly; extensions duprefs: invalid value" {
+		t.Errorf("unexpected error: %v", list)
+	}
+	for _, name := range Dialects() {
+		d, _ := LookupDialect(name)
+		if err := d.Extensions.Validate(); err != nil {
+			t.Errorf("dialect %s: %v", name, err)
+		}
+	}
+}
+
+func TestCompat(t *testing.T) {
+	doc := Parse("Some *text*...\n", Extensions{Smart: true})
+	var buf strings.Builder
+	if err := doc.WriteHtml(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if s := buf.String(); s != "<p>Some <em>text</em>&hellip;</p>\n" {
+		t.Errorf("unexpected output: %q", s)
+	}
+}
+
+// failingWriter accepts n bytes, and fails afterwards.
+type failingWriter struct {
+	bytes.Buffer
+	n int
+}
+
+func (w *failingWriter) check(n int) error {
+	if w.Len()+n > w.n {
+		return errors.New("write failed")
+	}
+	return nil
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if err := w.check(len(b)); err != nil {
+		return 0, err
+	}
+	return w.Buffer.Write(b)
+}
+
+func (w *failingWriter) WriteString(s string) (int, error) {
+	if err := w.check(len(s)); err != nil {
+		return 0, err
+	}
+	return w.Buffer.WriteString(s)
+}
+
+func (w *failingWriter) WriteRune(r rune) (int, error) {
+	if err := w.check(utf8.RuneLen(r)); err != nil {
+		return 0, err
+	}
+	return w.Buffer.WriteRune(r)
+}
+
+func (w *failingWriter) WriteByte(c byte) error {
+	if err := w.check(1); err != nil {
+		return err
+	}
+	return w.Buffer.WriteByte(c)
+}
+
+func TestWriteError(t *testing.T) {
+	input := strings.Repeat("A paragraph.\n\n", 100)
+	for _, f := range []func(Writer) Formatter{ToHTML, ToGroffMM, ToMarkdown} {
+		w := &failingWriter{n: 100}
+		p := NewParser(nil)
+		err := p.Markdown(strings.NewReader(input), f(w))
+		if err == nil || err.Error() != "write failed" {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if n := p.Stats().Blocks; n > 10 {
+			t.Errorf("parsing did not stop: %d blocks", n)
+		}
+		if w.Len() > 100 {
+			t.Errorf("unexpected output length: %d", w.Len())
+		}
+	}
+	doc := NewParser(nil).Parse(strings.NewReader(input))
+	if err := doc.Render(ToHTML(&failingWriter{n: 100})); err == nil {
+		t.Error("Render: missing error")
+	}
+	if err := doc.Render(ToHTML(new(bytes.Buffer))); err != nil {
+		t.Errorf("Render: unexpected error: %v", err)
+	}
+}
+
+// cancelingFormatter cancels a context after n blocks.
+type cancelingFormatter struct {
+	Formatter
+	n      int
+	cancel context.CancelFunc
+}
+
+func (f *cancelingFormatter) FormatBlock(tree *Node) {
+	f.Formatter.FormatBlock(tree)
+	if f.n--; f.n == 0 {
+		f.cancel()
+	}
+}
+
+func TestMarkdownContext(t *testing.T) {
+	input := strings.Repeat("A paragraph.\n\n", 100)
+	p := NewParser(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	var buf bytes.Buffer
+	err := p.MarkdownContext(ctx, strings.NewReader(input), &cancelingFormatter{ToHTML(&buf), 3, cancel})
+	if err != context.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if n := strings.Count(buf.String(), "<p>"); n != 3 {
+		t.Errorf("%d paragraphs written", n)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := p.MarkdownContext(ctx, strings.NewReader(input), ToHTML(new(bytes.Buffer))); err != context.Canceled || p.Stats().Blocks != 0 {
+		t.Errorf("unexpected result: %v, %d blocks", err, p.Stats().Blocks)
+	}
+}
+
+func TestMarkdownBytes(t *testing.T) {
+	const input = "# Title\n\n*\tItem\n\n\tcode\twith tab\n\nText[^1].\n\n[^1]: Note.\n"
+	for _, x := range []Extensions{{Notes: true}, {Notes: true, CodeTabs: KeepTabs}} {
+		p := NewParser(&x)
+		var b1, b2 bytes.Buffer
+		p.Markdown(strings.NewReader(input), ToHTML(&b1))
+		p.MarkdownBytes([]byte(input), ToHTML(&b2))
+		if b1.String() != b2.String() {
+			t.Errorf("outputs differ:\n%s\n%s", b1.String(), b2.String())
+		}
+		b2.Reset()
+		p.ParseBytes([]byte(input)).Render(ToHTML(&b2))
+		if b1.String() != b2.String() {
+			t.Errorf("ParseBytes: outputs differ:\n%s\n%s", b1.String(), b2.String())
+		}
+	}
+}
+
+func TestFlush(t *testing.T) {
+	input := strings.Repeat("A paragraph.\n\n", 10)
+	for _, tc := range []struct {
+		bytes   int
+		flushes []int
+	}{
+		{0, []int{19, 40, 61, 82, 103, 124, 145, 166, 187, 208, 209}},
+		{50, []int{61, 124, 187, 209}},
+	} {
+		var buf bytes.Buffer
+		var flushes []int
+		opt := &HTMLOptions{
+			Flush: func() error {
+				flushes = append(flushes, buf.Len())
+				return nil
+			},
+			FlushBytes: tc.bytes,
+		}
+		NewParser(nil).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, opt))
+		if fmt.Sprint(flushes) != fmt.Sprint(tc.flushes) {
+			t.Errorf("FlushBytes %d: unexpected flushes: %v", tc.bytes, flushes)
+		}
+	}
+
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	err := p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{Flush: func() error { return errors.New("flush failed") }}))
+	if err == nil || err.Error() != "flush failed" || p.Stats().Blocks != 1 {
+		t.Errorf("unexpected result: %v, %d blocks", err, p.Stats().Blocks)
+	}
+}
+
+func TestReferenceIndex(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&b, "[*Ref* %d], [x][ref %d][^%d]\n\n", i, i, i)
+	}
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&b, "[*REF* %d]: /a%d\n[*ref* %d]: /b%d\n[Ref %d]: /c%d\n[ref %d]: /d%d\n\n[^%d]: First %d.\n\n[^%d]: Second.\n\n", i, i, i, i, i, i, i, i, i, i, i)
+	}
+	input := b.String()
+	for _, tc := range []struct {
+		policy     int
+		emph, text string
+	}{
+		{DupRefFirst, "/a", "/c"},
+		{DupRefLast, "/b", "/d"},
+	} {
+		p := NewParser(&Extensions{Notes: true, DupRefs: tc.policy})
+		for run := 0; run < 5; run++ {
+			var buf bytes.Buffer
+			p.Markdown(strings.NewReader(input), ToHTML(&buf))
+			s := buf.String()
+			for _, i := range []int{0, 42, 99} {
+				ref := fmt.Sprintf(`<a href="%s%d"><em>Ref</em> %d</a>, <a href="%s%d">x</a>`, tc.emph, i, i, tc.text, i)
+				note := fmt.Sprintf("<p>First %d.</p>", i)
+				if !strings.Contains(s, ref) || !strings.Contains(s, note) || strings.Contains(s, "Second") {
+					t.Fatalf("policy %d, reference %d not resolved as expected:\n%s", tc.policy, i, s)
+				}
+			}
+		}
+	}
+}
+
+func TestDuplicateNotes(t *testing.T) {
+	const input = "A[^n], b[^m].\n\n[^n]: First.\n\n[^m]: M.\n\n[^n]: Second.\n"
+	for _, tc := range []struct {
+		policy   int
+		expected string
+		notes    int
+		codes    string
+	}{
+		{DupRefFirst, "First.", 2, "7:duplicate-note"},
+		{DupRefLast, "Second.", 2, "7:duplicate-note"},
+		{DupRefNone, "A[^n]", 1, "7:duplicate-note 1:undefined-note"},
+	} {
+		var buf bytes.Buffer
+		p := NewParser(&Extensions{Notes: true, DupNotes: tc.policy})
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		s := buf.String()
+		var codes []string
+		for _, d := range p.Diagnostics() {
+			codes = append(codes, fmt.Sprintf("%d:%s", d.Line, d.Code))
+		}
+		if !strings.Contains(s, tc.expected) || strings.Count(s, "<li id=") != tc.notes || strings.Join(codes, " ") != tc.codes {
+			t.Errorf("policy %d: unexpected output:\n%s%v", tc.policy, s, codes)
+		}
+	}
+}
+
+func TestNoteCycles(t *testing.T) {
+	/* references within the contents of the referenced note,
+	 * directly or through other notes, are kept as text
+	 */
+	for _, tc := range []struct{ input, expected, diags string }{
+		{"See[^1].\n\n[^1]: note one\n[^1]: dup\n", "<p>note one\n[^1]: dup</p>", "[3:note-cycle]"},
+		{"See[^1].\n\n[^1]: self[^1]\n", "<p>self[^1]</p>", "[3:note-cycle]"},
+		{"See[^a].\n\n[^a]: to b[^b]\n\n[^b]: to a[^a] and [x][]\n", "<li id=\"fn2\">\n<p>to a[^a] and [x][]</p>", "[5:undefined-reference 5:note-cycle 5:undefined-reference]"},
+	} {
+		var buf bytes.Buffer
+		p := NewParser(&Extensions{Notes: true})
+		p.Markdown(strings.NewReader(tc.input), ToHTML(&buf))
+		if s := buf.String(); !strings.Contains(s, tc.expected) {
+			t.Errorf("%q: unexpected output:\n%s", tc.input, s)
+		}
+		var diags []string
+		for _, d := range p.Diagnostics() {
+			diags = append(diags, fmt.Sprintf("%d:%s", d.Line, d.Code))
+		}
+		if fmt.Sprint(diags) != tc.diags {
+			t.Errorf("%q: unexpected diagnostics: %v", tc.input, diags)
+		}
+		p.Markdown(strings.NewReader(tc.input), ToMarkdown(&buf))
+		p.Markdown(strings.NewReader(tc.input), ToGroffMM(&buf))
+	}
+}
+
+func TestExamples(t *testing.T) {
+	const input = `(@)  My first example.
+(@good) A good one.
+
+As (@good) illustrates, see also (@bad) and ` + "`(@good)`" + `.
+
+(@) Another one.
+(@good) A duplicate.
+`
+	p := NewParser(&Extensions{Examples: true})
+	var buf bytes.Buffer
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	expected := `<ol>
+<li>My first example.</li>
+<li>A good one.</li>
+</ol>
+
+<p>As (2) illustrates, see also (@bad) and <code>(@good)</code>.</p>
+
+<ol start="3">
+<li>Another one.</li>
+<li>A duplicate.</li>
+</ol>
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+	var codes []string
+	for _, d := range p.Diagnostics() {
+		codes = append(codes, d.Code)
+	}
+	if fmt.Sprint(codes) != "[duplicate-example undefined-example]" {
+		t.Errorf("unexpected diagnostics: %v", p.Diagnostics())
+	}
+}
+
+func TestLang(t *testing.T) {
+	const input = "# Titel {lang=de}\n\nGuten Tag,\nwie geht's?\n{lang=de-AT}\n\nPlain {lang=x y}\n\n{lang=fr}\n"
+	const expected = `<h1 lang="de">Titel</h1>
+
+<p lang="de-AT">Guten Tag,
+wie geht's?</p>
+
+<p>Plain {lang=x y}</p>
+
+<p>{lang=fr}</p>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{Lang: true})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestIndexTerms(t *testing.T) {
+	const input = "Peg[[PEG]] parsers\\index{parser} are fast.\n\n* Lists[[lists]] of [[PEG]].\n\nNo [[term\nhere]], \\index{}.\n"
+	p := NewParser(&Extensions{Index: true})
+	doc := p.Parse(strings.NewReader(input))
+	if s := fmt.Sprint(doc.IndexTerms()); s != "[{PEG 1 idx1} {parser 1 idx2} {lists 3 idx3} {PEG 3 idx4}]" {
+		t.Errorf("unexpected terms: %s", s)
+	}
+
+	const expected = `<p>Peg<a id="idx1"></a> parsers<a id="idx2"></a> are fast.</p>
+
+<ul>
+<li>Lists<a id="idx3"></a> of <a id="idx4"></a>.</li>
+</ul>
+
+<p>No [[term
+here]], \index{}.</p>
+
+<div class="index">
+<ul>
+<li>lists <a href="#idx3">1</a></li>
+<li>parser <a href="#idx2">1</a></li>
+<li>PEG <a href="#idx1">1</a>, <a href="#idx4">2</a></li>
+</ul>
+</div>
+`
+	var buf bytes.Buffer
+	doc.Render(ToHTMLOpt(&buf, &HTMLOptions{Index: true}))
+	if s := buf.String(); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+}
+
+func TestIndexTermsNotesTOC(t *testing.T) {
+	const input = "[TOC]\n\n# A [[alpha]]\n\nText[^n] and [[beta]].\n\n[^n]: Note [[gamma]].\n"
+	doc := NewParser(&Extensions{Index: true, Notes: true, TOC: true}).Parse(strings.NewReader(input))
+	if s := fmt.Sprint(doc.IndexTerms()); s != "[{alpha 3 idx1} {gamma 5 idx2} {beta 5 idx3}]" {
+		t.Errorf("unexpected terms: %s", s)
+	}
+
+	var buf bytes.Buffer
+	doc.Render(ToHTMLOpt(&buf, &HTMLOptions{Index: true}))
+	s := buf.String()
+	for _, want := range []string{
+		`<li><a href="#a-">A </a></li>`,
+		`<h1 id="a-">A <a id="idx1"></a></h1>`,
+		`<p>Note <a id="idx2"></a>.</p>`,
+		` and <a id="idx3"></a>.</p>`,
+		`<li>gamma <a href="#idx2">1</a></li>`,
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("output lacks %q:\n%s", want, s)
+		}
+	}
+}
+
+func TestLinkGraphNotesTOC(t *testing.T) {
+	const input = "[TOC]\n\n# See ![i](a.png)\n\nText[^n].\n\n[^n]: See [b](b.md).\n"
+	doc := NewParser(&Extensions{Notes: true, TOC: true}).Parse(strings.NewReader(input))
+	if s := fmt.Sprint(doc.Assets()); s != "[{a.png a.png true 3} {b.md b.md false 5}]" {
+		t.Errorf("unexpected assets: %s", s)
+	}
+	if s := fmt.Sprint(doc.LinkGraph(nil)); s != "{[{a.png [3]} {b.md [5]}] [see-i]}" {
+		t.Errorf("unexpected link graph: %s", s)
+	}
+}
+
+func TestLinkGraph(t *testing.T) {
+	const input = "# Intro\n\nSee [b](b.md), [top](#intro), and [ext](http://x.org/).\n\n## More\n\nAgain [b](./b.md) ![i](/img/i.png)\n"
+	doc := NewParser(nil).Parse(strings.NewReader(input))
+	base, _ := url.Parse("http://wiki/docs/a.md")
+	g := doc.LinkGraph(base)
+	expected := "{[{http://wiki/docs/b.md [3 7]} {http://wiki/docs/a.md#intro [3]} {http://x.org/ [3]} {http://wiki/img/i.png [7]}] [intro more]}"
+	if s := fmt.Sprint(g); s != expected {
+		t.Errorf("unexpected link graph:\n%s", s)
+	}
+	if g := doc.LinkGraph(nil); g.Links[1].URL != "#intro" || len(g.Links) != 5 {
+		t.Errorf("unexpected links: %v", g.Links)
+	}
+}
+
+func TestAssets(t *testing.T) {
+	const input = "![logo](img/logo%20big.png) [spec](docs/spec.pdf#p2)\n\n[web](http://x.org/a.png) [top](#top) ![remote](//cdn.org/b.png)\n\n![again](img/logo%20big.png \"Logo\")\n"
+	doc := NewParser(nil).Parse(strings.NewReader(input))
+	expected := "[{img/logo%20big.png img/logo big.png true 1} {docs/spec.pdf#p2 docs/spec.pdf false 1} {img/logo%20big.png img/logo big.png true 5}]"
+	if s := fmt.Sprint(doc.Assets()); s != expected {
+		t.Errorf("unexpected assets:\n%s", s)
+	}
+}
+
+func TestTextHash(t *testing.T) {
+	hash := func(input string) string {
+		return NewParser(nil).Parse(strings.NewReader(input)).TextHash()
+	}
+	a := hash("Title\n=====\n\nSome *emphasized* text,\nwith a [link](http://x.org/).\n\n* one\n* two\n\n    code\n")
+	b := hash("# Title\n\nSome _emphasized_   text, with a [link][x].\n\n- one\n\n- two\n\n<div>ignored</div>\n\n\tcode\n\n[x]: http://y.org/\n")
+	if a != b {
+		t.Error("hashes of equivalent documents differ")
+	}
+	if c := hash("# Title\n\nSome emphasized text, with another link.\n"); c == a {
+		t.Error("hashes of different documents are equal")
+	}
+}
+
+func TestRewriteReferences(t *testing.T) {
+	const input = "See [the docs][docs], [this][docs], and ![logo][].\n\nAlso [inline](http://old.org/docs).\n\n[docs]: http://old.org/docs \"Docs\"\n[logo]: http://old.org/logo.png\n"
+	doc := NewParser(nil).Parse(strings.NewReader(input))
+	if s := fmt.Sprint(doc.References()); s != "[{docs http://old.org/docs Docs} {logo http://old.org/logo.png }]" {
+		t.Errorf("unexpected references: %s", s)
+	}
+	clone := doc.Clone()
+	rewrite := func(d *Document) string {
+		d.RewriteReferences(func(r *Reference) {
+			r.URL = strings.Replace(r.URL, "old.org", "new.org", 1)
+			if r.Label == "docs" {
+				r.Title = "Documentation"
+			}
+		})
+		var buf bytes.Buffer
+		d.Render(ToHTML(&buf))
+		return buf.String()
+	}
+	expected := `<p>See <a href="http://new.org/docs" title="Documentation">the docs</a>, <a href="http://new.org/docs" title="Documentation">this</a>, and <img src="http://new.org/logo.png" alt="logo" />.</p>
+
+<p>Also <a href="http://old.org/docs">inline</a>.</p>
+`
+	if s := rewrite(doc); s != expected {
+		t.Errorf("unexpected output:\n%s", s)
+	}
+	if s := rewrite(clone); s != expected {
+		t.Errorf("unexpected output of clone:\n%s", s)
+	}
+}
+
+// Writing the documents of the md1.0.3 suite in Markdown
+// format, and parsing the result again, must result in the
+// expected HTML.
+func TestMarkdownOutput(t *testing.T) {
+	names, err := filepath.Glob(filepath.Join("tests", "md1.0.3", "*.text"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var md, html bytes.Buffer
+	p := NewParser(nil)
+	for _, name := range names {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := os.ReadFile(strings.TrimSuffix(name, ".text") + ".html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		md.Reset()
+		p.Markdown(bytes.NewReader(src), ToMarkdown(&md))
+		html.Reset()
+		p.Markdown(bytes.NewReader(md.Bytes()), ToHTML(&html))
+		if !bytes.Equal(html.Bytes(), expected) {
+			t.Errorf("%s: unexpected output after round trip:\n%s", name, md.String())
+		}
+	}
+}
+
+func TestMarkdownLabels(t *testing.T) {
+	/* labels containing elements like tags are compared
+	 * without reaching the process-terminating default of
+	 * a switch over known keys
+	 */
+	for _, tc := range []struct {
+		x        Extensions
+		in, want string
+	}{
+		{Extensions{Strike: true}, "[~~x~~]: /u\n\nhi [~~x~~]\n", "[~~x~~]: /u\n\nhi [~~x~~][]\n"},
+		{Extensions{Strike: true}, "[~~x~~]: /u\n\nhi [~~y~~][~~x~~]\n", "[~~x~~]: /u\n\nhi [~~y~~][~~x~~]\n"},
+		{Extensions{Tags: true}, "[#tag]: /u\n\nhi [#tag]\n", "[#tag]: /u\n\nhi [#tag][]\n"},
+		{Extensions{Tags: true}, "[#a]: /u\n\nhi [#b]\n", "[#a]: /u\n\nhi \\[#b\\]\n"},
+	} {
+		var buf bytes.Buffer
+		NewParser(&tc.x).Markdown(strings.NewReader(tc.in), ToMarkdown(&buf))
+		if s := buf.String(); s != tc.want {
+			t.Errorf("%q: unexpected output: %q", tc.in, s)
+		}
+	}
+}
+
+func TestMarkdownNumberEscape(t *testing.T) {
+	for _, in := range []string{"1986\\. A great year.\n", "Some text\n1986\\. A great year.\n", "> 12\\.\n"} {
+		var md, html, want bytes.Buffer
+		p := NewParser(nil)
+		p.Markdown(strings.NewReader(in), ToMarkdown(&md))
+		p.Markdown(bytes.NewReader(md.Bytes()), ToHTML(&html))
+		p.Markdown(strings.NewReader(in), ToHTML(&want))
+		if html.String() != want.String() {
+			t.Errorf("%q: unexpected output after round trip: %q", in, md.String())
+		}
+	}
+}
+
+func TestMarkdownOptions(t *testing.T) {
+	const input = "+ a\n+ b\n\nText\n\n3. c\n7. d\n\nA [link][x].\n\n\tcode\n\n[x]: http://old.org/\n"
+	doc := NewParser(nil).Parse(strings.NewReader(input))
+	doc.RewriteReferences(func(r *Reference) {
+		r.URL = "http://new.org/"
+	})
+	var buf bytes.Buffer
+	doc.Render(ToMarkdownOpt(&buf, &MarkdownOptions{Bullet: '-', SameNumbers: true, FencedCode: true}))
+	expected := "- a\n- b\n\nText\n\n3. c\n3. d\n\nA [link][x].\n\n```\ncode\n```\n\n[x]: http://new.org/\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWidth(t *testing.T) {
+	const input = "# A heading that is not filled\n\nA paragraph with a [link that is long](http://x.org/), and `a code span`,\nwhich is - filled\n\n> * quoted .item text\n"
+	p := NewParser(nil)
+	var buf bytes.Buffer
+	p.Markdown(strings.NewReader(input), ToMarkdownOpt(&buf, &MarkdownOptions{Width: 20}))
+	expected := `# A heading that is not filled
+
+A paragraph with a
+[link that is long](http://x.org/),
+and ` + "`a code span`" + `,
+which is - filled
+
+> * quoted .item
+>     text
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected markdown output:\n%s", buf.String())
+	}
+	buf.Reset()
+	p.Markdown(strings.NewReader(input), ToGroffMMOpt(&buf, &GroffOptions{Width: 20}))
+	expected = `.H 1 "A heading that is not filled"
+.P
+A paragraph with a
+link that is long (http://x.org/),
+and
+\fCa code span\fR,
+which is - filled
+.DS I
+.BL
+.LI
+quoted \[char46]item
+text
+.LE 1
+.DE
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected groff output:\n%s", buf.String())
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	/* a stand-in for norm.NFC.String, composing e and U+0301 */
+	nfc := func(s string) string {
+		return strings.Replace(s, "e\u0301", "\u00e9", -1)
+	}
+	const input = "# Cafe\u0301\n\nSee [the cafe\u0301](#caf\u00e9), `cafe\u0301`.\n"
+	var buf bytes.Buffer
+	NewParser(nil).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{Normalize: nfc, NormalizeIDs: true, Permalinks: true}))
+	expected := "<h1 id=\"caf\u00e9\">Caf\u00e9 <a class=\"anchor\" href=\"#caf\u00e9\">\u00b6</a></h1>\n\n<p>See <a href=\"#caf\u00e9\">the caf\u00e9</a>, <code>caf\u00e9</code>.</p>\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%q", buf.String())
+	}
+}
+
+func TestInvisible(t *testing.T) {
+	const input = "Pay \u202eexe.txt\u202c [in\u200bvoice](x \"a\u200db\") `co\u200bde`\n"
+	for policy, expected := range map[int]string{
+		InvisibleKeep:  "<p>Pay \u202eexe.txt\u202c <a href=\"x\" title=\"a\u200db\">in\u200bvoice</a> <code>co\u200bde</code></p>\n",
+		InvisibleStrip: "<p>Pay exe.txt <a href=\"x\" title=\"ab\">invoice</a> <code>co\u200bde</code></p>\n",
+		InvisibleShow:  "<p>Pay &lt;U+202E&gt;exe.txt&lt;U+202C&gt; <a href=\"x\" title=\"a&lt;U+200D&gt;b\">in&lt;U+200B&gt;voice</a> <code>co\u200bde</code></p>\n",
+	} {
+		var buf bytes.Buffer
+		NewParser(nil).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{Invisible: policy}))
+		if buf.String() != expected {
+			t.Errorf("policy %d: unexpected output:\n%q", policy, buf.String())
+		}
+	}
+}
+
+func TestSpoilers(t *testing.T) {
+	const input = "The ||butler *did* it||, >!really!<. a || b, x > y, || not||\n"
+	var buf bytes.Buffer
+	NewParser(&Extensions{Spoilers: true}).Markdown(strings.NewReader(input), ToHTML(&buf))
+	expected := "<p>The <span class=\"spoiler\">butler <em>did</em> it</span>, <span class=\"spoiler\">really</span>. a || b, x &gt; y, || not||</p>\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+	buf.Reset()
+	NewParser(&Extensions{Spoilers: true}).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{Classes: map[int]string{SPOILER: "hidden"}}))
+	if !strings.Contains(buf.String(), `<span class="hidden">really</span>`) {
+		t.Errorf("class not applied:\n%s", buf.String())
+	}
+	buf.Reset()
+	NewParser(nil).Markdown(strings.NewReader(input), ToHTML(&buf))
+	if strings.Contains(buf.String(), "<span") {
+		t.Errorf("spoiler recognized without extension:\n%s", buf.String())
+	}
+}
+
+func TestTags(t *testing.T) {
+	const input = "Hi @alice, see #golang and #go-lang, not C#sharp, me@example.org, #123, or @-x.\n\n[about #golang](/about) by @bob\n"
+	x := &Extensions{Tags: true, ResolveTag: func(key int, name string) (string, bool) {
+		if key == MENTION {
+			return "/users/" + name, name != "bob"
+		}
+		return "/tags/" + name, true
+	}}
+	var buf bytes.Buffer
+	NewParser(x).Markdown(strings.NewReader(input), ToHTML(&buf))
+	expected := `<p>Hi <a class="mention" href="/users/alice">@alice</a>, see <a class="hashtag" href="/tags/golang">#golang</a> and <a class="hashtag" href="/tags/go-lang">#go-lang</a>, not C#sharp, me@example.org, #123, or @-x.</p>
+
+<p><a href="/about">about <span class="hashtag">#golang</span></a> by @bob</p>
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+	doc := NewParser(x).Parse(strings.NewReader(input))
+	var s string
+	for _, tag := range doc.Tags() {
+		s += fmt.Sprintf("%s %s %d;", tagText(&Node{key: tag.Key, contents: contents{str: tag.Name}}), tag.URL, tag.Line)
+	}
+	if s != "@alice /users/alice 1;#golang /tags/golang 1;#go-lang /tags/go-lang 1;#golang /tags/golang 3;" {
+		t.Errorf("unexpected tags: %s", s)
+	}
+	buf.Reset()
+	NewParser(nil).Markdown(strings.NewReader(input), ToHTML(&buf))
+	if strings.Contains(buf.String(), "<span") {
+		t.Errorf("tag recognized without extension:\n%s", buf.String())
+	}
+}
+
+func TestAutoRefs(t *testing.T) {
+	const input = "Fixed in 3f2a9c1 (see #12, GH-7, and x#3).\n\nNot [#12](/x), `#12`, xyz1234567, or #99.\n"
+	x := &Extensions{AutoRefs: DefaultAutoRefs, AutoRefURL: func(ref string) (string, bool) {
+		switch {
+		case ref == "#99":
+			return "", false
+		case ref[0] == '#':
+			return "/issues/" + ref[1:], true
+		case strings.HasPrefix(ref, "GH-"):
+			return "/issues/" + ref[3:], true
+		}
+		return "/commit/" + ref, true
+	}}
+	var buf bytes.Buffer
+	NewParser(x).Markdown(strings.NewReader(input), ToHTML(&buf))
+	expected := `<p>Fixed in <a href="/commit/3f2a9c1">3f2a9c1</a> (see <a href="/issues/12">#12</a>, <a href="/issues/7">GH-7</a>, and x#3).</p>
+
+<p>Not <a href="/x">#12</a>, <code>#12</code>, xyz1234567, or #99.</p>
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestContainers(t *testing.T) {
+	const input = "::: warning {#w .big title=\"Watch out\" onclick=x}\nCareful.\n\n:::: note\nNested.\n::::\n:::\n\n::: open\ntext\n"
+	var buf bytes.Buffer
+	NewParser(&Extensions{Containers: true}).Markdown(strings.NewReader(input), ToHTML(&buf))
+	expected := `<div class="warning big" id="w" title="Watch out">
+<p>Careful.</p>
+
+<div class="note">
+<p>Nested.</p>
+</div>
+</div>
+
+<p>::: open
+text</p>
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+	doc := NewParser(&Extensions{Containers: true}).Parse(strings.NewReader(input))
+	c := doc.Blocks()[0]
+	if s := fmt.Sprint(c.Text(), c.Attrs()); c.Key() != CONTAINER || s != "warning[{id w} {class big} {title Watch out} {onclick x}]" {
+		t.Errorf("unexpected container: %s", s)
+	}
+	buf.Reset()
+	NewParser(nil).Markdown(strings.NewReader(input), ToHTML(&buf))
+	if strings.Contains(buf.String(), "<div") {
+		t.Errorf("container recognized without extension:\n%s", buf.String())
+	}
+}
+
+func TestComments(t *testing.T) {
+	const input = "%% draft\n// check this\n\nSome text\n%% inside\ncontinues here.\n\nEnds with\n%% trailing\n"
+	x := &Extensions{Comments: true}
+	var buf bytes.Buffer
+	NewParser(x).Markdown(strings.NewReader(input), ToHTML(&buf))
+	expected := "<p>Some text\ncontinues here.</p>\n\n<p>Ends with</p>\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+	doc := NewParser(x).Parse(strings.NewReader(input))
+	var comments []string
+	for _, b := range doc.Blocks() {
+		Walk(b, func(n *Node, entering bool) WalkStatus {
+			if entering && n.Key() == COMMENT {
+				comments = append(comments, n.Text())
+			}
+			return GoToNext
+		})
+	}
+	if s := fmt.Sprintf("%q", comments); s != `["draft\ncheck this" "inside" "trailing"]` {
+		t.Errorf("unexpected comments: %s", s)
+	}
+	buf.Reset()
+	NewParser(x).Markdown(strings.NewReader(input), ToMarkdownOpt(&buf, &MarkdownOptions{Width: 20}))
+	expected = "%% draft\n%% check this\n\nSome text\n%% inside\ncontinues here.\n\nEnds with\n%% trailing\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected Markdown output:\n%s", buf.String())
+	}
+}
+
+func TestParallel(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("[TOC]\n\n[home]: http://example.org/\n[^n]: A *note*.\n\n")
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&b, "# Section %d\n\nSee [home][], [missing][], and a note[^n].\n\n", i)
+		b.WriteString(strings.Repeat("* item with *some* text\n", 20))
+		b.WriteString("\n    code\n\n> quote\n\n")
+		if i == 38 {
+			b.WriteString("<div>\n\n# Not split\n\n</div>\n\n")
+		}
+	}
+	input := b.String()
+	x := &Extensions{Notes: true, TOC: true}
+
+	render := func(p *Parser) (string, string) {
+		var buf bytes.Buffer
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		var lines []int
+		for _, block := range p.Parse(strings.NewReader(input)).Blocks() {
+			lines = append(lines, block.line)
+		}
+		return buf.String(), fmt.Sprint(lines, p.Diagnostics())
+	}
+	seq, seqInfo := render(NewParser(x))
+	p := NewParser(x)
+	p.SetParallel(4)
+	if n := len(p.splitChunks(input)); n != 4 {
+		t.Errorf("document split into %d chunks", n)
+	}
+	par, parInfo := render(p)
+	if par != seq {
+		t.Errorf("output of parallel parse differs")
+	}
+	if parInfo != seqInfo {
+		t.Errorf("line numbers or diagnostics of parallel parse differ:\n%s\n%s", parInfo, seqInfo)
+	}
+}
+
+func TestParallelNotes(t *testing.T) {
+	/* run with -race: all chunks refer to the same note, whose
+	 * contents must not be processed concurrently
+	 */
+	var b strings.Builder
+	b.WriteString("[^n]: A note.\n\n    * item\n\n    > quote\n\n    <https://youtu.be/dQw4w9WgXcQ>\n\n")
+	for i := 0; b.Len() < 52<<10; i++ {
+		fmt.Fprintf(&b, "# Section %d\n\nText with a note[^n].\n\n", i)
+		b.WriteString(strings.Repeat("some more text\n", 20) + "\n")
+	}
+	input := b.String()
+	x := &Extensions{Notes: true, Embeds: DefaultEmbeds}
+
+	render := func(p *Parser) string {
+		var buf bytes.Buffer
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		return buf.String()
+	}
+	seq := render(NewParser(x))
+	p := NewParser(x)
+	p.SetParallel(8)
+	if n := len(p.splitChunks(input)); n != 8 {
+		t.Errorf("document split into %d chunks", n)
+	}
+	if par := render(p); par != seq {
+		t.Errorf("output of parallel parse differs")
+	}
+	if !strings.Contains(seq, "<blockquote>") || !strings.Contains(seq, "<iframe") {
+		t.Errorf("contents of note not processed")
+	}
+}
+
+func TestParseStats(t *testing.T) {
+	const input = "* one\n\n    > quoted\n\n* two\n\ntext\n"
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if s := p.Stats(); s != (ParseStats{Blocks: 2, RawParses: 3}) {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+
+	input2 := strings.Repeat("# Section\n\n* item\n\n"+strings.Repeat("text\n", 1000)+"\n", 8)
+	p.SetParallel(4)
+	for i, inits := range []int{4, 0} {
+		p.Markdown(strings.NewReader(input2), ToHTML(&buf))
+		if s := p.Stats(); s.Inits != inits || s.Blocks != 24 || s.RawParses != 8 {
+			t.Errorf("#%d: unexpected stats: %+v", i, s)
+		}
+	}
+}
+
+func TestNestedLines(t *testing.T) {
+	const input = "Intro\n\n* item one\n  continued\n\n    > quoted [a][]\n    > more\n\n* item two\n\n    1. nested\n    2. nested [b][]\n\n> quote\n>\n> para [c][]\n"
+	p := NewParser(nil)
+	doc := p.Parse(strings.NewReader(input))
+	var lines []string
+	for _, b := range doc.Blocks() {
+		Walk(b, func(n *Node, entering bool) WalkStatus {
+			if entering && n.Line() > 0 {
+				lines = append(lines, fmt.Sprint(n.Line(), ":", keynames[n.Key()]))
+			}
+			return GoToNext
+		})
+	}
+	if s := strings.Join(lines, " "); s != "1:PARA 3:BULLETLIST 3:PARA 6:BLOCKQUOTE 6:PARA 9:PARA 11:ORDEREDLIST 11:PLAIN 12:PLAIN 14:BLOCKQUOTE 14:PARA 16:PARA" {
+		t.Errorf("unexpected lines: %s", s)
+	}
+	var diags []int
+	for _, d := range p.Diagnostics() {
+		diags = append(diags, d.Line)
+	}
+	if s := fmt.Sprint(diags); s != "[6 12 16]" {
+		t.Errorf("unexpected lines of diagnostics: %s", s)
+	}
+}
+
+func TestLint(t *testing.T) {
+	const input = `# Title
+
+Some text.
+
+### Details
+
+For details [click here](a.html), or see [](b.html).
+
+* ![](c.png)
+`
+	var diags []Diagnostic
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), Lint(&diags))
+	expected := []string{
+		"5: heading level jumps from 1 to 3 [heading-jump]",
+		`7: link text "click here" does not describe link to "a.html" [vague-link]`,
+		`7: link to "b.html" has no text [empty-link]`,
+		`9: image "c.png" has no alternative text [missing-alt]`,
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	for i, d := range diags {
+		if d.String() != expected[i] {
+			t.Errorf("unexpected diagnostic: %v", d)
+		}
+	}
+}
+
+func TestUndefinedReferences(t *testing.T) {
+	const input = `See [foo][], [bar] [baz], and [defined]; a[i] or [RFC 1234] are prose.
+
+Note[^1] and note[^2].
+
+[^1]: A note.
+
+[defined]: http://example.com/
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{Notes: true})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	expected := []string{
+		`1: undefined reference "foo" [undefined-reference]`,
+		`1: undefined reference "baz" [undefined-reference]`,
+		`3: undefined note "2" [undefined-note]`,
+	}
+	diags := p.Diagnostics()
+	if len(diags) != len(expected) {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	for i, d := range diags {
+		if d.String() != expected[i] {
+			t.Errorf("unexpected diagnostic: %v", d)
+		}
+	}
+}
+
+func TestDuplicateReferences(t *testing.T) {
+	const input = "[a], [b]\n\n[a]: /first\n[A]: /second\n[b]: /b\n"
+	tests := []struct {
+		policy   int
+		expected string
+	}{
+		{DupRefFirst, `<p><a href="/first">a</a>, <a href="/b">b</a></p>` + "\n"},
+		{DupRefLast, `<p><a href="/second">a</a>, <a href="/b">b</a></p>` + "\n"},
+		{DupRefNone, `<p>[a], <a href="/b">b</a></p>` + "\n"},
+	}
+	var buf bytes.Buffer
+	for _, tt := range tests {
+		buf.Reset()
+		p := NewParser(&Extensions{DupRefs: tt.policy})
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if s := buf.String(); s != tt.expected {
+			t.Errorf("policy %d: unexpected output:\n%s", tt.policy, s)
+		}
+		diags := p.Diagnostics()
+		if len(diags) == 0 || diags[0].Code != "duplicate-reference" || diags[0].Line != 4 {
+			t.Errorf("policy %d: unexpected diagnostics: %v", tt.policy, diags)
+		}
+	}
+}
+
+func TestLinks(t *testing.T) {
+	const input = "[a](http://a/) and ![b](b.png)\n\nagain [a](http://a/), and [dead](http://dead/)\n"
+	var links []Link
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), LinksTo(&links))
+	if s := fmt.Sprint(links); s != "[{http://a/ [1 3]} {b.png [1]} {http://dead/ [3]}]" {
+		t.Errorf("unexpected links: %s", s)
+	}
+
+	const expected = `<p>again <a href="http://a/">a</a>, and <a href="http://dead/" class="dead-link">dead</a></p>`
+	var buf bytes.Buffer
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{
+		LinkClass: func(url string) string {
+			if url == "http://dead/" {
+				return "dead-link"
+			}
+			return ""
+		},
+	}))
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestStrictCSP(t *testing.T) {
+	const input = `<div onclick="evil()" class="box" style="color:red">
+<script>alert(1)</script>
+</div>
+
+A <span onmouseover='x()'>span</span>, [a link](javascript:alert(1)),
+<a href=" JavaScript:void(0)" title="t">raw</a> and [ok](http://ok/).
+
+<a href="jav&#x61;script:alert(1)">a</a> <a href="javascript&colon;alert(1)">b</a>
+<a href="&#106avascript:alert(1)">c</a> [d](jav&#x61;script:alert(1))
+
+<div><img src=x onerror=alert(1) title="></div>
+`
+	const expected = `<div class="box">
+
+</div>
+
+<p>A <span>span</span>, a link,
+<a title="t">raw</a> and <a href="http://ok/">ok</a>.</p>
+
+<p><a>a</a> <a>b</a>
+<a>c</a> d</p>
+
+<div>&lt;img src=x onerror=alert(1) title="></div>
+`
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{StrictCSP: true}))
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestStrictCSPSyntax(t *testing.T) {
+	/* markup browsers read differently than a naive parser */
+	for _, input := range []string{
+		"<div><img\fsrc=x\fonerror=alert(1)></div>\n",
+		"<div><img/src=\"x\"/onerror=alert(1)></div>\n",
+		"<!-- --!> <img src=x onerror=alert(1)> -->\n",
+		"A <!-- --!> <img src=x onerror=alert(1)> --> b\n",
+		"<div><!--> <img src=x onerror=alert(1)> --></div>\n",
+		"<div><!-- <img src=x onerror=alert(1)></div>\n",
+		"<iframe srcdoc=\"<script>alert(1)</script>\"></iframe>\n",
+	} {
+		var buf bytes.Buffer
+		NewParser(nil).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{StrictCSP: true}))
+		if s := buf.String(); strings.Contains(s, "onerror") && strings.Contains(s, "<img") || strings.Contains(s, "srcdoc") {
+			t.Errorf("%q: unexpected output: %q", input, s)
+		}
+	}
+}
+
+func TestAllowedHTML(t *testing.T) {
+	const input = `<div>block</div>
+
+Press <kbd>Ctrl</kbd>-<b>C</b><br/> <span class="x">now</span>.
+`
+	const expected = `&lt;div&gt;block&lt;/div&gt;
+
+<p>Press <kbd>Ctrl</kbd>-&lt;b&gt;C&lt;/b&gt;<br/> &lt;span class=&quot;x&quot;&gt;now&lt;/span&gt;.</p>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{AllowedHTML: []string{"br", "sup", "sub", "abbr", "kbd"}})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestAllowedHTMLMalformed(t *testing.T) {
+	for _, tc := range []struct {
+		x     Extensions
+		input string
+	}{
+		{Extensions{AllowedHTML: []string{"b"}}, "<div>\n<img src=x onerror=alert(1) a=\">\n</div>\n"},
+		{Extensions{AllowedHTML: []string{"b"}}, "<div>\n<img src=x onerror=alert(1) a='>\n</div>\n"},
+		{Extensions{AllowedHTML: []string{"b"}}, "A <b>b</b> <img src=x onerror=alert(1) a=\">\n"},
+		{Extensions{AllowedBlockHTML: []string{"div"}}, "<div>\n<img src=x onerror=alert(1) a=\">\n</div>\n"},
+		{Extensions{AllowedBlockHTML: []string{"div"}}, "<div>\n<img src=x onerror=alert(1) a='>\n</div>\n"},
+		{Extensions{AllowedHTML: []string{"b"}}, "<!-- --!> <img src=x onerror=alert(1)> -->\n"},
+		{Extensions{AllowedHTML: []string{"b"}}, "A <!-- --!> <img src=x onerror=alert(1)> --> b\n"},
+		{Extensions{AllowedBlockHTML: []string{"div"}}, "<div><!-- --!> <img src=x onerror=alert(1)> --></div>\n"},
+	} {
+		var buf bytes.Buffer
+		NewParser(&tc.x).Markdown(strings.NewReader(tc.input), ToHTML(&buf))
+		if strings.Contains(buf.String(), "<img") {
+			t.Errorf("%q: tag not escaped:\n%s", tc.input, buf.String())
+		}
+	}
+}
+
+func TestAllowedHTMLAttributes(t *testing.T) {
+	for _, tc := range []struct {
+		x               Extensions
+		input, expected string
+	}{
+		{Extensions{AllowedHTML: []string{"span", "abbr"}},
+			"A <span onclick=alert(1) class=\"x\">b</span> <abbr title=\"t\" ONMOUSEOVER='f()'>c</abbr>\n",
+			"<p>A <span class=\"x\">b</span> <abbr title=\"t\">c</abbr></p>\n"},
+		{Extensions{AllowedHTML: []string{"a"}},
+			"<a href=\"jav&#x61;script:alert(1)\" title=t>x</a> <a href=\"/ok\">y</a>\n",
+			"<p><a title=t>x</a> <a href=\"/ok\">y</a></p>\n"},
+		{Extensions{AllowedBlockHTML: []string{"div", "iframe"}},
+			"<div onclick=\"f()\">\n<iframe srcdoc=\"x\"></iframe>\n</div>\n",
+			"<div>\n<iframe></iframe>\n</div>\n"},
+	} {
+		var buf bytes.Buffer
+		NewParser(&tc.x).Markdown(strings.NewReader(tc.input), ToHTML(&buf))
+		if buf.String() != tc.expected {
+			t.Errorf("%q: unexpected output:\n%s", tc.input, buf.String())
+		}
+	}
+}
+
+func TestAllowedBlockHTML(t *testing.T) {
+	const input = `<div>block</div>
+
+<table><tr><td>x</td></tr></table>
+
+H<sub>2</sub>O, <abbr>HTML</abbr>, <span>no</span>.
+`
+	for _, tc := range []struct {
+		x        Extensions
+		expected string
+	}{
+		{Extensions{AllowedHTML: []string{"sub", "abbr"}, AllowedBlockHTML: []string{}}, `&lt;div&gt;block&lt;/div&gt;
+
+&lt;table&gt;&lt;tr&gt;&lt;td&gt;x&lt;/td&gt;&lt;/tr&gt;&lt;/table&gt;
+
+<p>H<sub>2</sub>O, <abbr>HTML</abbr>, &lt;span&gt;no&lt;/span&gt;.</p>
+`},
+		{Extensions{AllowedHTML: []string{"sub", "abbr"}, AllowedBlockHTML: []string{"div"}}, `<div>block</div>
+
+&lt;table&gt;&lt;tr&gt;&lt;td&gt;x&lt;/td&gt;&lt;/tr&gt;&lt;/table&gt;
+
+<p>H<sub>2</sub>O, <abbr>HTML</abbr>, &lt;span&gt;no&lt;/span&gt;.</p>
+`},
+		{Extensions{FilterHTMLBlocks: true}, `<p>H<sub>2</sub>O, <abbr>HTML</abbr>, <span>no</span>.</p>
+`},
+	} {
+		var buf bytes.Buffer
+		p := NewParser(&tc.x)
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if buf.String() != tc.expected {
+			t.Errorf("unexpected output:\n%s", buf.String())
+		}
+	}
+}
+
+func TestUnwrapHTML(t *testing.T) {
+	const input = `<img src="a.png" alt="A">
+
+<span class="icon"></span> <!-- icon -->
+<br>
+
+<span>text</span>
+
+A <img src="b.png" alt="B">
+`
+	var buf bytes.Buffer
+	NewParser(nil).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{UnwrapHTML: true}))
+	expected := `<img src="a.png" alt="A">
+
+<span class="icon"></span> <!-- icon -->
+<br>
+
+<p><span>text</span></p>
+
+<p>A <img src="b.png" alt="B"></p>
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestEmbeds(t *testing.T) {
+	const input = `<https://youtu.be/dQw4w9WgXcQ>
+
+A video: @[vimeo](76979871), @[youtube](bad), <https://vimeo.com/1>.
+`
+	p := NewParser(&Extensions{Embeds: DefaultEmbeds})
+	for _, tc := range []struct {
+		f        func(Writer) Formatter
+		expected string
+	}{
+		{ToHTML, `<p><iframe class="embed youtube" src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ" title="YouTube video" allow="fullscreen; picture-in-picture"></iframe></p>
+
+<p>A video: <iframe class="embed vimeo" src="https://player.vimeo.com/video/76979871" title="Vimeo video" allow="fullscreen; picture-in-picture"></iframe>, @<a href="bad">youtube</a>, <a href="https://vimeo.com/1">https://vimeo.com/1</a>.</p>
+`},
+		{ToMarkdown, `<https://youtu.be/dQw4w9WgXcQ>
+
+A video: <https://vimeo.com/76979871>, @[youtube](bad), <https://vimeo.com/1>.
+`},
+	} {
+		var buf bytes.Buffer
+		p.Markdown(strings.NewReader(input), tc.f(&buf))
+		if buf.String() != tc.expected {
+			t.Errorf("unexpected output:\n%s", buf.String())
+		}
+	}
+}
+
+func TestMedia(t *testing.T) {
+	const input = "![Intro](intro.webm \"The intro\") ![Theme](/a/theme.MP3?v=2) ![Photo](a.png)\n"
+	p := NewParser(&Extensions{Media: true})
+	for _, tc := range []struct {
+		f        func(Writer) Formatter
+		expected string
+	}{
+		{ToHTML, `<p><video src="intro.webm" controls title="The intro"><a href="intro.webm">Intro</a></video> <audio src="/a/theme.MP3?v=2" controls><a href="/a/theme.MP3?v=2">Theme</a></audio> <img src="a.png" alt="Photo" /></p>
+`},
+		{func(w Writer) Formatter {
+			return ToHTMLOpt(w, &HTMLOptions{MediaAttrs: []string{"muted", `preload="none"`}})
+		}, `<p><video src="intro.webm" muted preload="none" title="The intro"><a href="intro.webm">Intro</a></video> <audio src="/a/theme.MP3?v=2" muted preload="none"><a href="/a/theme.MP3?v=2">Theme</a></audio> <img src="a.png" alt="Photo" /></p>
+`},
+		{ToMarkdown, input},
+		{ToGroffMM, `.P
+Intro (intro.webm) Theme (/a/theme.MP3?v=2) [IMAGE: Photo]
+`},
+	} {
+		var buf bytes.Buffer
+		p.Markdown(strings.NewReader(input), tc.f(&buf))
+		if buf.String() != tc.expected {
+			t.Errorf("unexpected output:\n%s", buf.String())
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	const input = "![Logo](/img/logo.svg \"The logo\") ![Big](big.svg) ![Photo](a.png)\n"
+	fsys := fstest.MapFS{
+		"img/logo.svg": {Data: []byte(`<?xml version="1.0"?>
+<svg viewBox="0 0 1 1" onload="f()"><script>g()</script><rect /></svg>
+`)},
+		"big.svg": {Data: []byte("<svg>" + strings.Repeat(" ", 100) + "</svg>")},
+	}
+	for _, tc := range []struct {
+		opt      HTMLOptions
+		expected string
+		warnings []string
+	}{
+		{HTMLOptions{}, `<p><img src="/img/logo.svg" alt="Logo" title="The logo" /> <img src="big.svg" alt="Big" /> <img src="a.png" alt="Photo" /></p>
+`, nil},
+		{HTMLOptions{SVG: SVGObject}, `<p><object data="/img/logo.svg" type="image/svg+xml" title="The logo">Logo</object> <object data="big.svg" type="image/svg+xml">Big</object> <img src="a.png" alt="Photo" /></p>
+`, []string{"Logo: contains an event handler"}},
+		{HTMLOptions{SVG: SVGInline, SVGMaxInline: 100}, `<p><svg role="img" aria-label="Logo" viewBox="0 0 1 1" onload="f()"><script>g()</script><rect /></svg> <img src="big.svg" alt="Big" /> <img src="a.png" alt="Photo" /></p>
+`, []string{"Logo: contains an event handler", "Big: larger than SVGMaxInline"}},
+		{HTMLOptions{SVG: SVGInline, StrictCSP: true}, `<p><svg role="img" aria-label="Logo" viewBox="0 0 1 1"><rect /></svg> <svg role="img" aria-label="Big">` + strings.Repeat(" ", 100) + `</svg> <img src="a.png" alt="Photo" /></p>
+`, []string{"Logo: contains an event handler"}},
+	} {
+		var warnings []string
+		opt := tc.opt
+		if opt.SVG != SVGImg {
+			opt.SVGFS = fsys
+		}
+		opt.SVGWarning = func(img Image, reason string) {
+			warnings = append(warnings, img.Alt+": "+reason)
+		}
+		var buf bytes.Buffer
+		NewParser(nil).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &opt))
+		if buf.String() != tc.expected {
+			t.Errorf("svg %d: unexpected output:\n%s", tc.opt.SVG, buf.String())
+		}
+		if fmt.Sprint(warnings) != fmt.Sprint(tc.warnings) {
+			t.Errorf("svg %d: unexpected warnings: %q", tc.opt.SVG, warnings)
+		}
+	}
+}
+
+func TestURLPolicy(t *testing.T) {
+	const input = `![a](data:image/png;base64,AAAA) ![b](data:image/svg+xml;base64,AAAA)
+[c](data:text/html,x) [d](//example.org/) [e](/\example.org) [f](/path)
+`
+	for _, tc := range []struct {
+		policy   *URLPolicy
+		expected string
+	}{
+		{nil, `<p><img src="data:image/png;base64,AAAA" alt="a" /> <img src="data:image/svg+xml;base64,AAAA" alt="b" />
+<a href="data:text/html,x">c</a> <a href="//example.org/">d</a> <a href="/\example.org">e</a> <a href="/path">f</a></p>
+`},
+		{&URLPolicy{}, `<p>a b
+c d e <a href="/path">f</a></p>
+`},
+		{&URLPolicy{DataImages: true, ProtocolRelative: true}, `<p><img src="data:image/png;base64,AAAA" alt="a" /> b
+c <a href="//example.org/">d</a> <a href="/\example.org">e</a> <a href="/path">f</a></p>
+`},
+	} {
+		var buf bytes.Buffer
+		NewParser(nil).Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{URLs: tc.policy}))
+		if buf.String() != tc.expected {
+			t.Errorf("policy %+v: unexpected output:\n%s", tc.policy, buf.String())
+		}
+	}
+}
+
+func TestScripts(t *testing.T) {
+	const input = `<script src="a.js"></script>
+
+<div>block</div>
+
+Text <script>f()</script> <b>bold</b>.
+`
+	for _, tc := range []struct {
+		x        Extensions
+		expected string
+		scripts  int
+	}{
+		{Extensions{FilterHTML: true}, `<p>Text  bold.</p>
+`, 0},
+		{Extensions{FilterHTML: true, Scripts: ScriptsPass}, `<script src="a.js"></script>
+
+<p>Text <script>f()</script> bold.</p>
+`, 0},
+		{Extensions{Scripts: ScriptsDrop}, `<div>block</div>
+
+<p>Text  <b>bold</b>.</p>
+`, 0},
+		{Extensions{Scripts: ScriptsCollect}, `<div>block</div>
+
+<p>Text  <b>bold</b>.</p>
+`, 2},
+	} {
+		var buf bytes.Buffer
+		doc := NewParser(&tc.x).Parse(strings.NewReader(input))
+		doc.Render(ToHTML(&buf))
+		if buf.String() != tc.expected {
+			t.Errorf("scripts %d: unexpected output:\n%s", tc.x.Scripts, buf.String())
+		}
+		if s := doc.Scripts(); len(s) != tc.scripts {
+			t.Errorf("scripts %d: unexpected scripts: %q", tc.x.Scripts, s)
+		} else if len(s) == 2 && s[1] != "<script>f()</script>" {
+			t.Errorf("unexpected script: %q", s[1])
+		}
+	}
+}
+
+func TestScriptsNested(t *testing.T) {
+	const input = "<div><script>a()</script><b>x</b></div>\n\n<SCRIPT>x</SCRIPT >\n\nText <span><script>b()</script></span> <!-- <script>c()</script> -->.\n"
+	const expected = `<div><b>x</b></div>
+
+<p>Text <span></span> <!-- <script>c()</script> -->.</p>
+`
+	for _, mode := range []int{ScriptsDrop, ScriptsCollect} {
+		var buf bytes.Buffer
+		doc := NewParser(&Extensions{Scripts: mode}).Parse(strings.NewReader(input))
+		doc.Render(ToHTML(&buf))
+		if buf.String() != expected {
+			t.Errorf("scripts %d: unexpected output:\n%s", mode, buf.String())
+		}
+		scripts := fmt.Sprintf("%q", doc.Scripts())
+		if mode == ScriptsDrop && scripts != "[]" || mode == ScriptsCollect && scripts != `["<script>a()</script>" "<SCRIPT>x</SCRIPT >" "<script>b()</script>"]` {
+			t.Errorf("scripts %d: unexpected scripts: %s", mode, scripts)
+		}
+	}
+}
+
+func TestSmartRawHTML(t *testing.T) {
+	const input = `"a" <span title="it's -- 'q'..." :title="'x'" @click="f('y')" x-on:click.prevent='g("z")'>'b'</span>
+`
+	var buf bytes.Buffer
+	NewParser(&Extensions{Smart: true, Symbols: true, Arrows: true}).Markdown(strings.NewReader(input), ToHTML(&buf))
+	expected := `<p>&ldquo;a&rdquo; <span title="it's -- 'q'..." :title="'x'" @click="f('y')" x-on:click.prevent='g("z")'>&lsquo;b&rsquo;</span></p>
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	/* not an attribute @example.org */
+	if n, value, _ := matchRule(nil, ruleRawHtml, "<user@example.org>"); n != 0 {
+		t.Errorf("email address matched as raw HTML: %s", value)
+	}
+
+	/* the output in default mode has changed with DialectVersion 2,
+	 * such tags used to be escaped
+	 */
+	for _, tc := range []struct{ in, expected string }{
+		{"<div :x=\"1\">\ntext\n</div>\n", "<div :x=\"1\">\ntext\n</div>\n"},
+		{"<a href=\"x\" :foo=\"y\">z</a>\n", "<p><a href=\"x\" :foo=\"y\">z</a></p>\n"},
+	} {
+		buf.Reset()
+		NewParser(nil).Markdown(strings.NewReader(tc.in), ToHTML(&buf))
+		if buf.String() != tc.expected {
+			t.Errorf("%q: unexpected output in default mode: %q", tc.in, buf.String())
+		}
+	}
+}
+
+func TestGridTables(t *testing.T) {
+	const input = `+------+----------+
+| Name | Notes    |
++======+==========+
+| peg  | - fast   |
+|      | - small  |
++------+----------+
+| e    |          |
++------+----------+
+
+After
+`
+	const expected = `<table>
+<thead>
+<tr>
+<th>Name</th>
+<th>Notes</th>
+</tr>
+</thead>
+<tbody>
+<tr>
+<td>peg</td>
+<td><ul>
+<li>fast</li>
+<li>small</li>
+</ul></td>
+</tr>
+<tr>
+<td>e</td>
+<td></td>
+</tr>
+</tbody>
+</table>
+
+<p>After</p>
+`
+	p := NewParser(&Extensions{GridTables: true})
+	doc := p.Parse(strings.NewReader(input))
+	var buf bytes.Buffer
+	doc.Render(ToHTML(&buf))
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+
+	/* the Markdown writer's output must parse into the same table */
+	var md, html bytes.Buffer
+	doc.Render(ToMarkdown(&md))
+	p.Markdown(bytes.NewReader(md.Bytes()), ToHTML(&html))
+	if html.String() != expected {
+		t.Errorf("Markdown output not preserving the table:\n%s", md.String())
+	}
+
+	buf.Reset()
+	doc.Render(ToGroffMM(&buf))
+	if s := buf.String(); !strings.Contains(s, ".TS\nallbox;\nlb lb\nl l.\nT{\nName\nT}\tT{\nNotes\nT}\n") || !strings.Contains(s, "T{\ne\nT}\tT{\nT}\n.TE\n") {
+		t.Errorf("unexpected groff output:\n%s", s)
+	}
+
+	/* without the extension, the table is a paragraph */
+	buf.Reset()
+	NewParser(nil).Markdown(strings.NewReader(input), ToHTML(&buf))
+	if !strings.HasPrefix(buf.String(), "<p>+------+") {
+		t.Errorf("table recognized without extension:\n%s", buf.String())
+	}
+}
+
+func TestLiteralCode(t *testing.T) {
+	const input = "Run `ls -l 'a'`.\n\n    echo `x` -n\n"
+	for _, tc := range []struct {
+		f        func(Writer) Formatter
+		expected string
+	}{
+		{func(w Writer) Formatter {
+			return ToHTMLOpt(w, &HTMLOptions{Normalize: strings.ToUpper})
+		}, "<p>RUN <code>LS -L 'A'</code>.</p>\n\n<pre><code>ECHO `X` -N\n</code></pre>\n"},
+		{func(w Writer) Formatter {
+			return ToHTMLOpt(w, &HTMLOptions{Normalize: strings.ToUpper, LiteralCode: true})
+		}, "<p>RUN <code>ls -l 'a'</code>.</p>\n\n<pre><code>echo `x` -n\n</code></pre>\n"},
+		{func(w Writer) Formatter {
+			return ToGroffMMOpt(w, &GroffOptions{LiteralCode: true})
+		}, ".P\nRun \\fCls \\-l \\(aqa\\(aq\\fR\\[char46]\n.VERBON 2\necho \\(gax\\(ga \\-n\n.VERBOFF\n"},
+	} {
+		var buf bytes.Buffer
+		NewParser(nil).Markdown(strings.NewReader(input), tc.f(&buf))
+		if buf.String() != tc.expected {
+			t.Errorf("unexpected output:\n%s", buf.String())
+		}
+	}
+}
+
+func TestSmartSymbols(t *testing.T) {
+	const input = "He is 5'10\" tall, the 1980's -> (c) (TM) 1/2 3/4 11/2 1/2/3 <=> `a -> b`.\n"
+	tests := []struct {
+		ext      Extensions
+		expected string
+	}{
+		{Extensions{Smart: true},
+			"<p>He is 5&rsquo;10&quot; tall, the 1980&rsquo;s -&gt; (c) (TM) 1/2 3/4 11/2 1/2/3 &lt;=&gt; <code>a -&gt; b</code>.</p>\n"},
+		{Extensions{Smart: true, Primes: true, Arrows: true, Symbols: true, Fractions: true},
+			"<p>He is 5′10″ tall, the 1980&rsquo;s → © ™ ½ ¾ 11/2 1/2/3 ⇔ <code>a -&gt; b</code>.</p>\n"},
+		{Extensions{Primes: true, Arrows: true, Symbols: true, Fractions: true},
+			"<p>He is 5'10&quot; tall, the 1980's -&gt; (c) (TM) 1/2 3/4 11/2 1/2/3 &lt;=&gt; <code>a -&gt; b</code>.</p>\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		ext := test.ext
+		p := NewParser(&ext)
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if buf.String() != test.expected {
+			t.Errorf("unexpected output for %+v:\n%s", test.ext, buf.String())
+		}
+	}
+}
+
+func TestTextHook(t *testing.T) {
+	const input = "# Title\n\nSome \"quoted\" *text* & `code`.\n"
+	const expected = "<h1>TITLE</h1>\n\n<p>SOME &ldquo;QUOTED&rdquo; <em>TEXT</em> &amp; <code>code</code>.</p>\n"
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{Smart: true})
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{Text: strings.ToUpper}))
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestLineNumbers(t *testing.T) {
+	const input = "Code:\n\n    if a < b {\n\n        return\n    }\n"
+	const expected = `<p>Code:</p>
+
+<pre><code><span class="line"><span class="ln">1</span>if a &lt; b {</span>
+<span class="line"><span class="ln">2</span></span>
+<span class="line"><span class="ln">3</span>    return</span>
+<span class="line"><span class="ln">4</span>}</span>
+</code></pre>
+`
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{LineNumbers: true}))
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestCodeTabs(t *testing.T) {
+	const input = "\tif a {\n\t\treturn\t// x\n  \t}\n"
+	tests := []struct {
+		tabs     int
+		expected string
+	}{
+		{0, "<pre><code>if a {\n    return  // x\n}\n</code></pre>\n"},
+		{KeepTabs, "<pre><code>if a {\n\treturn\t// x\n}\n</code></pre>\n"},
+		{2, "<pre><code>if a {\n  return  // x\n}\n</code></pre>\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewParser(&Extensions{CodeTabs: test.tabs})
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if buf.String() != test.expected {
+			t.Errorf("unexpected output for CodeTabs %d:\n%q", test.tabs, buf.String())
+		}
+	}
+
+	/* tabs outside of code are expanded in any case, so that the
+	 * structure of a document does not depend on CodeTabs; tabs
+	 * in code within blockquotes are kept
+	 */
+	const quoted = ">\tnot code\n\ntext\twith `a\tb`\n\n-\titem\n\n>     code\tin quote\n"
+	const expected = "<blockquote>\n<p>not code</p>\n</blockquote>\n\n<p>text with <code>a b</code></p>\n\n<ul>\n<li>item</li>\n</ul>\n\n<blockquote>\n<pre><code>code%sin quote\n</code></pre>\n</blockquote>\n"
+	for _, tabs := range []int{0, KeepTabs} {
+		var buf bytes.Buffer
+		p := NewParser(&Extensions{CodeTabs: tabs})
+		p.Markdown(strings.NewReader(quoted), ToHTML(&buf))
+		tab := "\t"
+		if tabs == 0 {
+			tab = "  "
+		}
+		if buf.String() != fmt.Sprintf(expected, tab) {
+			t.Errorf("unexpected output for CodeTabs %d:\n%q", tabs, buf.String())
+		}
+	}
+}
+
+func TestExactCode(t *testing.T) {
+	const input = "Code:\n\n    a  \r\n      \n    \t\r\n\tb <&>\t\n    c\r\n\n    d\n"
+	tests := []struct {
+		ext      Extensions
+		expected string
+	}{
+		{Extensions{}, "<pre><code>a  \r\n\n\nb &lt;&amp;&gt;   \nc\r\n\nd\n</code></pre>\n"},
+		{Extensions{ExactCode: true, CodeTabs: KeepTabs}, "<pre><code>a  \r\n  \n\t\r\nb &lt;&amp;&gt;\t\nc\r\n\nd\n</code></pre>\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		ext := test.ext
+		p := NewParser(&ext)
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if s := strings.TrimPrefix(buf.String(), "<p>Code:</p>\n\n"); s != test.expected {
+			t.Errorf("unexpected output for %+v:\n%q", test.ext, s)
+		}
+	}
+}
+
+func TestMaxNesting(t *testing.T) {
+	tests := []struct {
+		max      int
+		expected string
+	}{
+		{0, "<p><em>a <strong>b <em>c</em> d</strong> e</em></p>\n"},
+		{2, "<p><em>a <strong>b _c_ d</strong> e</em></p>\n"},
+		{-1, "<p><em>a <strong>b <em>c</em> d</strong> e</em></p>\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewParser(&Extensions{MaxNesting: test.max})
+		p.Markdown(strings.NewReader("*a **b _c_ d** e*\n"), ToHTML(&buf))
+		if buf.String() != test.expected {
+			t.Errorf("unexpected output for MaxNesting %d:\n%s", test.max, buf.String())
+		}
+	}
+
+	// must not take exponential time
+	input := strings.Repeat("*a ", 100) + "\n"
+	p := NewParser(nil)
+	p.Markdown(strings.NewReader(input), ToHTML(new(bytes.Buffer)))
+}
+
+func TestNoIntraEmphasis(t *testing.T) {
+	const input = "snake*case*words and snake_case_words, *emph* and **strong**\n"
+	tests := []struct {
+		ext      Extensions
+		expected string
+	}{
+		{Extensions{}, "<p>snake<em>case</em>words and snake_case_words, <em>emph</em> and <strong>strong</strong></p>\n"},
+		{Extensions{NoIntraEmphasis: true}, "<p>snake*case*words and snake_case_words, <em>emph</em> and <strong>strong</strong></p>\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		ext := test.ext
+		p := NewParser(&ext)
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if buf.String() != test.expected {
+			t.Errorf("unexpected output for %+v:\n%s", test.ext, buf.String())
+		}
+	}
+}
+
+func TestAllEscapes(t *testing.T) {
+	const input = "\\~\\~no\\~\\~ \\\"quote\\\" \\' \\* \\@ \\a\n"
+	tests := []struct {
+		ext      Extensions
+		expected string
+	}{
+		{Extensions{Strike: true, Smart: true}, "<p>\\~\\~no\\~\\~ \\&ldquo;quote\\&rdquo; \\&rsquo; * \\@ \\a</p>\n"},
+		{Extensions{Strike: true, Smart: true, AllEscapes: true}, "<p>~~no~~ &quot;quote&quot; ' * @ \\a</p>\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		ext := test.ext
+		p := NewParser(&ext)
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if buf.String() != test.expected {
+			t.Errorf("unexpected output for %+v:\n%s", test.ext, buf.String())
+		}
+	}
+}
+
+func TestNumericEntities(t *testing.T) {
+	const input = "&#65; &#x263A; &amp; &#0; &#xD800; &#x110000; &#99999999999; &#x9F;\n"
+	tests := []struct {
+		replace  bool
+		expected string
+	}{
+		{false, "<p>&#65; &#x263A; &amp; &#0; &#xD800; &#x110000; &#99999999999; &#x9F;</p>\n"},
+		{true, "<p>&#65; &#x263A; &amp; &#xFFFD; &#xFFFD; &#xFFFD; &#xFFFD; &#xFFFD;</p>\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewParser(&Extensions{ReplaceEntities: test.replace})
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if buf.String() != test.expected {
+			t.Errorf("unexpected output:\n%s", buf.String())
+		}
+		if n := len(p.Diagnostics()); n != 5 {
+			t.Errorf("expected 5 diagnostics, got %v", p.Diagnostics())
+		}
+	}
+}
+
+func TestAutoLinks(t *testing.T) {
+	const input = "<http://xn--bcher-kva.example/x> <ftp://a.b/> <xyz://q> <https://bad.example/>\n"
+	tests := []struct {
+		ext      Extensions
+		expected string
+	}{
+		{Extensions{},
+			`<p><a href="http://xn--bcher-kva.example/x">http://xn--bcher-kva.example/x</a> <a href="ftp://a.b/">ftp://a.b/</a> <a href="xyz://q">xyz://q</a> <a href="https://bad.example/">https://bad.example/</a></p>`},
+		{Extensions{
+			AutoLinkSchemes: []string{"http", "https", "ftp"},
+			AutoLinkFilter: func(url string) (string, bool) {
+				if strings.Contains(url, "bad.") {
+					return "", false
+				}
+				return strings.Replace(url, "ftp:", "https:", 1), true
+			},
+			IDNDisplay: true,
+		},
+			`<p><a href="http://xn--bcher-kva.example/x">http://bücher.example/x</a> <a href="https://a.b/">ftp://a.b/</a> &lt;xyz://q&gt; &lt;https://bad.example/&gt;</p>`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		ext := test.ext
+		p := NewParser(&ext)
+		p.Markdown(strings.NewReader(input), ToHTML(&buf))
+		if s := strings.TrimSpace(buf.String()); s != test.expected {
+			t.Errorf("unexpected output:\n%s", s)
+		}
+	}
+	if s, _ := decodePunycode("wgv71a119e"); s != "日本語" {
+		t.Errorf("punycode: unexpected %q", s)
+	}
+}
+
+func TestTitles(t *testing.T) {
+	const input = "[a](/u \"x \\\"y\\\" & <z> &amp; \\a\") ![b](/i 'it's')\n"
+	tests := []struct {
+		noTitles bool
+		expected string
+	}{
+		{false, `<p><a href="/u" title="x &quot;y&quot; &amp; &lt;z&gt; &amp; \a">a</a> <img src="/i" alt="b" title="it's" /></p>`},
+		{true, `<p><a href="/u">a</a> <img src="/i" alt="b" /></p>`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		p := NewParser(nil)
+		p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{NoTitles: test.noTitles}))
+		if s := strings.TrimSpace(buf.String()); s != test.expected {
+			t.Errorf("unexpected output:\n%s", s)
+		}
+	}
+}
+
+func TestFirstNote(t *testing.T) {
+	const input = "A[^a] and B[^b].\n\n[^a]: Note a.\n\n[^b]: Note b.\n"
+	const expected = `<p>A<a class="noteref" id="fnref4" href="#fn4" title="Jump to note 4">[4]</a> and B<a class="noteref" id="fnref5" href="#fn5" title="Jump to note 5">[5]</a>.</p>
+
+<hr/>
+<ol id="notes" start="4">
+
+<li id="fn4">
+<p>Note a.</p> <a href="#fnref4" title="Jump back to reference">[back]</a>
+</li>
+
+<li id="fn5">
+<p>Note b.</p> <a href="#fnref5" title="Jump back to reference">[back]</a>
+</li>
+
+</ol>
+`
+	var buf bytes.Buffer
+	var last int
+	p := NewParser(&Extensions{Notes: true})
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{FirstNote: 4, LastNote: &last}))
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+	if last != 5 {
+		t.Errorf("expected last note 5, got %d", last)
+	}
+}
+
+func TestInlineMarkdown(t *testing.T) {
+	const input = "# A *title* with [a link](/x)\nand `code`\n\n- second\n"
+	const expected = "# A <em>title</em> with <a href=\"/x\">a link</a>\nand <code>code</code>\n- second"
+	var buf bytes.Buffer
+	p := NewParser(nil)
+	p.InlineMarkdown(input, &buf)
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%q", buf.String())
+	}
+}
+
+func TestBlocksOnly(t *testing.T) {
+	const input = "# A *title* ##\n\nSome  [text](/x) & <b>more</b>\nnext line\n\n- item `one`\n- item\n\n> quote _a_\n"
+	const expected = `<h1>A *title*</h1>
+
+<p>Some  [text](/x) &amp; &lt;b&gt;more&lt;/b&gt;
+next line</p>
+
+<ul>
+<li>item ` + "`one`" + `</li>
+<li>item</li>
+</ul>
+
+<blockquote>
+<p>quote _a_</p>
+</blockquote>
+`
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{BlocksOnly: true})
+	p.Markdown(strings.NewReader(input), ToHTML(&buf))
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestIDPrefix(t *testing.T) {
+	const input = "[TOC]\n\n# Head\n\nText[^a].\n\n[^a]: Note.\n"
+	var buf bytes.Buffer
+	p := NewParser(&Extensions{TOC: true, Notes: true})
+	p.Markdown(strings.NewReader(input), ToHTMLOpt(&buf, &HTMLOptions{IDPrefix: "p1-", Permalinks: true}))
+	for _, s := range []string{
+		`<a href="#p1-head">Head</a>`,
+		`<h1 id="p1-head">Head <a class="anchor" href="#p1-head">¶</a></h1>`,
+		`id="p1-fnref1" href="#p1-fn1"`,
+		`<ol id="p1-notes">`,
+		`<li id="p1-fn1">`,
+		`<a href="#p1-fnref1" title="Jump back to reference">`,
+	} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("output does not contain %s:\n%s", s, buf.String())
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Package markdowntest runs MarkdownTest-style suites: directories
+containing pairs of Markdown input files, ending in .text, and
+files with the expected output, like .html. It may be used to
+verify that Formatters, extensions, or forks of package markdown
+still produce the output of the original implementation.
+
+Usage example:
+
+	func TestMarkdown103(t *testing.T) {
+		p := markdown.NewParser(nil)
+		s := &markdowntest.Suite{Dir: markdowntest.CorpusDir("md1.0.3")}
+		s.Run(t, func(w *bytes.Buffer, src io.Reader) {
+			p.Markdown(src, markdown.ToHTML(w))
+		})
+	}
+*/
+package markdowntest
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"testing"
+)
+
+// A Suite describes a directory of tests.
+type Suite struct {
+	Dir string // directory containing the test files
+	Ext string // extension of files containing the expected output, ".html" if empty
+
+	// If Normalize is not nil, it is applied to both the expected
+	// and the actual output before they are compared, so that
+	// insignificant differences, like white space between HTML
+	// tags, can be ignored. See NormalizeHTML.
+	Normalize func([]byte) []byte
+}
+
+// Run calls render for each .text file of the suite that has a file
+// containing the expected output, and reports an error if the output
+// written to w differs. Each file is run as a subtest named after the file.
+func (s *Suite) Run(t *testing.T, render func(w *bytes.Buffer, src io.Reader)) {
+	ext := s.Ext
+	if ext == "" {
+		ext = ".html"
+	}
+	names, err := filepath.Glob(filepath.Join(s.Dir, "*.text"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatalf("no tests found in %s", s.Dir)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		refPath := name[:len(name)-len(".text")] + ext
+		want, err := os.ReadFile(refPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		t.Run(filepath.Base(name[:len(name)-len(".text")]), func(t *testing.T) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			src, err := os.Open(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer src.Close()
+
+			var buf bytes.Buffer
+			render(&buf, src)
+			got := buf.Bytes()
+			if s.Normalize != nil {
+				want, got = s.Normalize(want), s.Normalize(got)
+			}
+			if !bytes.Equal(want, got) {
+				t.Errorf("output differs from %s:\n%s", refPath, got)
+			}
+		})
+	}
+}
+
+var (
+	spaceBetweenTags = regexp.MustCompile(`>\s+<`)
+	spaceRuns        = regexp.MustCompile(`\s+`)
+)
+
+// NormalizeHTML removes white space between tags, and at the
+// start and end of b, and replaces any other run of white space
+// by a single space.
+func NormalizeHTML(b []byte) []byte {
+	b = spaceBetweenTags.ReplaceAll(bytes.TrimSpace(b), []byte("><"))
+	return spaceRuns.ReplaceAll(b, []byte(" "))
+}
+
+// CorpusDir returns the path of one of the test suites shipped
+// with the source code of package markdown, like "md1.0.3", John
+// Gruber's MarkdownTest_1.0.3 as imported into peg-markdown, with
+// .html and .mm (groff) files containing the expected output.
+func CorpusDir(name string) string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "tests", name)
+}
@@ -0,0 +1,29 @@
+package markdowntest
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/knieriem/markdown"
+)
+
+func TestMarkdown103(t *testing.T) {
+	p := markdown.NewParser(nil)
+	s := &Suite{Dir: CorpusDir("md1.0.3")}
+	s.Run(t, func(w *bytes.Buffer, src io.Reader) {
+		p.Markdown(src, markdown.ToHTML(w))
+	})
+
+	s.Ext = ".mm"
+	s.Run(t, func(w *bytes.Buffer, src io.Reader) {
+		p.Markdown(src, markdown.ToGroffMM(w))
+	})
+}
+
+func TestNormalizeHTML(t *testing.T) {
+	const input = "\n<p>A\n  b</p>\n\n<ul>\n<li>c</li>\n</ul>\n"
+	if s := string(NormalizeHTML([]byte(input))); s != "<p>A b</p><ul><li>c</li></ul>" {
+		t.Errorf("unexpected result: %q", s)
+	}
+}
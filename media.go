@@ -0,0 +1,73 @@
+package markdown
+
+// Video and audio elements, written using image syntax.
+
+import (
+	"strings"
+)
+
+// Elements for media files, by the extension of a URL's path.
+var mediaElems = map[string]string{
+	".mp4":  "video",
+	".m4v":  "video",
+	".webm": "video",
+	".ogv":  "video",
+	".mp3":  "audio",
+	".m4a":  "audio",
+	".oga":  "audio",
+	".ogg":  "audio",
+	".opus": "audio",
+	".wav":  "audio",
+	".flac": "audio",
+}
+
+/* mediaElem - returns the element name for a media file's URL,
+ * video or audio, or an empty string for other URLs
+ */
+func mediaElem(url string) string {
+	if i := strings.IndexAny(url, "?#"); i != -1 {
+		url = url[:i]
+	}
+	i := strings.LastIndexByte(url, '.')
+	if i == -1 || strings.IndexByte(url[i:], '/') != -1 {
+		return ""
+	}
+	return mediaElems[strings.ToLower(url[i:])]
+}
+
+/* imageKey - returns the key of an element written using image
+ * syntax: MEDIA, if the Media extension is on, and the URL
+ * refers to a video or audio file, otherwise IMAGE
+ */
+func (p *yyParser) imageKey(url string) int {
+	if p.extension.Media && mediaElem(url) != "" {
+		return MEDIA
+	}
+	return IMAGE
+}
+
+/* media - writes a video or audio element, containing a link
+ * to the file for browsers not supporting the element
+ */
+func (w *htmlOut) media(elt *Node) {
+	l := elt.contents.link
+	elem := mediaElem(l.url)
+	if elem == "" {
+		/* the URL has been changed using SetURL */
+		elem = "video"
+	}
+	w.s("<" + elem + ` src="`).str(l.url).s(`"`)
+	attrs := w.opt.MediaAttrs
+	if attrs == nil {
+		attrs = []string{"controls"}
+	}
+	for _, a := range attrs {
+		w.s(" " + a)
+	}
+	w.title(l.title)
+	w.s(`><a href="`).str(l.url).s(`">`)
+	w.inLink++
+	w.elist(l.label)
+	w.inLink--
+	w.s("</a></" + elem + ">")
+}
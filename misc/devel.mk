@@ -30,7 +30,29 @@ pprof: cmd m
 	@echo go tool pprof \'--nodefraction=0.1\' $(MD) /tmp/md.prof
 	@echo go tool pprof $(MD) /tmp/md.prof
 
+#
+# benchmarks
+#
+# `make bench' runs the benchmarks of bench_test.go, writing
+# the results to ,,bench.new. If a baseline exists in ,,bench.old,
+# it is compared with the new results using benchstat
+# (golang.org/x/perf/cmd/benchstat). `make bench-baseline' turns
+# the latest results into the baseline; run it before changing
+# the grammar.
+#
+BENCHCOUNT=5
+
+bench:
+	go test -run NONE -bench . -benchmem -count $(BENCHCOUNT) . | tee ,,bench.new
+	test ! -f ,,bench.old || benchstat ,,bench.old ,,bench.new
+
+bench-baseline: ,,bench.new
+	cp ,,bench.new ,,bench.old
+
 .PHONY:\
+	bench\
+	bench-baseline\
 	diff\
 	gofmt\
 	pprof\
+
@@ -0,0 +1,281 @@
+package markdown
+
+// Access to the nodes of a parsed document.
+
+// Key returns the kind of the node, one of LIST, RAW, SPACE, ...
+func (n *Node) Key() int {
+	return n.key
+}
+
+// SetData attaches a value to the node under the given key, so that
+// a pass transforming the document can leave information, like
+// a resolved link target, for a later pass or a renderer. Like with
+// context.WithValue, key should be of a type defined by the caller,
+// to avoid collisions between different users. A nil value removes
+// the key.
+func (n *Node) SetData(key, value interface{}) {
+	if value == nil {
+		delete(n.data, key)
+		return
+	}
+	if n.data == nil {
+		n.data = make(map[interface{}]interface{})
+	}
+	n.data[key] = value
+}
+
+// Data returns the value attached to the node under key,
+// or nil, if there is none.
+func (n *Node) Data(key interface{}) interface{} {
+	return n.data[key]
+}
+
+// A WalkStatus, returned by the function passed to Walk,
+// controls how the traversal continues.
+type WalkStatus int
+
+const (
+	GoToNext     WalkStatus = iota // continue with the node's children, or its next sibling
+	SkipChildren                   // don't visit the node's children
+	Terminate                      // stop the traversal
+)
+
+// Walk traverses the tree rooted at n in depth-first order. The
+// function fn is called twice for each node: with entering set
+// to true before the node's children are visited, and with entering
+// set to false afterwards, even if the children have been skipped.
+// The label of a link or image counts as its children. Siblings
+// of n are not visited. Walk returns Terminate if the traversal
+// has been stopped by fn, and GoToNext otherwise.
+func Walk(n *Node, fn func(n *Node, entering bool) WalkStatus) WalkStatus {
+	status := fn(n, true)
+	if status == Terminate {
+		return Terminate
+	}
+	if status != SkipChildren {
+		children := n.children
+		switch n.key {
+		case LINK, IMAGE, MEDIA, EMBED:
+			if n.contents.link != nil {
+				children = n.contents.link.label
+			}
+		}
+		for c := children; c != nil; c = c.next {
+			if Walk(c, fn) == Terminate {
+				return Terminate
+			}
+		}
+	}
+	if fn(n, false) == Terminate {
+		return Terminate
+	}
+	return GoToNext
+}
+
+// Node construction. Nodes created by these functions are not
+// allocated by a Parser, so they may be kept as long as needed.
+
+// NewNode returns a node of the given kind, like PARA or EMPH,
+// with the given children.
+func NewNode(key int, children ...*Node) *Node {
+	n := &Node{key: key}
+	for _, c := range children {
+		n.AppendChild(c)
+	}
+	return n
+}
+
+// NewText returns a STR node containing s.
+func NewText(s string) *Node {
+	return &Node{key: STR, contents: contents{str: s}}
+}
+
+// NewLink returns a LINK node with the given label.
+func NewLink(url, title string, label ...*Node) *Node {
+	n := &Node{key: LINK, contents: contents{link: &link{url: url, title: title}}}
+	for _, c := range label {
+		n.AppendChild(c)
+	}
+	return n
+}
+
+// NewImage returns an IMAGE node, with alt as alternative text.
+func NewImage(url, title string, alt ...*Node) *Node {
+	n := NewLink(url, title, alt...)
+	n.key = IMAGE
+	return n
+}
+
+// NewList returns a BULLETLIST or ORDEREDLIST node containing
+// a LISTITEM for each of the items, which are lists of blocks.
+func NewList(key int, items ...[]*Node) *Node {
+	n := NewNode(key)
+	for _, blocks := range items {
+		n.AppendChild(NewNode(LISTITEM, blocks...))
+	}
+	return n
+}
+
+// Accessors.
+
+// FirstChild returns the first child of n, or nil.
+// For links, images, media, and embeds, this is the first node of the label.
+func (n *Node) FirstChild() *Node {
+	switch n.key {
+	case LINK, IMAGE, MEDIA, EMBED:
+		if n.contents.link == nil {
+			return nil
+		}
+		return n.contents.link.label
+	}
+	return n.children
+}
+
+// Next returns the next sibling of n, or nil.
+func (n *Node) Next() *Node {
+	return n.next
+}
+
+// Text returns the text of a STR, CODE, HTML, or similar node.
+func (n *Node) Text() string {
+	return n.contents.str
+}
+
+// SetText sets the text of a STR, CODE, HTML, or similar node.
+func (n *Node) SetText(s string) {
+	n.contents.str = s
+}
+
+// Line returns the number of the line a block starts on, or 0,
+// if it is unknown, as for inline elements, or the contents of
+// notes referenced from another block.
+func (n *Node) Line() int {
+	return n.line
+}
+
+// URL returns the URL of a LINK, IMAGE, MEDIA, or EMBED node, or the one
+// a TAG or MENTION node has been resolved to.
+func (n *Node) URL() string {
+	if n.contents.link == nil {
+		return ""
+	}
+	return n.contents.link.url
+}
+
+// Title returns the title of a LINK, IMAGE, MEDIA, or EMBED node.
+func (n *Node) Title() string {
+	if n.contents.link == nil {
+		return ""
+	}
+	return n.contents.link.title
+}
+
+// SetURL changes the URL of a LINK, IMAGE, MEDIA, or EMBED node. If the link
+// has been resolved with a reference definition, it is no longer
+// affected by Document.RewriteReferences.
+func (n *Node) SetURL(url string) {
+	if n.contents.link != nil {
+		l := *n.contents.link /* the link may be shared with a reference */
+		l.url = url
+		l.ref = nil
+		n.contents.link = &l
+	}
+}
+
+// Mutation. As nodes don't know their parents, the functions
+// modifying a list of children are methods of the parent.
+// Nodes to be inserted must not be part of a list yet, i.e.
+// they must have no next sibling; otherwise, they panic.
+
+func (n *Node) childList() **Node {
+	switch n.key {
+	case LINK, IMAGE, MEDIA, EMBED:
+		if n.contents.link == nil {
+			n.contents.link = new(link)
+		}
+		return &n.contents.link.label
+	}
+	return &n.children
+}
+
+func checkDetached(c *Node) {
+	if c.next != nil {
+		panic("markdown: node to be inserted has siblings")
+	}
+}
+
+// AppendChild adds c to the end of n's children.
+func (n *Node) AppendChild(c *Node) {
+	checkDetached(c)
+	p := n.childList()
+	for *p != nil {
+		p = &(*p).next
+	}
+	*p = c
+}
+
+// PrependChild inserts c before the first child of n.
+func (n *Node) PrependChild(c *Node) {
+	checkDetached(c)
+	p := n.childList()
+	c.next = *p
+	*p = c
+}
+
+// InsertAfter inserts c as next sibling of n.
+func (n *Node) InsertAfter(c *Node) {
+	checkDetached(c)
+	c.next = n.next
+	n.next = c
+}
+
+// InsertBefore inserts c before ref, one of n's children.
+// It returns false if ref is not a child of n.
+func (n *Node) InsertBefore(c, ref *Node) bool {
+	checkDetached(c)
+	p := n.findChild(ref)
+	if p == nil {
+		return false
+	}
+	c.next = ref
+	*p = c
+	return true
+}
+
+// RemoveChild removes c from the children of n. It returns
+// false if c is not a child of n.
+func (n *Node) RemoveChild(c *Node) bool {
+	p := n.findChild(c)
+	if p == nil {
+		return false
+	}
+	*p = c.next
+	c.next = nil
+	return true
+}
+
+// ReplaceChild replaces old, one of n's children, by c.
+// It returns false if old is not a child of n.
+func (n *Node) ReplaceChild(old, c *Node) bool {
+	checkDetached(c)
+	p := n.findChild(old)
+	if p == nil {
+		return false
+	}
+	c.next = old.next
+	*p = c
+	old.next = nil
+	return true
+}
+
+/* findChild - returns the pointer pointing to c within
+ * n's list of children, or nil
+ */
+func (n *Node) findChild(c *Node) **Node {
+	for p := n.childList(); *p != nil; p = &(*p).next {
+		if *p == c {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package markdown
+
+// Open Graph metadata.
+
+import (
+	"strings"
+)
+
+// OpenGraph holds values for the Open Graph meta tags
+// og:title, og:description, and og:image of a web page.
+type OpenGraph struct {
+	Title       string // see Document.Title
+	Description string // plain text of the first top-level paragraph
+	Image       string // URL of the first image
+}
+
+// OpenGraph derives Open Graph metadata from the document.
+// White space in the description is collapsed into single spaces.
+func (d *Document) OpenGraph() OpenGraph {
+	og := OpenGraph{Title: d.Title()}
+	for _, tree := range d.blocks {
+		for b := tree; b != nil; b = b.next {
+			if og.Description == "" && b.key == PARA {
+				og.Description = strings.Join(strings.Fields(inlineText(b.children)), " ")
+			}
+		}
+		if og.Image == "" {
+			walkElements(tree, func(el *Node) {
+				if og.Image == "" && el.key == IMAGE {
+					og.Image = el.contents.link.url
+				}
+			})
+		}
+	}
+	return og
+}
@@ -20,25 +20,56 @@ package markdown
 // groff mm output functions
 
 import (
+	"bytes"
 	"log"
 	"strings"
 )
 
+// Options controlling the groff mm output.
+type GroffOptions struct {
+	// If Width is greater than zero, the text of paragraphs
+	// is filled into lines not longer than Width characters, if
+	// possible, without breaking links or code spans. By default,
+	// line breaks are kept as they are.
+	Width int
+
+	// If LiteralCode is set, characters groff would typeset
+	// differently in code spans and blocks, like ' and `, which
+	// become typographic quotes, or -, which becomes a hyphen,
+	// are escaped, so that the code appears as written.
+	LiteralCode bool
+}
+
 type troffOut struct {
 	baseWriter
+	opt                GroffOptions
 	strikeMacroWritten bool
 	inListItem         bool
+	nobreak            int  /* > 0 within links and requests, where lines must not be broken */
+	filling            bool /* within text being filled, see text */
 	escape             *strings.Replacer
+	codeEscape         *strings.Replacer /* used if LiteralCode is set */
 }
 
 // Returns a formatter that writes the document in groff mm format.
 func ToGroffMM(w Writer) Formatter {
+	return ToGroffMMOpt(w, nil)
+}
+
+// Like ToGroffMM, but allows to adjust the output using options.
+func ToGroffMMOpt(w Writer, opt *GroffOptions) Formatter {
 	f := new(troffOut)
-	f.baseWriter = baseWriter{w, 2}
+	f.baseWriter = baseWriter{Writer: w, padded: 2}
+	if opt != nil {
+		f.opt = *opt
+	}
 	f.escape = strings.NewReplacer(`\`, `\e`)
+	if f.opt.LiteralCode {
+		f.codeEscape = strings.NewReplacer(`\`, `\e`, "'", `\(aq`, "`", `\(ga`, "-", `\-`, "^", `\(ha`, "~", `\(ti`)
+	}
 	return f
 }
-func (f *troffOut) FormatBlock(tree *element) {
+func (f *troffOut) FormatBlock(tree *Node) {
 	f.elist(tree)
 }
 func (f *troffOut) Finish() {
@@ -76,19 +107,49 @@ func (w *troffOut) str(s string) *troffOut {
 	return w
 }
 
-func (w *troffOut) children(el *element) *troffOut {
+// write code, escaped according to LiteralCode
+func (w *troffOut) code(s string) *troffOut {
+	if w.codeEscape == nil {
+		return w.str(s)
+	}
+	if strings.HasPrefix(s, ".") {
+		w.WriteString(`\[char46]`)
+		s = s[1:]
+	}
+	w.codeEscape.WriteString(w, s)
+	return w
+}
+
+func (w *troffOut) children(el *Node) *troffOut {
 	return w.elist(el.children)
 }
-func (w *troffOut) inline(pfx string, el *element, sfx string) *troffOut {
+func (w *troffOut) inline(pfx string, el *Node, sfx string) *troffOut {
 	return w.s(pfx).children(el).s(sfx)
 }
 
+// write the inlines of a paragraph, filling them into lines
+// of the configured width; a paragraph's text starts on a new line
+func (w *troffOut) text(el *Node) *troffOut {
+	if w.opt.Width <= 0 {
+		return w.children(el)
+	}
+	var buf bytes.Buffer
+	out := w.Writer
+	w.Writer, w.filling = &buf, true
+	w.children(el)
+	w.Writer, w.filling = out, false
+	return w.s(fillLines(buf.String(), 0, 0, w.opt.Width, func(word string) bool {
+		/* a line starting with a dot or an apostrophe is a request */
+		return strings.HasPrefix(word, ".") || strings.HasPrefix(word, "'")
+	}))
+}
+
 func (w *troffOut) req(name string) *troffOut {
 	return w.br().s(".").s(name)
 }
 
 // write a list of elements
-func (w *troffOut) elist(list *element) *troffOut {
+func (w *troffOut) elist(list *Node) *troffOut {
 	for i := 0; list != nil; i++ {
 		w.elem(list, i == 0)
 		list = list.next
@@ -96,12 +157,15 @@ func (w *troffOut) elist(list *element) *troffOut {
 	return w
 }
 
-func (w *troffOut) elem(elt *element, isFirst bool) *troffOut {
+func (w *troffOut) elem(elt *Node, isFirst bool) *troffOut {
 	var s string
 
 	switch elt.key {
 	case SPACE:
 		s = elt.contents.str
+		if w.filling && w.nobreak == 0 {
+			s = breakMark
+		}
 	case LINEBREAK:
 		w.req("br\n")
 	case STR:
@@ -119,13 +183,15 @@ func (w *troffOut) elem(elt *element, isFirst bool) *troffOut {
 	case DOUBLEQUOTED:
 		w.inline(`\[lq]`, elt, `\[rq]`)
 	case CODE:
-		w.s(`\fC`).str(elt.contents.str).s(`\fR`)
+		w.s(`\fC`).code(elt.contents.str).s(`\fR`)
 	case HTML:
 		/* don't print HTML */
-	case LINK:
+	case LINK, MEDIA, EMBED:
 		link := elt.contents.link
+		w.nobreak++
 		w.elist(link.label)
 		w.s(" (").s(link.url).s(")")
+		w.nobreak--
 	case IMAGE:
 		w.s("[IMAGE: ").elist(elt.contents.link.label).s("]")
 		/* not supported */
@@ -143,8 +209,10 @@ func (w *troffOut) elem(elt *element, isFirst bool) *troffOut {
 `)
 			w.strikeMacroWritten = true
 		}
+		w.nobreak++
 		w.inline(".ST \"", elt, `"`).br()
-	case LIST:
+		w.nobreak--
+	case LIST, SPOILER, CONTAINER:
 		w.children(elt)
 	case RAW:
 		/* Shouldn't occur - these are handled by process_raw_blocks() */
@@ -153,12 +221,12 @@ func (w *troffOut) elem(elt *element, isFirst bool) *troffOut {
 		h := ".H " + string('1'+elt.key-H1) + ` "` /* assumes H1 ... H6 are in order */
 		w.br().inline(h, elt, `"`)
 	case PLAIN:
-		w.br().children(elt)
+		w.br().text(elt)
 	case PARA:
 		if !w.inListItem || !isFirst {
-			w.req("P\n").children(elt)
+			w.req("P\n").text(elt)
 		} else {
-			w.br().children(elt)
+			w.br().text(elt)
 		}
 	case HRULE:
 		w.br().s(`\l'\n(.lu*8u/10u'`)
@@ -166,12 +234,17 @@ func (w *troffOut) elem(elt *element, isFirst bool) *troffOut {
 		/* don't print HTML block */
 	case VERBATIM:
 		w.req("VERBON 2\n")
-		w.str(elt.contents.str)
+		w.code(elt.contents.str)
 		w.s(".VERBOFF")
 	case BULLETLIST:
 		w.req("BL").children(elt).req("LE 1")
 	case ORDEREDLIST:
-		w.req("AL").children(elt).req("LE 1")
+		w.req("AL")
+		if elt.contents.str != "" {
+			typ, _ := parseEnumerator(elt.contents.str)
+			w.s(" ").s(string(typ))
+		}
+		w.children(elt).req("LE 1")
 	case DEFINITIONLIST:
 		w.req(`BVL \\n(Pin`).children(elt).req("LE 1")
 	case DEFTITLE:
@@ -200,8 +273,22 @@ func (w *troffOut) elem(elt *element, isFirst bool) *troffOut {
 			w.children(elt)
 			w.req("FE\n")
 		}
+	case TABLE:
+		w.table(elt)
+	case TABLEHEAD, TABLEROW, TABLECELL:
+		/* printed by table */
+	case TOC:
+		w.children(elt)
+	case CITATIONLINE:
+		w.req("br\n").s(`\[em] `).children(elt)
 	case REFERENCE:
 		/* Nonprinting */
+	case INDEXTERM:
+		/* not supported */
+	case COMMENT, SCRIPT:
+		/* not printed */
+	case TAG, MENTION:
+		w.str(tagText(elt))
 	default:
 		log.Fatalf("troffOut.elem encountered unknown element key = %d\n", elt.key)
 	}
@@ -210,3 +297,51 @@ func (w *troffOut) elem(elt *element, isFirst bool) *troffOut {
 	}
 	return w
 }
+
+// write a TABLE element for tbl; header rows are printed in bold,
+// cells are text blocks, so that they may hold lists and the like
+func (w *troffOut) table(table *Node) {
+	var rows []*Node
+	nhead := 0
+	for r := table.children; r != nil; r = r.next {
+		if r.key != TABLEHEAD {
+			rows = append(rows, r)
+			continue
+		}
+		for hr := r.children; hr != nil; hr = hr.next {
+			rows = append(rows, hr)
+			nhead++
+		}
+	}
+	cols := 0
+	for _, r := range rows {
+		n := 0
+		for c := r.children; c != nil; c = c.next {
+			n++
+		}
+		if n > cols {
+			cols = n
+		}
+	}
+	if cols == 0 {
+		return
+	}
+	w.req("TS\nallbox;")
+	for i := 0; i < nhead; i++ {
+		w.br().s(strings.Repeat("lb ", cols-1) + "lb")
+	}
+	w.br().s(strings.Repeat("l ", cols-1) + "l.")
+	for _, r := range rows {
+		w.br()
+		for c := r.children; c != nil; c = c.next {
+			if c != r.children {
+				w.s("\t")
+			}
+			w.s("T{\n")
+			w.skipPadding()
+			w.children(c)
+			w.br().s("T}")
+		}
+	}
+	w.req("TE")
+}
@@ -0,0 +1,643 @@
+package markdown
+
+// Markdown output functions
+
+import (
+	"bytes"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Options controlling the Markdown output.
+type MarkdownOptions struct {
+	// Bullet is the marker of bullet list items,
+	// one of '*', '-', or '+'. It defaults to '*'.
+	Bullet byte
+
+	// If SameNumbers is set, all items of an ordered list get
+	// the number of the first item, like "1.", so that inserting
+	// an item does not change the lines of the following ones.
+	// Otherwise, items are numbered consecutively. Enumerators
+	// other than numbers, see Extensions.FancyLists, are kept.
+	SameNumbers bool
+
+	// If FencedCode is set, code blocks are enclosed in lines
+	// of three backticks instead of being indented. Note that
+	// this package's parser does not recognize fenced code
+	// blocks; the output is meant for other implementations.
+	FencedCode bool
+
+	// If Width is greater than zero, the text of paragraphs
+	// is filled into lines not longer than Width characters, if
+	// possible. Links and code spans are not broken. By default,
+	// line breaks are kept as they are.
+	Width int
+}
+
+type markdownOut struct {
+	baseWriter
+	opt MarkdownOptions
+
+	prefix     []string /* prefixes of the lines of nested blocks, like "> " */
+	bol        bool     /* at the beginning of a line; the prefix has not been written yet */
+	lineStart  bool     /* nothing but a prefix or list marker has been written on the current line */
+	lineDigits bool     /* nothing but digits have been written after the line start */
+	afterPlain bool     /* the previous block has been a PLAIN block of a tight list */
+	col        int      /* column of the next character */
+	nobreak    int      /* > 0 within links, where lines must not be broken */
+	inText     bool     /* within the inlines of a paragraph */
+	comment    bool     /* a comment has been written within a paragraph */
+
+	notes    []*Node /* notes to print after the main content */
+	noteNums map[*Node]int
+}
+
+// ToMarkdown returns a formatter that writes the document
+// in Markdown format, like a pretty-printer. Reference
+// definitions and links using them are kept as such; notes
+// defined separately are numbered, and moved to the end
+// of the document.
+func ToMarkdown(w Writer) Formatter {
+	return ToMarkdownOpt(w, nil)
+}
+
+// Like ToMarkdown, but allows to adjust the output using options.
+func ToMarkdownOpt(w Writer, opt *MarkdownOptions) Formatter {
+	f := new(markdownOut)
+	f.baseWriter = baseWriter{Writer: w, padded: 2}
+	if opt != nil {
+		f.opt = *opt
+	}
+	switch f.opt.Bullet {
+	case '-', '+':
+	default:
+		f.opt.Bullet = '*'
+	}
+	f.bol = true
+	f.lineStart = true
+	f.noteNums = make(map[*Node]int)
+	return f
+}
+func (f *markdownOut) FormatBlock(tree *Node) {
+	f.elist(tree)
+}
+func (f *markdownOut) Finish() {
+	for i, note := range f.notes {
+		f.sp().s("[^" + strconv.Itoa(i+1) + "]: ")
+		f.indent("    ", func() {
+			f.padded = 2
+			f.elist(note.children)
+		})
+	}
+	f.WriteByte('\n')
+	f.padded = 2
+	f.bol = true
+	f.lineStart = true
+	f.afterPlain = false
+	f.notes = nil
+	f.noteNums = make(map[*Node]int)
+}
+
+// pad - like baseWriter.pad, but blank lines get the prefix
+// of the current block, with trailing spaces removed, so that
+// they remain part of a blockquote. As the padding is kept
+// until text is written, consecutive calls don't add up.
+func (w *markdownOut) pad(n int) {
+	for ; n > w.padded; w.padded++ {
+		if w.bol {
+			w.WriteString(strings.TrimRight(strings.Join(w.prefix, ""), " "))
+		}
+		w.WriteByte('\n')
+		w.bol = true
+		w.lineStart = true
+	}
+}
+
+func (w *markdownOut) br() *markdownOut {
+	w.pad(1)
+	return w
+}
+
+func (w *markdownOut) sp() *markdownOut {
+	w.pad(2)
+	return w
+}
+
+// start a block, separated from the previous one by an
+// empty line, unless it follows the first line of a tight
+// list item
+func (w *markdownOut) block() *markdownOut {
+	if w.afterPlain {
+		w.br()
+	} else {
+		w.sp()
+	}
+	w.afterPlain = false
+	return w
+}
+
+// write s, prefixing each line with the prefixes of the
+// enclosing blocks
+func (w *markdownOut) s(s string) *markdownOut {
+	for s != "" {
+		if w.bol {
+			pfx := strings.Join(w.prefix, "")
+			w.WriteString(pfx)
+			w.col = utf8.RuneCountInString(pfx)
+			w.bol = false
+		}
+		line := s
+		if i := strings.IndexByte(s, '\n'); i != -1 {
+			line = s[:i+1]
+			w.bol = true
+			w.lineStart = true
+			w.col = 0
+		} else {
+			w.lineStart = false
+			w.col += utf8.RuneCountInString(line)
+		}
+		w.lineDigits = false
+		w.WriteString(line)
+		w.padded = 0
+		s = s[len(line):]
+	}
+	return w
+}
+
+// run fn with pfx added to the prefixes of lines
+func (w *markdownOut) indent(pfx string, fn func()) {
+	w.prefix = append(w.prefix, pfx)
+	fn()
+	w.prefix = w.prefix[:len(w.prefix)-1]
+}
+
+// write text, escaping characters that would
+// otherwise be interpreted as markup
+func (w *markdownOut) str(s string, next *Node) *markdownOut {
+	var b strings.Builder
+	/* a number at the start of a line may be followed by a period
+	 * in the next string, as `1986\.' is parsed as "1986" and "."
+	 */
+	digits := (w.lineStart || w.lineDigits) && s != "" && strings.Trim(s, "0123456789") == ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\\', '`', '*', '_', '[', ']', '<':
+			b.WriteByte('\\')
+		case '#', '>', '+', '-':
+			if i == 0 && w.lineStart {
+				b.WriteByte('\\')
+			}
+		case '.':
+			if (i > 0 && w.lineStart || i == 0 && w.lineDigits) && strings.Trim(s[:i], "0123456789") == "" {
+				b.WriteByte('\\')
+			}
+		case '!':
+			if i == len(s)-1 && next != nil && next.key == LINK {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteByte(c)
+	}
+	w.s(b.String())
+	w.lineDigits = digits
+	return w
+}
+
+func (w *markdownOut) children(el *Node) *markdownOut {
+	return w.elist(el.children)
+}
+func (w *markdownOut) inline(delim string, el *Node) *markdownOut {
+	return w.s(delim).children(el).s(delim)
+}
+
+// write a list of elements
+func (w *markdownOut) elist(list *Node) *markdownOut {
+	for list != nil {
+		w.elem(list)
+		list = list.next
+	}
+	return w
+}
+
+func (w *markdownOut) elem(elt *Node) *markdownOut {
+	switch elt.key {
+	case SPACE:
+		if w.comment {
+			/* the line break ending a comment must be kept */
+			w.s("\n")
+			w.comment = false
+		} else if w.opt.Width > 0 && w.inText && w.nobreak == 0 {
+			/* only the text of paragraphs is filled, see text */
+			w.s(breakMark)
+		} else {
+			w.s(elt.contents.str)
+		}
+	case LINEBREAK:
+		w.s("  \n")
+	case STR:
+		w.str(elt.contents.str, elt.next)
+	case ELLIPSIS:
+		w.s("...")
+	case EMDASH:
+		w.s("---")
+	case ENDASH:
+		w.s("-")
+	case APOSTROPHE:
+		w.s("'")
+	case SINGLEQUOTED:
+		w.inline("'", elt)
+	case DOUBLEQUOTED:
+		w.inline(`"`, elt)
+	case CODE:
+		w.code(elt.contents.str)
+	case HTML:
+		w.s(elt.contents.str)
+	case LINK, IMAGE, MEDIA, EMBED:
+		w.link(elt)
+	case EMPH:
+		w.inline("*", elt)
+	case STRONG:
+		w.inline("**", elt)
+	case STRIKE:
+		w.inline("~~", elt)
+	case SPOILER:
+		w.inline("||", elt)
+	case LIST:
+		w.children(elt)
+	case RAW:
+		/* Shouldn't occur - these are handled by process_raw_blocks() */
+		log.Fatalf("RAW")
+	case H1, H2, H3, H4, H5, H6:
+		w.block().s(strings.Repeat("#", 1+elt.key-H1) + " ").children(elt).lang(elt)
+	case PLAIN:
+		w.block().text(elt)
+		w.afterPlain = true
+	case PARA:
+		w.block().text(elt).lang(elt)
+	case HRULE:
+		w.block().s("* * *")
+	case HTMLBLOCK:
+		w.block().s(strings.TrimRight(elt.contents.str, "\n"))
+	case VERBATIM:
+		code := strings.TrimSuffix(elt.contents.str, "\n")
+		if w.opt.FencedCode {
+			w.block().s("```\n" + code + "\n```")
+		} else {
+			w.block().indent("    ", func() {
+				w.s(code)
+			})
+		}
+	case BULLETLIST:
+		w.block()
+		for item := elt.children; item != nil; item = item.next {
+			w.listItem(string(w.opt.Bullet)+" ", item)
+		}
+	case ORDEREDLIST:
+		w.block().orderedList(elt)
+	case DEFINITIONLIST:
+		w.block().children(elt)
+	case DEFTITLE:
+		w.br().children(elt)
+	case DEFDATA:
+		w.listItem(":   ", elt)
+	case LISTITEM:
+		w.listItem(string(w.opt.Bullet)+" ", elt)
+	case BLOCKQUOTE:
+		w.block()
+		w.s("> ")
+		w.lineStart = true
+		w.indent("> ", func() {
+			w.padded = 2
+			w.children(elt)
+		})
+	case CONTAINER:
+		w.block().s("::: " + elt.contents.str)
+		if attrs := elt.Attrs(); attrs != nil {
+			w.s(" {")
+			for i, a := range attrs {
+				if i > 0 {
+					w.s(" ")
+				}
+				switch a.Key {
+				case "id":
+					w.s("#" + a.Value)
+				case "class":
+					w.s("." + strings.Replace(a.Value, " ", " .", -1))
+				default:
+					w.s(a.Key + `="` + a.Value + `"`)
+				}
+			}
+			w.s("}")
+		}
+		w.s("\n")
+		w.padded = 2
+		w.children(elt)
+		w.block().s(":::")
+	case TABLE:
+		w.block().s(w.gridTable(elt))
+	case CITATIONLINE:
+		w.br().s("-- ").children(elt)
+	case COMMENT:
+		if w.inText {
+			w.s("\n" + commentLines(elt.contents.str))
+			w.comment = true
+		} else {
+			w.block().s(commentLines(elt.contents.str))
+		}
+	case SCRIPT:
+		if w.inText {
+			w.s(elt.contents.str)
+		} else {
+			w.block().s(elt.contents.str)
+		}
+	case TOC:
+		w.block().s("[TOC]")
+	case REFERENCE:
+		l := elt.contents.link
+		w.block().s("[").elist(l.label).s("]: ").s(l.url)
+		if l.title != "" {
+			w.s(` "`).s(l.title).s(`"`)
+		}
+	case INDEXTERM:
+		w.s("[[").s(elt.contents.str).s("]]")
+	case TAG, MENTION:
+		w.s(tagText(elt))
+	case NOTE:
+		/* if contents.str == 0, then print note; else ignore, since this
+		 * is a note block that has been incorporated into the notes list
+		 */
+		if elt.contents.str == "" && isInlineNote(elt) {
+			w.s("^[").children(elt).s("]")
+		} else if elt.contents.str == "" {
+			nn, ok := w.noteNums[elt.children]
+			if !ok || elt.children == nil {
+				w.notes = append(w.notes, elt)
+				nn = len(w.notes)
+				w.noteNums[elt.children] = nn
+			}
+			w.s("[^" + strconv.Itoa(nn) + "]")
+		}
+	default:
+		log.Fatalf("markdownOut.elem encountered unknown element key = %d\n", elt.key)
+	}
+	return w
+}
+
+// write the inlines of a paragraph, filling them into lines
+// of the configured width
+func (w *markdownOut) text(el *Node) *markdownOut {
+	w.inText = true
+	if w.opt.Width <= 0 {
+		w.children(el)
+	} else {
+		indent := utf8.RuneCountInString(strings.Join(w.prefix, ""))
+		col := w.col
+		if w.bol {
+			col = indent
+		}
+		var buf bytes.Buffer
+		out, prefix, bol := w.Writer, w.prefix, w.bol
+		w.Writer, w.prefix, w.bol = &buf, nil, false
+		w.children(el)
+		w.Writer, w.prefix, w.bol, w.col = out, prefix, bol, col
+		w.s(fillLines(buf.String(), col, indent, w.opt.Width, keepMarkdownWord))
+	}
+	w.inText, w.comment = false, false
+	return w
+}
+
+/* keepMarkdownWord - returns true if word would be taken as the
+ * start of a block, like a heading or list item, or as underline
+ * of a heading, if it was moved to the beginning of a line
+ */
+func keepMarkdownWord(word string) bool {
+	if word == "" {
+		return false
+	}
+	switch word[0] {
+	case '#', '>', '+', '-', '*', '=':
+		return true
+	}
+	digits := strings.TrimLeft(word, "0123456789")
+	return len(digits) < len(word) && digits != "" && (digits[0] == '.' || digits[0] == ')')
+}
+
+// write a language attribute, if a language is attached to the element
+func (w *markdownOut) lang(el *Node) *markdownOut {
+	if l := el.Lang(); l != "" {
+		w.s(" {lang=" + l + "}")
+	}
+	return w
+}
+
+// write a code span, delimited by a run of backticks
+// longer than any run contained in the code
+func (w *markdownOut) code(s string) *markdownOut {
+	n, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '`' {
+			run++
+			if run > n {
+				n = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	ticks := strings.Repeat("`", n+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return w.s(ticks + " " + s + " " + ticks)
+	}
+	return w.s(ticks + s + ticks)
+}
+
+// write a link or image, either as automatic link, using
+// its reference definition, or inline
+func (w *markdownOut) link(elt *Node) *markdownOut {
+	l := elt.contents.link
+	if (elt.key == LINK || elt.key == EMBED) && l.ref == nil && l.title == "" && l.label != nil && l.label.next == nil && l.label.key == STR {
+		if t := l.label.contents.str; t == l.url || "mailto:"+t == l.url {
+			return w.s("<" + t + ">")
+		}
+	}
+	if elt.key == IMAGE || elt.key == MEDIA {
+		w.s("!")
+	}
+	w.nobreak++
+	w.s("[").elist(l.label).s("]")
+	w.nobreak--
+	switch {
+	case l.ref == nil:
+		w.s("(").s(l.url)
+		if l.title != "" {
+			w.s(` "`).s(l.title).s(`"`)
+		}
+		w.s(")")
+	case sameLabel(l.label, l.ref.label):
+		w.s("[]")
+	default:
+		w.s("[").elist(l.ref.label).s("]")
+	}
+	return w
+}
+
+// write a list item, using marker for its first line
+// and indenting further lines by four spaces
+func (w *markdownOut) listItem(marker string, item *Node) *markdownOut {
+	loose := false
+	for c := item.children; c != nil; c = c.next {
+		if c.key == LIST && c.children != nil {
+			c = c.children
+		}
+		loose = c.key == PARA
+		break
+	}
+	if loose {
+		w.sp()
+	} else {
+		w.br()
+	}
+	w.s(marker)
+	w.lineStart = true
+	w.indent("    ", func() {
+		w.padded = 2
+		w.afterPlain = false
+		w.children(item)
+	})
+	w.afterPlain = false
+	return w
+}
+
+// write an ordered list, numbering its items
+// according to the options
+func (w *markdownOut) orderedList(elt *Node) *markdownOut {
+	n := 1
+	if item := elt.children; item != nil && item.contents.str != "" {
+		var typ byte
+		typ, n = parseEnumerator(item.contents.str)
+		if typ != '1' {
+			/* keep letters and roman numerals */
+			for ; item != nil; item = item.next {
+				w.listItem(item.contents.str+" ", item)
+			}
+			return w
+		}
+	}
+	for item := elt.children; item != nil; item = item.next {
+		w.listItem(strconv.Itoa(n)+". ", item)
+		if !w.opt.SameNumbers {
+			n++
+		}
+	}
+	return w
+}
+
+/* isInlineNote - returns true for notes like ^[text], which
+ * contain inlines, unlike notes defined separately, which
+ * contain blocks
+ */
+func isInlineNote(note *Node) bool {
+	c := note.children
+	for c != nil && c.key == LIST {
+		c = c.children
+	}
+	return c == nil || c.key < PLAIN || c.key == INDEXTERM
+}
+
+/* commentLines - returns the lines of a comment, each starting with %%
+ */
+func commentLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("%% "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// gridTable returns a TABLE element as a grid table, with
+// columns as wide as the widest line of their cells
+func (w *markdownOut) gridTable(table *Node) string {
+	type row struct {
+		cells [][]string
+		head  bool
+	}
+	var rows []row
+	var widths []int
+	add := func(r *Node, head bool) {
+		var cells [][]string
+		for i, c := 0, r.children; c != nil; i, c = i+1, c.next {
+			lines := strings.Split(w.cellText(c), "\n")
+			for _, l := range lines {
+				if i == len(widths) {
+					widths = append(widths, 1)
+				}
+				if n := utf8.RuneCountInString(l); n > widths[i] {
+					widths[i] = n
+				}
+			}
+			cells = append(cells, lines)
+		}
+		rows = append(rows, row{cells, head})
+	}
+	for r := table.children; r != nil; r = r.next {
+		if r.key == TABLEHEAD {
+			for hr := r.children; hr != nil; hr = hr.next {
+				add(hr, true)
+			}
+		} else {
+			add(r, false)
+		}
+	}
+
+	border := func(c string) string {
+		b := "+"
+		for _, n := range widths {
+			b += strings.Repeat(c, n+2) + "+"
+		}
+		return b + "\n"
+	}
+	var b strings.Builder
+	b.WriteString(border("-"))
+	for i, r := range rows {
+		height := 1
+		for _, lines := range r.cells {
+			if len(lines) > height {
+				height = len(lines)
+			}
+		}
+		for k := 0; k < height; k++ {
+			b.WriteString("|")
+			for j, n := range widths {
+				l := ""
+				if j < len(r.cells) && k < len(r.cells[j]) {
+					l = r.cells[j][k]
+				}
+				b.WriteString(" " + l + strings.Repeat(" ", n-utf8.RuneCountInString(l)) + " |")
+			}
+			b.WriteString("\n")
+		}
+		if r.head && (i+1 == len(rows) || !rows[i+1].head) {
+			b.WriteString(border("="))
+		} else {
+			b.WriteString(border("-"))
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// cellText returns the contents of a table cell in Markdown
+// format; notes are numbered along with those of the document
+func (w *markdownOut) cellText(cell *Node) string {
+	var buf bytes.Buffer
+	c := &markdownOut{baseWriter: baseWriter{Writer: &buf, padded: 2}, opt: w.opt}
+	c.opt.Width = 0
+	c.bol = true
+	c.lineStart = true
+	c.notes, c.noteNums = w.notes, w.noteNums
+	c.children(cell)
+	w.notes = c.notes
+	return strings.Trim(buf.String(), "\n")
+}
@@ -21,8 +21,11 @@ package markdown
 
 import (
 	"fmt"
+	"io/fs"
 	"log"
 	"math/rand"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -36,31 +39,390 @@ type Writer interface {
 type baseWriter struct {
 	Writer
 	padded int
+	err    error /* first error returned by Writer */
+	count  int   /* number of bytes written since the last flush */
+}
+
+/* Write functions of baseWriter record the first error
+ * returned by the underlying Writer, after which output
+ * is discarded.
+ */
+func (w *baseWriter) Write(b []byte) (n int, err error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err = w.Writer.Write(b)
+	w.count += n
+	w.err = err
+	return
+}
+
+func (w *baseWriter) WriteString(s string) (n int, err error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err = w.Writer.WriteString(s)
+	w.count += n
+	w.err = err
+	return
+}
+
+func (w *baseWriter) WriteRune(r rune) (n int, err error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err = w.Writer.WriteRune(r)
+	w.count += n
+	w.err = err
+	return
+}
+
+func (w *baseWriter) WriteByte(c byte) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.Writer.WriteByte(c)
+	if w.err == nil {
+		w.count++
+	}
+	return w.err
+}
+
+/* flush - calls fn, if at least min bytes have been written
+ * since the previous call, recording its error
+ */
+func (w *baseWriter) flush(fn func() error, min int) {
+	if w.err != nil || w.count == 0 || w.count < min {
+		return
+	}
+	w.err = fn()
+	w.count = 0
+}
+
+// Err returns the first error that occurred writing the output.
+// The formatters of this package implement it; Parser.Markdown
+// stops parsing once it returns an error.
+func (w *baseWriter) Err() error {
+	return w.err
+}
+
+// Options controlling the HTML output.
+type HTMLOptions struct {
+	// If Permalinks is set, an anchor linking to the heading
+	// itself is inserted into each heading, like
+	//	<a class="anchor" href="#id">¶</a>
+	// Headings that have not been assigned an id yet,
+	// as it happens with the TOC extension, get one.
+	Permalinks      bool
+	PermalinkSymbol string // defaults to "¶"
+	PermalinkBefore bool   // place the anchor before the heading text
+
+	// Classes maps element kinds, like PARA, BLOCKQUOTE,
+	// or BULLETLIST, to class names that are added
+	// to the corresponding HTML elements, e.g.
+	//	Classes: map[int]string{BLOCKQUOTE: "quote"}
+	// Spoilers get the class configured for SPOILER,
+	// or "spoiler", if there is none; hashtags and mentions
+	// those for TAG and MENTION, or "hashtag" and "mention".
+	Classes map[int]string
+
+	// If ListValues is set, items of ordered lists whose number
+	// differs from the one a browser would display, e.g. because
+	// the author skipped some values, get a value attribute.
+	ListValues bool
+
+	// Paragraphs are wrapped in <p> elements, as with the original
+	// Markdown, even if they consist of raw HTML only, like an
+	// <img> tag on a line of its own. If UnwrapHTML is set,
+	// paragraphs containing nothing but raw HTML tags, comments,
+	// and white space are written as is, without <p>, unless they
+	// have a language set. A paragraph like <span>text</span> is
+	// still wrapped, as it contains text.
+	UnwrapHTML bool
+
+	// If LineNumbers is set, each line of a code block is
+	// wrapped in a span, preceded by its number:
+	//	<span class="line"><span class="ln">1</span>...</span>
+	LineNumbers bool
+
+	// MissingAlt, if not nil, is called for each image
+	// without alternative text.
+	MissingAlt func(Image)
+
+	// SVG selects how images with a .svg URL are rendered: as
+	// <img> elements (SVGImg, the default), as <object> elements,
+	// which let the SVG use links, and style sheets, or inline
+	// (SVGInline), so that the page's CSS may style the SVG, e.g.
+	// for a dark theme. Inlined documents are read from SVGFS,
+	// using the URL's path, without a leading slash; documents
+	// missing there, or larger than SVGMaxInline bytes, or
+	// DefaultSVGMaxInline if it is zero, are rendered as <img>.
+	// SVG documents may contain scripts, which run if they are
+	// rendered as <object> or inline. SVGWarning, if not nil, is
+	// called for such documents found in SVGFS, as well as for
+	// documents that cannot be inlined; the SVG is rendered
+	// anyway, unless StrictCSP is set, which removes scripts
+	// from inlined documents.
+	SVG          int
+	SVGFS        fs.FS
+	SVGMaxInline int
+	SVGWarning   func(img Image, reason string)
+
+	// MediaAttrs lists the attributes of the <video> and <audio>
+	// elements written for MEDIA elements, like "controls" or
+	// `preload="none"`. If it is nil, "controls" is used.
+	MediaAttrs []string
+
+	// LinkClass, if not nil, is called for each link;
+	// a non-empty result is added to the link's class
+	// attribute, like "dead-link".
+	LinkClass func(url string) string
+
+	// Text, if not nil, is called for the text of each STR
+	// element, i.e. after the Smart extension has replaced
+	// quotes, dashes and the like, and may return a modified
+	// version, e.g. containing soft hyphens (U+00AD) inserted
+	// by a hyphenation library. The result is escaped as usual.
+	Text func(s string) string
+
+	// Normalize, if not nil, is applied to the text of the
+	// document, including code, and titles, before Text is
+	// called. It is meant for Unicode normalization, so that
+	// text from sources mixing composed and decomposed forms of
+	// characters, as some editors produce, is output consistently:
+	//	Normalize: norm.NFC.String, // golang.org/x/text/unicode/norm
+	// If NormalizeIDs is set as well, the ids of headings, and
+	// links to fragments, like "#café", are normalized too, so
+	// that they match regardless of the forms used in the source.
+	Normalize    func(s string) string
+	NormalizeIDs bool
+
+	// If LiteralCode is set, code spans and blocks are written
+	// exactly as in the source, without applying Normalize, so
+	// that, like raw HTML, they are opaque to any transformation
+	// of the text. The Smart extension never applies to code.
+	LiteralCode bool
+
+	// Invisible selects how zero-width characters, like U+200B
+	// or U+200D, and controls of the bidirectional algorithm,
+	// like the right-to-left override U+202E, are treated in the
+	// text of the document, and in titles. These characters may
+	// be used to make user-supplied content appear different from
+	// what it is. Code, raw HTML, and URLs are not affected. The
+	// value is one of InvisibleKeep, the default, InvisibleStrip,
+	// or InvisibleShow.
+	Invisible int
+
+	// If NoTitles is set, titles of links and images are
+	// omitted. Otherwise, they are emitted as title attributes,
+	// with backslash escapes of punctuation characters resolved,
+	// character references like &amp; kept, and any other &, <, >,
+	// and " escaped.
+	NoTitles bool
+
+	// IDPrefix is prepended to all ids generated for headings
+	// and footnotes, and to the corresponding anchors in links,
+	// so that several documents can be embedded into one page.
+	IDPrefix string
+
+	// Slugify derives the ids of headings from their text, if
+	// Permalinks is set. If nil, Slugify is used. For headings
+	// listed in a table of contents, ids are determined by the
+	// parser's Extensions.Slugify instead.
+	Slugify func(string) string
+
+	// Footnotes are numbered starting at FirstNote, or at 1, if it
+	// is zero. LastNote, if not nil, receives the number of the
+	// last footnote printed when the document is finished, so that
+	// numbering can be continued on the next page of an article.
+	FirstNote int
+	LastNote  *int
+
+	// If NotePopovers is set, each reference to a footnote gets
+	// a data-note attribute containing the note rendered as HTML,
+	// which a style sheet or a small script may display when the
+	// reference is hovered, without looking up the note:
+	//	<a class="noteref" ... data-note="&lt;p&gt;The note.&lt;/p&gt;">
+	NotePopovers bool
+
+	// NoteMarker, if not nil, returns the marker of the n-th
+	// footnote, counting from FirstNote, which is displayed in
+	// place of the bracketed number "[n]" in references to the
+	// note. Label is the label of the note, like "note-label"
+	// for [^note-label], or empty for inline notes. In the list of
+	// notes, the marker is put into a data-marker attribute, which
+	// a style sheet may display instead of the number:
+	//	#notes li::marker { content: attr(data-marker) " " }
+	// NoteLabels and NoteSymbols are predefined functions.
+	NoteMarker func(n int, label string) string
+
+	// If SectionNotes is set to a heading level, like 1 or 2,
+	// the footnotes referenced within a section are printed at the
+	// end of that section, i.e. before the next top-level heading
+	// of that level or above, instead of at the end of the document.
+	// Numbering continues across sections; a note referenced in
+	// several sections is repeated in each of them.
+	SectionNotes int
+
+	// If QuoteNotes is set, footnotes referenced within block
+	// quotes, which would otherwise link to a place far out of the
+	// quoted context, are printed at the end of the innermost
+	// quote, numbered separately, as a small-print block:
+	//	<aside class="notes">
+	//	<ol>
+	//	<li>
+	//	<p>The note.</p>
+	//	</li>
+	//	</ol>
+	//	</aside>
+	// A style sheet may set the font size of aside.notes.
+	QuoteNotes bool
+
+	// If Index is set, an index of the terms marked using the
+	// Index extension is appended to the document, linking to
+	// the places where they occur.
+	Index bool
+
+	// If StrictCSP is set, the output contains no inline event
+	// handlers, style attributes, script or style elements, no
+	// srcdoc attributes of frames, and no javascript: URLs, so
+	// that it can be served under a strict Content-Security-Policy.
+	// Raw HTML is filtered accordingly; links and images with
+	// javascript: URLs are reduced to their text.
+	StrictCSP bool
+
+	// URLs, if not nil, restricts the URLs of links and images,
+	// see URLPolicy. Links and images with URLs it rejects are
+	// reduced to their text. Raw HTML is not affected. If URLs is
+	// nil, as for trusted content, any URL is permitted.
+	URLs *URLPolicy
+
+	// If Flush is not nil, it is called after each top-level block,
+	// once at least FlushBytes bytes have been written since the
+	// previous call, and at the end of the document, so that the
+	// output of a long document can be sent to a client, and be
+	// displayed, progressively. An error it returns ends the
+	// rendering. For example, in an HTTP handler:
+	//	bw := bufio.NewWriter(rw)
+	//	Flush: func() error {
+	//		err := bw.Flush()
+	//		rw.(http.Flusher).Flush()
+	//		return err
+	//	},
+	Flush      func() error
+	FlushBytes int
 }
 
 type htmlOut struct {
 	baseWriter
 	obfuscate bool
+	opt       HTMLOptions
 
-	notenum  int
-	endNotes []*element /* List of endnotes to print after main content. */
+	notenum    int
+	endNotes   []*endNote /* List of endnotes to print after main content. */
+	noteNums   map[*Node]int
+	notesDone  int     /* number of endnotes printed in previous sections */
+	noteLists  int     /* number of lists of endnotes printed */
+	inQuote    int     /* > 0 within block quotes */
+	quoteNotes []*Node /* notes referenced in the current quote, if QuoteNotes is set */
+	ids        *headingIDs
+	inTOC      bool
+	inHead     bool     /* within the header rows of a table */
+	inLink     int      /* > 0 within link labels, where tags are not linked */
+	popoverOf  *htmlOut /* within a popover, the writer of the document, see noteHTML */
+
+	indexTerms []string       /* index terms found, in order */
+	indexNums  map[*Node]int  /* numbers of index terms not yet printed */
+	indexSeen  map[*Node]bool /* note contents already numbered, see walkIndexTerms */
+}
+
+// A note to be printed after the main content.
+type endNote struct {
+	*Node
+	nrefs int // number of references to the note
 }
 
+// Returns a formatter that writes the document in HTML format.
 func ToHTML(w Writer) Formatter {
+	return ToHTMLOpt(w, nil)
+}
+
+// Like ToHTML, but allows to adjust the output using options.
+func ToHTMLOpt(w Writer, opt *HTMLOptions) Formatter {
 	f := new(htmlOut)
-	f.baseWriter = baseWriter{w, 2}
+	f.baseWriter = baseWriter{Writer: w, padded: 2}
+	if opt != nil {
+		f.opt = *opt
+	}
+	if f.opt.PermalinkSymbol == "" {
+		f.opt.PermalinkSymbol = "¶"
+	}
+	if f.opt.FirstNote == 0 {
+		f.opt.FirstNote = 1
+	}
+	f.noteNums = make(map[*Node]int)
+	f.ids = newHeadingIDs(f.opt.Slugify)
+	f.indexNums = make(map[*Node]int)
+	f.indexSeen = make(map[*Node]bool)
 	return f
 }
-func (f *htmlOut) FormatBlock(tree *element) {
-	f.elist(tree)
+func (f *htmlOut) FormatBlock(tree *Node) {
+	/* Index terms are numbered before they are printed, as the
+	 * contents of notes may be printed after later blocks.
+	 */
+	walkIndexTerms(tree, f.indexSeen, func(el *Node) {
+		f.indexTerms = append(f.indexTerms, el.contents.str)
+		f.indexNums[el] = len(f.indexTerms)
+	})
+	f.blocks(tree)
+	if f.opt.Flush != nil {
+		f.flush(f.opt.Flush, f.opt.FlushBytes)
+	}
+}
+
+/* blocks - prints a list of top-level blocks, printing
+ * footnotes before headings, if SectionNotes is set
+ */
+func (f *htmlOut) blocks(tree *Node) {
+	if f.opt.SectionNotes == 0 {
+		f.elist(tree)
+		return
+	}
+	for ; tree != nil; tree = tree.next {
+		if tree.key >= H1 && tree.key <= H6 && tree.key-H1 < f.opt.SectionNotes && len(f.endNotes) > f.notesDone {
+			f.sp()
+			f.printEndnotes()
+			f.noteNums = make(map[*Node]int)
+		}
+		f.elem(tree)
+	}
 }
 func (f *htmlOut) Finish() {
-	if len(f.endNotes) != 0 {
+	if len(f.endNotes) > f.notesDone {
 		f.sp()
 		f.printEndnotes()
 	}
+	if f.opt.LastNote != nil {
+		*f.opt.LastNote = f.opt.FirstNote - 1 + len(f.endNotes)
+	}
+	if f.opt.Index && len(f.indexTerms) != 0 {
+		f.sp()
+		f.printIndex()
+	}
 	f.WriteByte('\n')
+	if f.opt.Flush != nil {
+		f.flush(f.opt.Flush, 0)
+	}
 	f.padded = 2
+	f.notenum = 0
+	f.endNotes = nil
+	f.notesDone = 0
+	f.noteLists = 0
+	f.noteNums = make(map[*Node]int)
+	f.ids = newHeadingIDs(f.opt.Slugify)
+	f.indexNums = make(map[*Node]int)
+	f.indexSeen = make(map[*Node]bool)
+	f.indexTerms = nil
 }
 
 // pad - add a number of newlines, the value of the
@@ -140,22 +502,71 @@ func (w *htmlOut) str(s string) *htmlOut {
 	return w
 }
 
-func (w *htmlOut) children(el *element) *htmlOut {
+func (w *htmlOut) children(el *Node) *htmlOut {
 	return w.elist(el.children)
 }
-func (w *htmlOut) inline(tag string, el *element) *htmlOut {
-	return w.s(tag).children(el).s("</").s(tag[1:])
+func (w *htmlOut) inline(tag string, el *Node) *htmlOut {
+	return w.open(tag, el.key).children(el).s("</").s(tag[1:])
+}
+func (w *htmlOut) listBlock(tag string, el *Node) *htmlOut {
+	return w.sp().open(tag, el.key).elist(el.children).br().s("</").s(tag[1:])
+}
+func (w *htmlOut) listItem(tag string, el *Node) *htmlOut {
+	return w.br().open(tag, el.key).skipPadding().elist(el.children).s("</").s(tag[1:])
+}
+
+// print an opening tag like "<p>", adding the class
+// configured for the element kind, if any
+func (w *htmlOut) open(tag string, key int) *htmlOut {
+	if w.opt.Classes[key] == "" {
+		return w.s(tag)
+	}
+	return w.s(tag[:len(tag)-1]).class(key).s(">")
+}
+
+// print a class attribute for an element kind, if configured
+func (w *htmlOut) class(key int) *htmlOut {
+	if c := w.opt.Classes[key]; c != "" {
+		w.s(` class="`).str(c).s(`"`)
+	}
+	return w
+}
+
+// normalize text, if configured
+func (w *htmlOut) norm(s string) string {
+	if w.opt.Normalize == nil {
+		return s
+	}
+	return w.opt.Normalize(s)
+}
+
+// normalize code, unless LiteralCode is set
+func (w *htmlOut) codeText(s string) string {
+	if w.opt.LiteralCode {
+		return s
+	}
+	return w.norm(s)
 }
-func (w *htmlOut) listBlock(tag string, el *element) *htmlOut {
-	return w.sp().s(tag).elist(el.children).br().s("</").s(tag[1:])
+
+// normalize an id, or a fragment, if configured
+func (w *htmlOut) normID(id string) string {
+	if !w.opt.NormalizeIDs {
+		return id
+	}
+	return w.norm(id)
 }
-func (w *htmlOut) listItem(tag string, el *element) *htmlOut {
-	return w.br().s(tag).skipPadding().elist(el.children).s("</").s(tag[1:])
+
+// print a lang attribute, if a language is attached to the element
+func (w *htmlOut) lang(el *Node) *htmlOut {
+	if l := el.Lang(); l != "" {
+		w.s(` lang="`).str(l).s(`"`)
+	}
+	return w
 }
 
 /* print a list of elements
  */
-func (w *htmlOut) elist(list *element) *htmlOut {
+func (w *htmlOut) elist(list *Node) *htmlOut {
 	for list != nil {
 		w.elem(list)
 		list = list.next
@@ -164,7 +575,7 @@ func (w *htmlOut) elist(list *element) *htmlOut {
 }
 
 // print an element
-func (w *htmlOut) elem(elt *element) *htmlOut {
+func (w *htmlOut) elem(elt *Node) *htmlOut {
 	var s string
 
 	switch elt.key {
@@ -173,7 +584,11 @@ func (w *htmlOut) elem(elt *element) *htmlOut {
 	case LINEBREAK:
 		s = "<br/>\n"
 	case STR:
-		w.str(elt.contents.str)
+		text := w.norm(elt.contents.str)
+		if w.opt.Text != nil {
+			text = w.opt.Text(text)
+		}
+		w.str(applyInvisible(text, w.opt.Invisible))
 	case ELLIPSIS:
 		s = "&hellip;"
 	case EMDASH:
@@ -187,55 +602,129 @@ func (w *htmlOut) elem(elt *element) *htmlOut {
 	case DOUBLEQUOTED:
 		w.s("&ldquo;").children(elt).s("&rdquo;")
 	case CODE:
-		w.s("<code>").str(elt.contents.str).s("</code>")
-	case HTML:
-		s = elt.contents.str
+		w.s("<code>").str(w.codeText(elt.contents.str)).s("</code>")
+	case HTML, EMBED:
+		s = w.rawHTML(elt.contents.str)
 	case LINK:
+		url := elt.contents.link.url
+		if w.opt.StrictCSP && isJavascriptURL(url) || w.opt.URLs != nil && !w.opt.URLs.allows(url, false) {
+			w.elist(elt.contents.link.label)
+			break
+		}
 		o := w.obfuscate
-		if strings.Index(elt.contents.link.url, "mailto:") == 0 {
+		if strings.Index(url, "mailto:") == 0 {
 			w.obfuscate = true /* obfuscate mailto: links */
 		}
-		w.s(`<a href="`).str(elt.contents.link.url).s(`"`)
-		if len(elt.contents.link.title) > 0 {
-			w.s(` title="`).str(elt.contents.link.title).s(`"`)
+		if strings.HasPrefix(url, "#") {
+			url = "#" + w.normID(url[1:])
+			if w.inTOC {
+				url = "#" + w.opt.IDPrefix + url[1:]
+			}
 		}
-		w.s(">").elist(elt.contents.link.label).s("</a>")
+		w.s(`<a href="`).str(url).s(`"`)
+		w.title(elt.contents.link.title)
+		w.linkClass(elt.contents.link.url)
+		w.s(">")
+		w.inLink++
+		w.elist(elt.contents.link.label)
+		w.inLink--
+		w.s("</a>")
 		w.obfuscate = o
 	case IMAGE:
+		if w.opt.MissingAlt != nil && strings.TrimSpace(inlineText(elt.contents.link.label)) == "" {
+			w.opt.MissingAlt(newImage(elt))
+		}
+		if url := elt.contents.link.url; w.opt.StrictCSP && isJavascriptURL(url) || w.opt.URLs != nil && !w.opt.URLs.allows(url, true) {
+			w.elist(elt.contents.link.label)
+			break
+		}
+		if w.opt.SVG != SVGImg && isSVGURL(elt.contents.link.url) && w.svg(elt) {
+			break
+		}
 		w.s(`<img src="`).str(elt.contents.link.url).s(`" alt="`)
 		w.elist(elt.contents.link.label).s(`"`)
-		if len(elt.contents.link.title) > 0 {
-			w.s(` title="`).str(elt.contents.link.title).s(`"`)
-		}
+		w.title(elt.contents.link.title)
 		w.s(" />")
+	case MEDIA:
+		if url := elt.contents.link.url; w.opt.StrictCSP && isJavascriptURL(url) || w.opt.URLs != nil && !w.opt.URLs.allows(url, false) {
+			w.elist(elt.contents.link.label)
+			break
+		}
+		w.media(elt)
 	case EMPH:
 		w.inline("<em>", elt)
 	case STRONG:
 		w.inline("<strong>", elt)
 	case STRIKE:
 		w.inline("<del>", elt)
+	case SPOILER:
+		c := w.opt.Classes[SPOILER]
+		if c == "" {
+			c = "spoiler"
+		}
+		w.s(`<span class="`).str(c).s(`">`).children(elt).s("</span>")
+	case TAG, MENTION:
+		c := w.opt.Classes[elt.key]
+		if c == "" {
+			c = "hashtag"
+			if elt.key == MENTION {
+				c = "mention"
+			}
+		}
+		if url := elt.URL(); url != "" && w.inLink == 0 {
+			w.s(`<a class="`).str(c).s(`" href="`).str(url).s(`">`).str(tagText(elt)).s("</a>")
+		} else {
+			w.s(`<span class="`).str(c).s(`">`).str(tagText(elt)).s("</span>")
+		}
 	case LIST:
 		w.children(elt)
 	case RAW:
 		/* Shouldn't occur - these are handled by process_raw_blocks() */
 		log.Fatalf("RAW")
 	case H1, H2, H3, H4, H5, H6:
-		h := "<h" + string('1'+elt.key-H1) + ">" /* assumes H1 ... H6 are in order */
-		w.sp().inline(h, elt)
+		h := "h" + strconv.Itoa(1+elt.key-H1) /* assumes H1 ... H6 are in order */
+		id := w.normID(elt.contents.str)
+		permalinks := w.opt.Permalinks && w.popoverOf == nil
+		if id == "" && permalinks {
+			id = w.ids.make(w.normID(inlineText(elt.children)))
+		}
+		w.sp().s("<").s(h)
+		if id != "" && w.popoverOf == nil {
+			w.s(` id="`).str(w.opt.IDPrefix + id).s(`"`)
+		}
+		w.class(elt.key).lang(elt).s(">")
+		if permalinks && w.opt.PermalinkBefore {
+			w.permalink(id).s(" ")
+		}
+		w.children(elt)
+		if permalinks && !w.opt.PermalinkBefore {
+			w.s(" ").permalink(id)
+		}
+		w.s("</").s(h).s(">")
 	case PLAIN:
 		w.br().children(elt)
 	case PARA:
-		w.sp().inline("<p>", elt)
+		if w.opt.UnwrapHTML && elt.Lang() == "" && htmlOnly(elt.children) {
+			w.sp().children(elt)
+			break
+		}
+		w.sp().s("<p").class(elt.key).lang(elt).s(">").children(elt).s("</p>")
 	case HRULE:
-		w.sp().s("<hr />")
+		w.sp().s("<hr").class(elt.key).s(" />")
 	case HTMLBLOCK:
-		w.sp().s(elt.contents.str)
+		w.sp().s(w.rawHTML(elt.contents.str))
 	case VERBATIM:
-		w.sp().s("<pre><code>").str(elt.contents.str).s("</code></pre>")
+		w.sp().open("<pre>", elt.key).s("<code>")
+		if w.opt.LineNumbers {
+			w.codeLines(w.codeText(elt.contents.str))
+		} else {
+			w.str(w.codeText(elt.contents.str))
+		}
+		w.s("</code></pre>")
 	case BULLETLIST:
 		w.listBlock("<ul>", elt)
 	case ORDEREDLIST:
-		w.listBlock("<ol>", elt)
+		w.orderedList(elt)
 	case DEFINITIONLIST:
 		w.listBlock("<dl>", elt)
 	case DEFTITLE:
@@ -245,19 +734,129 @@ func (w *htmlOut) elem(elt *element) *htmlOut {
 	case LISTITEM:
 		w.listItem("<li>", elt)
 	case BLOCKQUOTE:
-		w.sp().s("<blockquote>\n").skipPadding().children(elt).br().s("</blockquote>")
+		var cite *Node
+		for c := elt.children; c != nil; c = c.next {
+			if c.key == CITATIONLINE {
+				cite = c
+			}
+		}
+		if cite != nil {
+			w.sp().s("<figure>\n").skipPadding()
+		}
+		notes := w.quoteNotes
+		w.quoteNotes = nil
+		w.inQuote++
+		w.sp().open("<blockquote>", elt.key).s("\n").skipPadding().children(elt)
+		w.inQuote--
+		if len(w.quoteNotes) != 0 {
+			w.printQuoteNotes()
+		}
+		w.br().s("</blockquote>")
+		w.quoteNotes = notes
+		if cite != nil {
+			w.br().s("<figcaption><cite>").children(cite).s("</cite></figcaption>").br().s("</figure>")
+		}
+	case CONTAINER:
+		class := elt.contents.str
+		var attrs []Attr
+		for _, a := range elt.Attrs() {
+			switch {
+			case a.Key == "class":
+				class += " " + a.Value
+			case strings.HasPrefix(strings.ToLower(a.Key), "on"):
+				/* no event handlers */
+			default:
+				attrs = append(attrs, a)
+			}
+		}
+		w.sp().s(`<div class="`).str(class).s(`"`)
+		for _, a := range attrs {
+			w.s(" ").str(a.Key).s(`="`).str(a.Value).s(`"`)
+		}
+		w.s(">\n").skipPadding().children(elt).br().s("</div>")
+	case TABLE:
+		w.sp().open("<table>", elt.key)
+		rows := elt.children
+		if rows != nil && rows.key == TABLEHEAD {
+			w.inHead = true
+			w.br().open("<thead>", rows.key).elist(rows.children).br().s("</thead>")
+			w.inHead = false
+			rows = rows.next
+		}
+		if rows != nil {
+			w.br().s("<tbody>").elist(rows).br().s("</tbody>")
+		}
+		w.br().s("</table>")
+	case TABLEHEAD:
+		/* printed as part of the table, see above */
+	case TABLEROW:
+		w.br().open("<tr>", elt.key).elist(elt.children).br().s("</tr>")
+	case TABLECELL:
+		tag := "<td>"
+		if w.inHead {
+			tag = "<th>"
+		}
+		if elt.children == nil {
+			w.br().open(tag, elt.key).s("</").s(tag[1:])
+		} else {
+			w.listItem(tag, elt)
+		}
+	case CITATIONLINE:
+		/* printed after the blockquote, see above */
+	case COMMENT, SCRIPT:
+		/* not printed */
+	case TOC:
+		w.sp().s(`<div class="toc`)
+		if c := w.opt.Classes[TOC]; c != "" {
+			w.s(" ").str(c)
+		}
+		w.s("\">\n").skipPadding()
+		w.inTOC = true
+		w.children(elt)
+		w.inTOC = false
+		w.br().s("</div>")
 	case REFERENCE:
 		/* Nonprinting */
+	case INDEXTERM:
+		/* A term is anchored once, and not within the table
+		 * of contents, where the anchor would end up in a link.
+		 */
+		if n, ok := w.indexNums[elt]; ok && !w.inTOC {
+			w.s(`<a id="`).str(w.opt.IDPrefix + indexTermID(n)).s(`"></a>`)
+			delete(w.indexNums, elt)
+		}
 	case NOTE:
 		/* if contents.str == 0, then print note; else ignore, since this
 		 * is a note block that has been incorporated into the notes list
 		 */
-		if elt.contents.str == "" {
-			w.endNotes = append(w.endNotes, elt) /* add an endnote to global endnotes list */
-			w.notenum++
-			nn := w.notenum
-			s = fmt.Sprintf(`<a class="noteref" id="fnref%d" href="#fn%d" title="Jump to note %d">[%d]</a>`,
-				nn, nn, nn, nn)
+		if elt.contents.str == "" && w.opt.QuoteNotes && w.inQuote > 0 {
+			w.quoteNotes = append(w.quoteNotes, elt)
+			s = fmt.Sprintf(`<sup class="noteref">%d</sup>`, len(w.quoteNotes))
+		} else if elt.contents.str == "" {
+			/* References to the same note share the note's children;
+			 * a note referenced more than once is printed only once.
+			 */
+			if w.popoverOf != nil {
+				/* A note referenced within a popover gets the number
+				 * it has in the document; the reference has no id,
+				 * which would duplicate that of the reference in
+				 * the list of endnotes.
+				 */
+				nn := w.popoverOf.noteNum(elt) + w.opt.FirstNote - 1
+				s = fmt.Sprintf(`<a class="noteref" href="#%sfn%d" title="Jump to note %d">%s</a>`,
+					w.opt.IDPrefix, nn, nn, w.noteMarker(nn, elt))
+				break
+			}
+			nn := w.noteNum(elt)
+			note := w.endNotes[nn-1]
+			note.nrefs++
+			nn += w.opt.FirstNote - 1
+			popover := ""
+			if w.opt.NotePopovers {
+				popover = ` data-note="` + htmlEscaper.Replace(w.noteHTML(elt)) + `"`
+			}
+			s = fmt.Sprintf(`<a class="noteref" id="%s" href="#%sfn%d" title="Jump to note %d"%s>%s</a>`,
+				w.noteRefID(nn, note.nrefs), w.opt.IDPrefix, nn, nn, popover, w.noteMarker(nn, elt))
 		}
 	default:
 		log.Fatalf("htmlOut.elem encountered unknown element key = %d\n", elt.key)
@@ -268,6 +867,219 @@ func (w *htmlOut) elem(elt *element) *htmlOut {
 	return w
 }
 
+// print the class attribute of a link, combining the class
+// configured for LINK elements with the result of LinkClass
+func (w *htmlOut) linkClass(url string) *htmlOut {
+	c := w.opt.Classes[LINK]
+	if w.opt.LinkClass != nil {
+		if lc := w.opt.LinkClass(url); lc != "" {
+			if c != "" {
+				c += " "
+			}
+			c += lc
+		}
+	}
+	if c != "" {
+		w.s(` class="`).str(c).s(`"`)
+	}
+	return w
+}
+
+// print the lines of a code block, each with its line number
+func (w *htmlOut) codeLines(code string) *htmlOut {
+	lines := strings.SplitAfter(code, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		nl := strings.HasSuffix(line, "\n")
+		w.s(`<span class="line"><span class="ln">`).s(strconv.Itoa(i + 1)).s("</span>")
+		w.str(strings.TrimSuffix(line, "\n")).s("</span>")
+		if nl {
+			w.s("\n")
+		}
+	}
+	return w
+}
+
+// print the title attribute of a link or image
+func (w *htmlOut) title(t string) *htmlOut {
+	if t == "" || w.opt.NoTitles {
+		return w
+	}
+	t = applyInvisible(w.norm(t), w.opt.Invisible)
+	w.s(` title="`)
+	for t != "" {
+		i := strings.IndexAny(t, "\\&")
+		if i == -1 {
+			break
+		}
+		w.str(t[:i])
+		t = t[i:]
+		if t[0] == '\\' {
+			if len(t) > 1 && isASCIIPunct(t[1]) {
+				w.str(t[1:2])
+				t = t[2:]
+				continue
+			}
+		} else if n := entityLen(t); n > 0 {
+			w.s(t[:n])
+			t = t[n:]
+			continue
+		}
+		w.str(t[:1])
+		t = t[1:]
+	}
+	return w.str(t).s(`"`)
+}
+
+// raw HTML, filtered if StrictCSP is set
+func (w *htmlOut) rawHTML(s string) string {
+	if w.opt.StrictCSP {
+		s = filterCSP(s)
+	}
+	return s
+}
+
+/* htmlOnly - returns true if a list of inline elements contains
+ * raw HTML, and nothing else but white space
+ */
+func htmlOnly(list *Node) bool {
+	found := false
+	for ; list != nil; list = list.next {
+		switch list.key {
+		case HTML:
+			found = true
+		case SPACE, LINEBREAK:
+		case LIST:
+			if list.children == nil {
+				break
+			}
+			if !htmlOnly(list.children) {
+				return false
+			}
+			found = true
+		default:
+			return false
+		}
+	}
+	return found
+}
+
+// print an ordered list
+func (w *htmlOut) orderedList(elt *Node) *htmlOut {
+	if elt.contents.str == "" && !w.opt.ListValues {
+		return w.listBlock("<ol>", elt)
+	}
+	next := 1
+	w.sp().s("<ol").class(elt.key)
+	if elt.contents.str != "" {
+		/* a fancy list; type and start are derived from the first marker */
+		typ, start := parseEnumerator(elt.contents.str)
+		if typ != '1' {
+			w.s(` type="`).s(string(typ)).s(`"`)
+		}
+		if start != 1 {
+			w.s(` start="`).s(strconv.Itoa(start)).s(`"`)
+		}
+		next = start
+	}
+	w.s(">")
+	for item := elt.children; item != nil; item = item.next {
+		if item.key != LISTITEM || !w.opt.ListValues {
+			w.elem(item)
+			continue
+		}
+		_, n := parseEnumerator(item.contents.str)
+		if n == next {
+			w.elem(item)
+		} else {
+			w.br().s("<li").class(item.key).s(` value="`).s(strconv.Itoa(n)).s(`">`)
+			w.skipPadding().children(item).s("</li>")
+		}
+		next = n + 1
+	}
+	return w.br().s("</ol>")
+}
+
+// print an anchor linking to the element with the specified id
+func (w *htmlOut) permalink(id string) *htmlOut {
+	return w.s(`<a class="anchor" href="#`).str(w.opt.IDPrefix + id).s(`">`).s(w.opt.PermalinkSymbol).s("</a>")
+}
+
+// noteNum returns the number of a referenced note, counting from
+// one, adding the note to the list of endnotes, if it is new
+func (w *htmlOut) noteNum(note *Node) int {
+	nn, ok := w.noteNums[note.children]
+	if !ok || note.children == nil {
+		w.endNotes = append(w.endNotes, &endNote{Node: note}) /* add an endnote to global endnotes list */
+		w.notenum++
+		nn = w.notenum
+		w.noteNums[note.children] = nn
+	}
+	return nn
+}
+
+// noteMarker returns the marker displayed by a reference to the
+// note numbered nn
+func (w *htmlOut) noteMarker(nn int, note *Node) string {
+	if w.opt.NoteMarker != nil {
+		return htmlEscaper.Replace(w.opt.NoteMarker(nn, noteLabel(note)))
+	}
+	return fmt.Sprintf("[%d]", nn)
+}
+
+// noteHTML returns the contents of a note rendered as HTML. Notes
+// referenced within are numbered by w, and ids are omitted, so that
+// they do not duplicate those of the document.
+func (w *htmlOut) noteHTML(note *Node) string {
+	var b strings.Builder
+	n := &htmlOut{baseWriter: baseWriter{Writer: &b, padded: 2}, opt: w.opt, popoverOf: w}
+	n.opt.NotePopovers = false
+	n.ids = newHeadingIDs(w.opt.Slugify)
+	n.children(note)
+	return strings.TrimSpace(b.String())
+}
+
+// noteLabel returns the label of a reference to a note, which
+// the parser keeps as the label of its link information,
+// or an empty string for inline notes
+func noteLabel(note *Node) string {
+	if note.contents.link == nil || note.contents.link.label == nil {
+		return ""
+	}
+	return note.contents.link.label.contents.str
+}
+
+// NoteLabels, used as HTMLOptions.NoteMarker, displays notes
+// by their labels, like "[note-label]" for [^note-label].
+// Inline notes, which have no label, keep their numbers.
+func NoteLabels(n int, label string) string {
+	if label == "" {
+		label = strconv.Itoa(n)
+	}
+	return "[" + label + "]"
+}
+
+var noteSymbols = []string{"*", "†", "‡", "§", "‖", "¶"}
+
+// NoteSymbols, used as HTMLOptions.NoteMarker, displays notes
+// by the traditional sequence of symbols *, †, ‡, §, ‖, ¶,
+// which is repeated with doubled, tripled, ... symbols for
+// the following notes: **, ††, and so on.
+func NoteSymbols(n int, label string) string {
+	i := (n - 1) % len(noteSymbols)
+	return strings.Repeat(noteSymbols[i], 1+(n-1)/len(noteSymbols))
+}
+
+// noteRefID returns the id of the i-th reference to note nn
+func (w *htmlOut) noteRefID(nn, i int) string {
+	if i == 1 {
+		return fmt.Sprintf("%sfnref%d", w.opt.IDPrefix, nn)
+	}
+	return fmt.Sprintf("%sfnref%d-%d", w.opt.IDPrefix, nn, i)
+}
+
 func (w *htmlOut) printEndnotes() {
 	extraNewline := func() {
 		// add an extra newline to maintain
@@ -275,17 +1087,91 @@ func (w *htmlOut) printEndnotes() {
 		w.padded--
 	}
 
-	counter := 0
+	counter := w.opt.FirstNote - 1 + w.notesDone
 
-	w.s("<hr/>\n<ol id=\"notes\">")
-	for _, elt := range w.endNotes {
+	w.s("<hr/>\n<ol id=\"").str(w.opt.IDPrefix).s("notes")
+	if w.noteLists != 0 {
+		w.s(strconv.Itoa(w.noteLists + 1))
+	}
+	w.s("\"")
+	if counter != 0 {
+		w.s(fmt.Sprintf(" start=\"%d\"", counter+1))
+	}
+	w.s(">")
+	/* notes referenced within notes are appended while printing */
+	for i := w.notesDone; i < len(w.endNotes); i++ {
+		note := w.endNotes[i]
 		counter++
 		extraNewline()
-		w.br().s(fmt.Sprintf("<li id=\"fn%d\">\n", counter)).skipPadding()
-		w.children(elt)
-		w.s(fmt.Sprintf(" <a href=\"#fnref%d\" title=\"Jump back to reference\">[back]</a>", counter))
+		w.br().s(fmt.Sprintf("<li id=\"%sfn%d\"", w.opt.IDPrefix, counter))
+		if w.opt.NoteMarker != nil {
+			w.s(` data-marker="`).str(w.opt.NoteMarker(counter, noteLabel(note.Node))).s(`"`)
+		}
+		w.s(">\n").skipPadding()
+		w.children(note.Node)
+		if note.nrefs == 1 {
+			w.s(fmt.Sprintf(" <a href=\"#%s\" title=\"Jump back to reference\">[back]</a>", w.noteRefID(counter, 1)))
+		} else {
+			for i := 1; i <= note.nrefs; i++ {
+				w.s(fmt.Sprintf(" <a href=\"#%s\" title=\"Jump back to reference %d\">[back<sup>%d</sup>]</a>",
+					w.noteRefID(counter, i), i, i))
+			}
+		}
 		w.br().s("</li>")
 	}
 	extraNewline()
 	w.br().s("</ol>")
+	w.notesDone = len(w.endNotes)
+	w.noteLists++
+}
+
+/* printQuoteNotes - prints the notes referenced within
+ * a block quote, if QuoteNotes is set
+ */
+func (w *htmlOut) printQuoteNotes() {
+	w.sp().s(`<aside class="notes">`).br().s("<ol>")
+	for _, note := range w.quoteNotes {
+		if isInlineNote(note) {
+			w.br().s("<li>").children(note).s("</li>")
+			continue
+		}
+		w.br().s("<li>\n").skipPadding()
+		w.children(note)
+		w.br().s("</li>")
+	}
+	w.br().s("</ol>").br().s("</aside>")
+}
+
+/* printIndex - prints the index terms, sorted, with
+ * links to their occurrences
+ */
+func (w *htmlOut) printIndex() {
+	occurrences := make(map[string][]int)
+	var terms []string
+	for i, t := range w.indexTerms {
+		if occurrences[t] == nil {
+			terms = append(terms, t)
+		}
+		occurrences[t] = append(occurrences[t], i+1)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		a, b := strings.ToLower(terms[i]), strings.ToLower(terms[j])
+		if a == b {
+			return terms[i] < terms[j]
+		}
+		return a < b
+	})
+
+	w.s(`<div class="index">`).br().s("<ul>")
+	for _, t := range terms {
+		w.br().s("<li>").str(t)
+		for i, n := range occurrences[t] {
+			if i > 0 {
+				w.s(",")
+			}
+			w.s(` <a href="#`).str(w.opt.IDPrefix + indexTermID(n)).s(`">`).s(strconv.Itoa(i + 1)).s("</a>")
+		}
+		w.s("</li>")
+	}
+	w.br().s("</ul>").br().s("</div>")
 }
@@ -0,0 +1,127 @@
+package markdown
+
+// Parallel parsing of large documents.
+
+import (
+	"strings"
+	"sync"
+)
+
+// Documents smaller than this are always parsed sequentially.
+const minChunkSize = 16 << 10
+
+// SetParallel sets the maximum number of goroutines parsing the
+// blocks of a large document concurrently, after its references and
+// notes have been collected. The document is split only at level
+// headings like "# Title" preceded by a blank line, where a new
+// block starts in any case; as raw HTML blocks may contain blank
+// lines and headings, a document is not split behind the first
+// one. The result is the same as with sequential parsing, which is
+// used if n is less than 2, the default.
+func (p *Parser) SetParallel(n int) {
+	p.parallel = n
+}
+
+// A chunk of a document, starting at the beginning of a block.
+type chunk struct {
+	text string
+	line int /* number of the chunk's first line */
+}
+
+/* splitChunks - splits s into at most p.parallel chunks of similar
+ * size, at ATX headings following a blank line. It returns nil,
+ * if the document is not split.
+ */
+func (p *Parser) splitChunks(s string) (chunks []chunk) {
+	if p.parallel < 2 || len(s) < minChunkSize {
+		return nil
+	}
+	size := len(s) / p.parallel
+	start, startLine := 0, 1
+	line := 1
+	blank := true /* the previous line was blank */
+	fences := 0   /* depth of open ::: containers */
+	html := false /* an HTML block might have been opened */
+	for pos := 0; pos < len(s); line++ {
+		n := strings.IndexByte(s[pos:], '\n') + 1
+		if n == 0 {
+			n = len(s) - pos
+		}
+		l := s[pos : pos+n]
+		if blank && l[0] == '#' && fences == 0 && !html && pos-start >= size && pos > start {
+			chunks = append(chunks, chunk{s[start:pos], startLine})
+			start, startLine = pos, line
+		}
+		t := strings.TrimLeft(l, " ")
+		if blank && len(l)-len(t) < 4 && strings.HasPrefix(t, "<") {
+			html = true
+		}
+		if p.yy.extension.Containers && len(l)-len(t) < 4 && strings.HasPrefix(t, ":::") {
+			if strings.TrimSpace(strings.TrimLeft(t, ":")) == "" {
+				fences--
+			} else {
+				fences++
+			}
+			if fences < 0 {
+				fences = 0
+			}
+		}
+		blank = strings.TrimSpace(l) == ""
+		pos += n
+	}
+	if chunks == nil {
+		return nil
+	}
+	return append(chunks, chunk{s[start:], startLine})
+}
+
+/* parseChunks - parses the blocks of each chunk using a separate
+ * parser, sharing the references and notes of p, and returns the
+ * blocks of all chunks in order. Diagnostics are collected in p.
+ */
+func (p *Parser) parseChunks(chunks []chunk) []*Node {
+	for len(p.sub) < len(chunks) {
+		x := p.yy.extension
+		x.AutoRefs = nil /* applied afterwards, as notes are shared */
+		p.sub = append(p.sub, NewParser(&x))
+		p.stats.Inits++
+	}
+	blocks := make([][]*Node, len(chunks))
+	var wg sync.WaitGroup
+	for i := range chunks {
+		sub := p.sub[i]
+		sub.yy.references = p.yy.references
+		sub.yy.notes = p.yy.notes
+		sub.yy.refIndex = p.yy.refIndex
+		sub.yy.noteIndex = p.yy.noteIndex
+		sub.yy.diags = nil
+		sub.stats = ParseStats{}
+		sub.stop = p.stop
+		sub.yy.state.heap = elemHeap{} /* the blocks of a previous document may still be in use */
+		sub.yy.state.heap.init(1024)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sub.parseBlocks(chunks[i].text, chunks[i].line, func(tree *Node) {
+				blocks[i] = append(blocks[i], tree)
+			}, true)
+		}(i)
+	}
+	wg.Wait()
+
+	var all []*Node
+	for i, b := range blocks {
+		p.yy.diags = append(p.yy.diags, p.sub[i].yy.diags...)
+		p.stats.Blocks += p.sub[i].stats.Blocks
+		p.stats.RawParses += p.sub[i].stats.RawParses
+		all = append(all, b...)
+	}
+	if p.autoRefsEnabled() {
+		for _, tree := range all {
+			p.linkAutoRefs(tree)
+		}
+		p.yy.state.heap.hasGlobals = true
+		p.yy.state.heap.Reset()
+	}
+	return all
+}
@@ -0,0 +1,3252 @@
+/* Rules of the grammar in parser.leg matching block elements.
+ * Generated by leg, and split off parser.leg.go by gen.go.
+ */
+
+package markdown
+
+func (p *yyParser) blockRules() {
+	/* 0 Doc <- (StartList (Block { a = cons(yy, a) })* { p.tree = reverse(a) } commit) */
+	p.rules[ruleDoc] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleBlock]() {
+				goto out
+			}
+			p.do(0)
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.do(1)
+		if !(p.commit(thunkPosition0)) {
+			goto ko
+		}
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 1 Docblock <- (Block { p.tree = yy } commit) */
+	p.rules[ruleDocblock] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !p.rules[ruleBlock]() {
+			goto ko
+		}
+		p.do(2)
+		if !(p.commit(thunkPosition0)) {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 2 Block <- (BlankLine* (BlockQuote / Container / Verbatim / Note / Reference / GridTable / HorizontalRule / Heading / DefinitionList / OrderedList / BulletList / HtmlBlock / StyleBlock / Comment / Para / Plain)) */
+	p.rules[ruleBlock] = func() (match bool) {
+		position0 := p.position
+	loop:
+		if !p.rules[ruleBlankLine]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.rules[ruleBlockQuote]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleContainer]() {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+		if !p.rules[ruleVerbatim]() {
+			goto nextAlt5
+		}
+		goto ok
+	nextAlt5:
+		if !p.rules[ruleNote]() {
+			goto nextAlt6
+		}
+		goto ok
+	nextAlt6:
+		if !p.rules[ruleReference]() {
+			goto nextAlt16
+		}
+		goto ok
+	nextAlt16:
+		if !p.rules[ruleGridTable]() {
+			goto nextAlt7
+		}
+		goto ok
+	nextAlt7:
+		if !p.rules[ruleHorizontalRule]() {
+			goto nextAlt8
+		}
+		goto ok
+	nextAlt8:
+		if !p.rules[ruleHeading]() {
+			goto nextAlt9
+		}
+		goto ok
+	nextAlt9:
+		if !p.rules[ruleDefinitionList]() {
+			goto nextAlt10
+		}
+		goto ok
+	nextAlt10:
+		if !p.rules[ruleOrderedList]() {
+			goto nextAlt11
+		}
+		goto ok
+	nextAlt11:
+		if !p.rules[ruleBulletList]() {
+			goto nextAlt12
+		}
+		goto ok
+	nextAlt12:
+		if !p.rules[ruleHtmlBlock]() {
+			goto nextAlt13
+		}
+		goto ok
+	nextAlt13:
+		if !p.rules[ruleStyleBlock]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleComment]() {
+			goto nextAlt14
+		}
+		goto ok
+	nextAlt14:
+		if !p.rules[rulePara]() {
+			goto nextAlt15
+		}
+		goto ok
+	nextAlt15:
+		if !p.rules[rulePlain]() {
+			goto ko
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 3 Para <- (NonindentSpace Inlines BlankLine+ { yy = a; yy.key = PARA }) */
+	p.rules[rulePara] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		if !p.rules[ruleInlines]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleBlankLine]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleBlankLine]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.do(3)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 4 Plain <- (Inlines { yy = a; yy.key = PLAIN }) */
+	p.rules[rulePlain] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleInlines]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		p.do(4)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 5 AtxInline <- (!Newline !(Sp '#'* Sp Newline) Inline) */
+	p.rules[ruleAtxInline] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNewline]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		{
+			position1 := p.position
+			if !p.rules[ruleSp]() {
+				goto ok2
+			}
+		loop:
+			if !p.matchChar('#') {
+				goto out
+			}
+			goto loop
+		out:
+			if !p.rules[ruleSp]() {
+				goto ok2
+			}
+			if !p.rules[ruleNewline]() {
+				goto ok2
+			}
+			goto ko
+		ok2:
+			p.position = position1
+		}
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 7 AtxHeading <- (AtxStart Sp StartList (AtxInline { a = cons(yy, a) })+ (Sp '#'* Sp)? Newline { yy = p.mkList(s.key, a)
+	   s = nil }) */
+	p.rules[ruleAtxHeading] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleAtxStart]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleAtxInline]() {
+			goto ko
+		}
+		p.do(6)
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleAtxInline]() {
+				goto out
+			}
+			p.do(6)
+			goto loop
+		out:
+			p.position = position1
+		}
+		{
+			position2 := p.position
+			if !p.rules[ruleSp]() {
+				goto ko3
+			}
+		loop5:
+			if !p.matchChar('#') {
+				goto out6
+			}
+			goto loop5
+		out6:
+			if !p.rules[ruleSp]() {
+				goto ko3
+			}
+			goto ok
+		ko3:
+			p.position = position2
+		}
+	ok:
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		p.do(7)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 8 SetextHeading <- (SetextHeading1 / SetextHeading2) */
+	p.rules[ruleSetextHeading] = func() (match bool) {
+		if !p.rules[ruleSetextHeading1]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleSetextHeading2]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 9 SetextBottom1 <- ('='+ Newline) */
+	p.rules[ruleSetextBottom1] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('=') {
+			goto ko
+		}
+	loop:
+		if !p.matchChar('=') {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 10 SetextBottom2 <- ('-'+ Newline) */
+	p.rules[ruleSetextBottom2] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('-') {
+			goto ko
+		}
+	loop:
+		if !p.matchChar('-') {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 11 SetextHeading1 <- (&(RawLine SetextBottom1) StartList (!Endline Inline { a = cons(yy, a) })+ Sp Newline SetextBottom1 { yy = p.mkList(H1, a) }) */
+	p.rules[ruleSetextHeading1] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		{
+			position1 := p.position
+			if !p.rules[ruleRawLine]() {
+				goto ko
+			}
+			if !p.rules[ruleSetextBottom1]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleEndline]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.do(8)
+	loop:
+		{
+			position2 := p.position
+			if !p.rules[ruleEndline]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.do(8)
+			goto loop
+		out:
+			p.position = position2
+		}
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		if !p.rules[ruleSetextBottom1]() {
+			goto ko
+		}
+		p.do(9)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 12 SetextHeading2 <- (&(RawLine SetextBottom2) StartList (!Endline Inline { a = cons(yy, a) })+ Sp Newline SetextBottom2 { yy = p.mkList(H2, a) }) */
+	p.rules[ruleSetextHeading2] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		{
+			position1 := p.position
+			if !p.rules[ruleRawLine]() {
+				goto ko
+			}
+			if !p.rules[ruleSetextBottom2]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleEndline]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.do(10)
+	loop:
+		{
+			position2 := p.position
+			if !p.rules[ruleEndline]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.do(10)
+			goto loop
+		out:
+			p.position = position2
+		}
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		if !p.rules[ruleSetextBottom2]() {
+			goto ko
+		}
+		p.do(11)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 13 Heading <- (SetextHeading / AtxHeading) */
+	p.rules[ruleHeading] = func() (match bool) {
+		if !p.rules[ruleSetextHeading]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleAtxHeading]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 14 BlockQuote <- (BlockQuoteRaw {  yy = p.mkElem(BLOCKQUOTE)
+	   yy.children = a
+	}) */
+	p.rules[ruleBlockQuote] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleBlockQuoteRaw]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		p.do(12)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 15 BlockQuoteRaw <- (StartList ('>' ' '? Line { a = cons(yy, a) } (!'>' !BlankLine Line { a = cons(yy, a) })* (BlankLine { a = cons(p.mkString("\n"), a) })*)+ {   yy = p.mkStringFromList(a, true)
+	    yy.key = RAW
+	}) */
+	p.rules[ruleBlockQuoteRaw] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchChar('>') {
+			goto ko
+		}
+		p.matchChar(' ')
+		if !p.rules[ruleLine]() {
+			goto ko
+		}
+		p.do(13)
+	loop3:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if p.peekChar('>') {
+				goto out4
+			}
+			if !p.rules[ruleBlankLine]() {
+				goto ok
+			}
+			goto out4
+		ok:
+			if !p.rules[ruleLine]() {
+				goto out4
+			}
+			p.do(14)
+			goto loop3
+		out4:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+	loop6:
+		{
+			position2 := p.position
+			if !p.rules[ruleBlankLine]() {
+				goto out7
+			}
+			p.do(15)
+			goto loop6
+		out7:
+			p.position = position2
+		}
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.matchChar('>') {
+				goto out
+			}
+			p.matchChar(' ')
+			if !p.rules[ruleLine]() {
+				goto out
+			}
+			p.do(13)
+		loop8:
+			{
+				position4, thunkPosition4 := p.position, p.thunkPosition
+				if p.peekChar('>') {
+					goto out9
+				}
+				if !p.rules[ruleBlankLine]() {
+					goto ok10
+				}
+				goto out9
+			ok10:
+				if !p.rules[ruleLine]() {
+					goto out9
+				}
+				p.do(14)
+				goto loop8
+			out9:
+				p.position, p.thunkPosition = position4, thunkPosition4
+			}
+		loop11:
+			{
+				position5 := p.position
+				if !p.rules[ruleBlankLine]() {
+					goto out12
+				}
+				p.do(15)
+				goto loop11
+			out12:
+				p.position = position5
+			}
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(16)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 16 NonblankIndentedLine <- (!BlankLine IndentedLine) */
+	p.rules[ruleNonblankIndentedLine] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleBlankLine]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleIndentedLine]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 17 VerbatimChunk <- (StartList (< BlankLine > { a = cons(p.mkString(p.verbatimBlankLine(yytext)), a) })* (NonblankIndentedLine { a = cons(yy, a) })+ { yy = p.mkStringFromList(a, false) }) */
+	p.rules[ruleVerbatimChunk] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+	loop:
+		{
+			position1 := p.position
+			p.begin = p.position
+			if !p.rules[ruleBlankLine]() {
+				goto out
+			}
+			p.end = p.position
+			p.do(17)
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleNonblankIndentedLine]() {
+			goto ko
+		}
+		p.do(18)
+	loop3:
+		{
+			position2 := p.position
+			if !p.rules[ruleNonblankIndentedLine]() {
+				goto out4
+			}
+			p.do(18)
+			goto loop3
+		out4:
+			p.position = position2
+		}
+		p.do(19)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 18 Verbatim <- (StartList (VerbatimChunk { a = cons(yy, a) })+ { yy = p.mkStringFromList(a, false)
+	   yy.key = VERBATIM
+	   if p.extension.CodeTabs > 0 {
+	       yy.contents.str = expandTabs(yy.contents.str, p.extension.CodeTabs)
+	   }
+	 }) */
+	p.rules[ruleVerbatim] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleVerbatimChunk]() {
+			goto ko
+		}
+		p.do(20)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleVerbatimChunk]() {
+				goto out
+			}
+			p.do(20)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(21)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 19 HorizontalRule <- (NonindentSpace ((&[_] ('_' Sp '_' Sp '_' (Sp '_')*)) | (&[\-] ('-' Sp '-' Sp '-' (Sp '-')*)) | (&[*] ('*' Sp '*' Sp '*' (Sp '*')*))) Sp Newline BlankLine+ { yy = p.mkElem(HRULE) }) */
+	p.rules[ruleHorizontalRule] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '_':
+				p.position++ // matchChar
+				if !p.rules[ruleSp]() {
+					goto ko
+				}
+				if !p.matchChar('_') {
+					goto ko
+				}
+				if !p.rules[ruleSp]() {
+					goto ko
+				}
+				if !p.matchChar('_') {
+					goto ko
+				}
+			loop:
+				{
+					position1 := p.position
+					if !p.rules[ruleSp]() {
+						goto out
+					}
+					if !p.matchChar('_') {
+						goto out
+					}
+					goto loop
+				out:
+					p.position = position1
+				}
+			case '-':
+				p.position++ // matchChar
+				if !p.rules[ruleSp]() {
+					goto ko
+				}
+				if !p.matchChar('-') {
+					goto ko
+				}
+				if !p.rules[ruleSp]() {
+					goto ko
+				}
+				if !p.matchChar('-') {
+					goto ko
+				}
+			loop4:
+				{
+					position2 := p.position
+					if !p.rules[ruleSp]() {
+						goto out5
+					}
+					if !p.matchChar('-') {
+						goto out5
+					}
+					goto loop4
+				out5:
+					p.position = position2
+				}
+			case '*':
+				p.position++ // matchChar
+				if !p.rules[ruleSp]() {
+					goto ko
+				}
+				if !p.matchChar('*') {
+					goto ko
+				}
+				if !p.rules[ruleSp]() {
+					goto ko
+				}
+				if !p.matchChar('*') {
+					goto ko
+				}
+			loop6:
+				{
+					position3 := p.position
+					if !p.rules[ruleSp]() {
+						goto out7
+					}
+					if !p.matchChar('*') {
+						goto out7
+					}
+					goto loop6
+				out7:
+					p.position = position3
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		if !p.rules[ruleBlankLine]() {
+			goto ko
+		}
+	loop8:
+		if !p.rules[ruleBlankLine]() {
+			goto out9
+		}
+		goto loop8
+	out9:
+		p.do(22)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 20 Bullet <- (!HorizontalRule NonindentSpace ((&[\-] '-') | (&[*] '*') | (&[+] '+')) Spacechar+) */
+	p.rules[ruleBullet] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !p.rules[ruleHorizontalRule]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '-':
+				p.position++ // matchChar
+			case '*':
+				p.position++ // matchChar
+			case '+':
+				p.position++ // matchChar
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpacechar]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleSpacechar]() {
+			goto out
+		}
+		goto loop
+	out:
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 21 BulletList <- (&Bullet (ListTight / ListLoose) { yy.key = BULLETLIST }) */
+	p.rules[ruleBulletList] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		{
+			position1 := p.position
+			if !p.rules[ruleBullet]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleListTight]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleListLoose]() {
+			goto ko
+		}
+	ok:
+		p.do(23)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 22 ListTight <- (StartList (ListItemTight { a = cons(yy, a) })+ BlankLine* !((&[:~] DefMarker) | (&[*+\-] Bullet) | (&[0-9] Enumerator)) { yy = p.mkList(LIST, a) }) */
+	p.rules[ruleListTight] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleListItemTight]() {
+			goto ko
+		}
+		p.do(24)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleListItemTight]() {
+				goto out
+			}
+			p.do(24)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+	loop3:
+		if !p.rules[ruleBlankLine]() {
+			goto out4
+		}
+		goto loop3
+	out4:
+		{
+			if p.position == len(p.Buffer) {
+				goto ok
+			}
+			switch p.Buffer[p.position] {
+			case ':', '~':
+				if !p.rules[ruleDefMarker]() {
+					goto ok
+				}
+			case '*', '+', '-':
+				if !p.rules[ruleBullet]() {
+					goto ok
+				}
+			default:
+				if !p.rules[ruleEnumerator]() {
+					goto ok
+				}
+			}
+		}
+		goto ko
+	ok:
+		p.do(25)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 23 ListLoose <- (StartList (ListItem BlankLine* {
+	    li := b.children
+	    li.contents.str += "\n\n"
+	    a = cons(b, a)
+	})+ { yy = p.mkList(LIST, a) }) */
+	p.rules[ruleListLoose] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleListItem]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+	loop3:
+		if !p.rules[ruleBlankLine]() {
+			goto out4
+		}
+		goto loop3
+	out4:
+		p.do(26)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleListItem]() {
+				goto out
+			}
+			p.doarg(yySet, -2)
+		loop5:
+			if !p.rules[ruleBlankLine]() {
+				goto out6
+			}
+			goto loop5
+		out6:
+			p.do(26)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(27)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 24 ListItem <- (StartList ListMarker ListBlock { a = cons(yy, a) } (ListContinuationBlock { a = cons(yy, a) })* {
+	   raw := p.mkStringFromList(a, false)
+	   raw.key = RAW
+	   yy = p.mkElem(LISTITEM)
+	   yy.contents.str = m.contents.str
+	   yy.children = raw
+	}) */
+	p.rules[ruleListItem] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleListMarker]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		if !p.rules[ruleListBlock]() {
+			goto ko
+		}
+		p.do(28)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleListContinuationBlock]() {
+				goto out
+			}
+			p.do(29)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(30)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 25 ListItemTight <- (StartList ListMarker ListBlock { a = cons(yy, a) } (!BlankLine ListContinuationBlock { a = cons(yy, a) })* !ListContinuationBlock {
+	   raw := p.mkStringFromList(a, false)
+	   raw.key = RAW
+	   yy = p.mkElem(LISTITEM)
+	   yy.contents.str = m.contents.str
+	   yy.children = raw
+	}) */
+	p.rules[ruleListItemTight] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleListMarker]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		if !p.rules[ruleListBlock]() {
+			goto ko
+		}
+		p.do(31)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleBlankLine]() {
+				goto ok4
+			}
+			goto out
+		ok4:
+			if !p.rules[ruleListContinuationBlock]() {
+				goto out
+			}
+			p.do(32)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.rules[ruleListContinuationBlock]() {
+			goto ok5
+		}
+		goto ko
+	ok5:
+		p.do(33)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 26 ListBlock <- (StartList !BlankLine Line { a = cons(yy, a) } (ListBlockLine { a = cons(yy, a) })* { yy = p.mkSegments(a) }) */
+	p.rules[ruleListBlock] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleBlankLine]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleLine]() {
+			goto ko
+		}
+		p.do(34)
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleListBlockLine]() {
+				goto out
+			}
+			p.do(35)
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.do(36)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 27 ListContinuationBlock <- (StartList (< BlankLine* > {   if len(yytext) == 0 {
+	         a = cons(p.mkString("\001"), a) // block separator
+	    } else {
+	         a = cons(p.mkString(yytext), a)
+	    }
+	}) (ListIndent ListBlock { a = cons(yy, a) })+ {  yy = p.mkSegments(a) }) */
+	p.rules[ruleListContinuationBlock] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		p.begin = p.position
+	loop:
+		if !p.rules[ruleBlankLine]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		p.do(37)
+		if !p.rules[ruleListIndent]() {
+			goto ko
+		}
+		if !p.rules[ruleListBlock]() {
+			goto ko
+		}
+		p.do(38)
+	loop3:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleListIndent]() {
+				goto out4
+			}
+			if !p.rules[ruleListBlock]() {
+				goto out4
+			}
+			p.do(38)
+			goto loop3
+		out4:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(39)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 28 Enumerator <- (NonindentSpace (([0-9]+ '.') / FancyEnumerator / ExampleEnumerator) Spacechar+) */
+	p.rules[ruleEnumerator] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		{
+			position1 := p.position
+			if !p.matchClass(0) {
+				goto nextAlt
+			}
+		loop:
+			if !p.matchClass(0) {
+				goto out
+			}
+			goto loop
+		out:
+			if !p.matchChar('.') {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			p.position = position1
+			if !p.rules[ruleFancyEnumerator]() {
+				goto nextAlt3
+			}
+			goto ok
+		nextAlt3:
+			if !p.rules[ruleExampleEnumerator]() {
+				goto ko
+			}
+		}
+	ok:
+		if !p.rules[ruleSpacechar]() {
+			goto ko
+		}
+	loop5:
+		if !p.rules[ruleSpacechar]() {
+			goto out6
+		}
+		goto loop5
+	out6:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 29 OrderedList <- (&Enumerator (ListTight / ListLoose) { yy.key = ORDEREDLIST
+	  if p.extension.FancyLists {
+	      yy.contents.str = yy.children.contents.str
+	  }
+	}) */
+	p.rules[ruleOrderedList] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		{
+			position1 := p.position
+			if !p.rules[ruleEnumerator]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleListTight]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleListLoose]() {
+			goto ko
+		}
+	ok:
+		p.do(40)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 30 ListBlockLine <- (!BlankLine !((&[:~] DefMarker) | (&[\t (*+\-0-9A-Za-z] (ListIndent? ((&[*+\-] Bullet) | (&[(0-9A-Za-z] Enumerator))))) !HorizontalRule OptionallyIndentedLine) */
+	p.rules[ruleListBlockLine] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !p.rules[ruleBlankLine]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		{
+			position1 := p.position
+			{
+				if p.position == len(p.Buffer) {
+					goto ok2
+				}
+				switch p.Buffer[p.position] {
+				case ':', '~':
+					if !p.rules[ruleDefMarker]() {
+						goto ok2
+					}
+				default:
+					if !p.rules[ruleListIndent]() {
+						goto ko4
+					}
+				ko4:
+					{
+						if p.position == len(p.Buffer) {
+							goto ok2
+						}
+						switch p.Buffer[p.position] {
+						case '*', '+', '-':
+							if !p.rules[ruleBullet]() {
+								goto ok2
+							}
+						default:
+							if !p.rules[ruleEnumerator]() {
+								goto ok2
+							}
+						}
+					}
+				}
+			}
+			goto ko
+		ok2:
+			p.position = position1
+		}
+		if !p.rules[ruleHorizontalRule]() {
+			goto ok7
+		}
+		goto ko
+	ok7:
+		if !p.rules[ruleOptionallyIndentedLine]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 137 StyleOpen <- ('<' Spnl ((&[S] 'STYLE') | (&[s] 'style')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleStyleOpen] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'S':
+				p.position++
+				if !p.matchString("TYLE") {
+					goto ko
+				}
+			case 's':
+				p.position++
+				if !p.matchString("tyle") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 138 StyleClose <- ('<' Spnl '/' ((&[S] 'STYLE') | (&[s] 'style')) Spnl '>') */
+	p.rules[ruleStyleClose] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'S':
+				p.position++
+				if !p.matchString("TYLE") {
+					goto ko
+				}
+			case 's':
+				p.position++
+				if !p.matchString("tyle") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 139 InStyleTags <- (StyleOpen (!StyleClose .)* StyleClose) */
+	p.rules[ruleInStyleTags] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleStyleOpen]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleStyleClose]() {
+				goto ok
+			}
+			goto out
+		ok:
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleStyleClose]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 140 StyleBlock <- (< InStyleTags > BlankLine* {   if p.extension.FilterStyles {
+	        yy = p.mkList(LIST, nil)
+	    } else {
+	        yy = p.mkString(escapeTags(yytext, p.blockTags()))
+	        yy.key = HTMLBLOCK
+	    }
+	}) */
+	p.rules[ruleStyleBlock] = func() (match bool) {
+		position0 := p.position
+		p.begin = p.position
+		if !p.rules[ruleInStyleTags]() {
+			goto ko
+		}
+		p.end = p.position
+	loop:
+		if !p.rules[ruleBlankLine]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.do(42)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 141 Inlines <- (StartList ((InlineComment { a = cons(c, a) }) / (!Endline Inline { a = cons(yy, a) }) / (Endline &Inline { a = cons(c, a) }))+ Endline? { yy = p.mkList(LIST, a) }) */
+	p.rules[ruleInlines] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		{
+			position1 := p.position
+			if !p.rules[ruleInlineComment]() {
+				goto nextAlt3
+			}
+			p.doarg(yySet, -2)
+			p.do(135)
+			goto ok
+		nextAlt3:
+			if !p.rules[ruleEndline]() {
+				goto ok5
+			}
+			goto nextAlt
+		ok5:
+			if !p.rules[ruleInline]() {
+				goto nextAlt
+			}
+			p.do(43)
+			goto ok
+		nextAlt:
+			p.position = position1
+			if !p.rules[ruleEndline]() {
+				goto ko
+			}
+			p.doarg(yySet, -2)
+			{
+				position2 := p.position
+				if !p.rules[ruleInline]() {
+					goto ko
+				}
+				p.position = position2
+			}
+			p.do(44)
+		}
+	ok:
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			{
+				position4 := p.position
+				if !p.rules[ruleInlineComment]() {
+					goto nextAlt6
+				}
+				p.doarg(yySet, -2)
+				p.do(135)
+				goto ok7
+			nextAlt6:
+				if !p.rules[ruleEndline]() {
+					goto ok9
+				}
+				goto nextAlt8
+			ok9:
+				if !p.rules[ruleInline]() {
+					goto nextAlt8
+				}
+				p.do(43)
+				goto ok7
+			nextAlt8:
+				p.position = position4
+				if !p.rules[ruleEndline]() {
+					goto out
+				}
+				p.doarg(yySet, -2)
+				{
+					position5 := p.position
+					if !p.rules[ruleInline]() {
+						goto out
+					}
+					p.position = position5
+				}
+				p.do(44)
+			}
+		ok7:
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.rules[ruleEndline]() {
+			goto ko11
+		}
+	ko11:
+		p.do(45)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 164 TwoTildeOpen <- (&{p.extension.Strike} !TildeLine '~~' !Spacechar !Newline) */
+	p.rules[ruleTwoTildeOpen] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.Strike) {
+			goto ko
+		}
+		if !p.rules[ruleTildeLine]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.matchString("~~") {
+			goto ko
+		}
+		if !p.rules[ruleSpacechar]() {
+			goto ok2
+		}
+		goto ko
+	ok2:
+		if !p.rules[ruleNewline]() {
+			goto ok3
+		}
+		goto ko
+	ok3:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 165 TwoTildeClose <- (&{p.extension.Strike} !Spacechar !Newline Inline '~~' { yy = a; }) */
+	p.rules[ruleTwoTildeClose] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !(p.extension.Strike) {
+			goto ko
+		}
+		if !p.rules[ruleSpacechar]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleNewline]() {
+			goto ok2
+		}
+		goto ko
+	ok2:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchString("~~") {
+			goto ko
+		}
+		p.do(69)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 181 Reference <- (NonindentSpace !'[]' Label ':' Spnl RefSrc RefTitle BlankLine+ { yy = p.mkLink(l.children, s.contents.str, t.contents.str)
+	   s = nil
+	   t = nil
+	   l = nil
+	   yy.key = REFERENCE }) */
+	p.rules[ruleReference] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 3)
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		if !p.matchString("[]") {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleLabel]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchChar(':') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.rules[ruleRefSrc]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		if !p.rules[ruleRefTitle]() {
+			goto ko
+		}
+		p.doarg(yySet, -3)
+		if !p.rules[ruleBlankLine]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleBlankLine]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.do(80)
+		p.doarg(yyPop, 3)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 183 RefSrc <- (< Nonspacechar+ > { yy = p.mkString(yytext)
+	   yy.key = HTML }) */
+	p.rules[ruleRefSrc] = func() (match bool) {
+		position0 := p.position
+		p.begin = p.position
+		if !p.rules[ruleNonspacechar]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleNonspacechar]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		p.do(83)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 184 RefTitle <- ((RefTitleSingle / RefTitleDouble / RefTitleParens / EmptyTitle) { yy = p.mkString(yytext) }) */
+	p.rules[ruleRefTitle] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleRefTitleSingle]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleRefTitleDouble]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleRefTitleParens]() {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+		if !p.rules[ruleEmptyTitle]() {
+			goto ko
+		}
+	ok:
+		p.do(84)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 185 EmptyTitle <- (< '' >) */
+	p.rules[ruleEmptyTitle] = func() (match bool) {
+		p.begin = p.position
+		p.end = p.position
+		match = true
+		return
+	}
+	/* 186 RefTitleSingle <- (Spnl '\'' < (!((&[\'] ('\'' Sp Newline)) | (&[\n\r] Newline)) .)* > '\'') */
+	p.rules[ruleRefTitleSingle] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('\'') {
+			goto ko
+		}
+		p.begin = p.position
+	loop:
+		{
+			position1 := p.position
+			{
+				position2 := p.position
+				{
+					if p.position == len(p.Buffer) {
+						goto ok
+					}
+					switch p.Buffer[p.position] {
+					case '\'':
+						p.position++ // matchChar
+						if !p.rules[ruleSp]() {
+							goto ok
+						}
+						if !p.rules[ruleNewline]() {
+							goto ok
+						}
+					case '\n', '\r':
+						if !p.rules[ruleNewline]() {
+							goto ok
+						}
+					default:
+						goto ok
+					}
+				}
+				goto out
+			ok:
+				p.position = position2
+			}
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.end = p.position
+		if !p.matchChar('\'') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 187 RefTitleDouble <- (Spnl '"' < (!((&[\"] ('"' Sp Newline)) | (&[\n\r] Newline)) .)* > '"') */
+	p.rules[ruleRefTitleDouble] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('"') {
+			goto ko
+		}
+		p.begin = p.position
+	loop:
+		{
+			position1 := p.position
+			{
+				position2 := p.position
+				{
+					if p.position == len(p.Buffer) {
+						goto ok
+					}
+					switch p.Buffer[p.position] {
+					case '"':
+						p.position++ // matchChar
+						if !p.rules[ruleSp]() {
+							goto ok
+						}
+						if !p.rules[ruleNewline]() {
+							goto ok
+						}
+					case '\n', '\r':
+						if !p.rules[ruleNewline]() {
+							goto ok
+						}
+					default:
+						goto ok
+					}
+				}
+				goto out
+			ok:
+				p.position = position2
+			}
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.end = p.position
+		if !p.matchChar('"') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 188 RefTitleParens <- (Spnl '(' < (!((&[)] (')' Sp Newline)) | (&[\n\r] Newline)) .)* > ')') */
+	p.rules[ruleRefTitleParens] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('(') {
+			goto ko
+		}
+		p.begin = p.position
+	loop:
+		{
+			position1 := p.position
+			{
+				position2 := p.position
+				{
+					if p.position == len(p.Buffer) {
+						goto ok
+					}
+					switch p.Buffer[p.position] {
+					case ')':
+						p.position++ // matchChar
+						if !p.rules[ruleSp]() {
+							goto ok
+						}
+						if !p.rules[ruleNewline]() {
+							goto ok
+						}
+					case '\n', '\r':
+						if !p.rules[ruleNewline]() {
+							goto ok
+						}
+					default:
+						goto ok
+					}
+				}
+				goto out
+			ok:
+				p.position = position2
+			}
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.end = p.position
+		if !p.matchChar(')') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 189 References <- (StartList ((Reference { a = cons(b, a) }) / SkipBlock)* { p.references = reverse(a)
+	   p.state.heap.hasGlobals = true
+	 } commit) */
+	p.rules[ruleReferences] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			{
+				position2, thunkPosition2 := p.position, p.thunkPosition
+				if !p.rules[ruleReference]() {
+					goto nextAlt
+				}
+				p.doarg(yySet, -2)
+				p.do(85)
+				goto ok
+			nextAlt:
+				p.position, p.thunkPosition = position2, thunkPosition2
+				if !p.rules[ruleSkipBlock]() {
+					goto out
+				}
+			}
+		ok:
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(86)
+		if !(p.commit(thunkPosition0)) {
+			goto ko
+		}
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 202 Eof <- !. */
+	p.rules[ruleEof] = func() (match bool) {
+		if p.position < len(p.Buffer) {
+			return
+		}
+		match = true
+		return
+	}
+	/* 211 AlphanumericAscii <- [A-Za-z0-9] */
+	p.rules[ruleAlphanumericAscii] = func() (match bool) {
+		if !p.matchClass(5) {
+			return
+		}
+		match = true
+		return
+	}
+	/* 216 NonindentSpace <- ('   ' / '  ' / ' ' / '') */
+	p.rules[ruleNonindentSpace] = func() (match bool) {
+		if !p.matchString("   ") {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchString("  ") {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.matchChar(' ') {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+	ok:
+		match = true
+		return
+	}
+	/* 217 Indent <- ((&[ ] '    ') | (&[\t] '\t')) */
+	p.rules[ruleIndent] = func() (match bool) {
+		{
+			if p.position == len(p.Buffer) {
+				return
+			}
+			switch p.Buffer[p.position] {
+			case ' ':
+				p.position++
+				if !p.matchString("   ") {
+					return
+				}
+			case '\t':
+				p.position++ // matchChar
+			default:
+				return
+			}
+		}
+		match = true
+		return
+	}
+	/* 218 IndentedLine <- (Indent Line) */
+	p.rules[ruleIndentedLine] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleIndent]() {
+			goto ko
+		}
+		if !p.rules[ruleLine]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 219 OptionallyIndentedLine <- (Indent? Line) */
+	p.rules[ruleOptionallyIndentedLine] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleIndent]() {
+			goto ko1
+		}
+	ko1:
+		if !p.rules[ruleLine]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 223 SkipBlock <- (HtmlBlock / ((!'#' !SetextBottom1 !SetextBottom2 !BlankLine RawLine)+ BlankLine*) / BlankLine+ / RawLine) */
+	p.rules[ruleSkipBlock] = func() (match bool) {
+		position0 := p.position
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlock]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if p.peekChar('#') {
+				goto nextAlt3
+			}
+			if !p.rules[ruleSetextBottom1]() {
+				goto ok6
+			}
+			goto nextAlt3
+		ok6:
+			if !p.rules[ruleSetextBottom2]() {
+				goto ok7
+			}
+			goto nextAlt3
+		ok7:
+			if !p.rules[ruleBlankLine]() {
+				goto ok8
+			}
+			goto nextAlt3
+		ok8:
+			if !p.rules[ruleRawLine]() {
+				goto nextAlt3
+			}
+		loop:
+			{
+				position2 := p.position
+				if p.peekChar('#') {
+					goto out
+				}
+				if !p.rules[ruleSetextBottom1]() {
+					goto ok9
+				}
+				goto out
+			ok9:
+				if !p.rules[ruleSetextBottom2]() {
+					goto ok10
+				}
+				goto out
+			ok10:
+				if !p.rules[ruleBlankLine]() {
+					goto ok11
+				}
+				goto out
+			ok11:
+				if !p.rules[ruleRawLine]() {
+					goto out
+				}
+				goto loop
+			out:
+				p.position = position2
+			}
+		loop12:
+			if !p.rules[ruleBlankLine]() {
+				goto out13
+			}
+			goto loop12
+		out13:
+			goto ok
+		nextAlt3:
+			p.position = position1
+			if !p.rules[ruleBlankLine]() {
+				goto nextAlt14
+			}
+		loop15:
+			if !p.rules[ruleBlankLine]() {
+				goto out16
+			}
+			goto loop15
+		out16:
+			goto ok
+		nextAlt14:
+			p.position = position1
+			if !p.rules[ruleRawLine]() {
+				goto ko
+			}
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 234 DoubleQuoteStart <- '"' */
+	p.rules[ruleDoubleQuoteStart] = func() (match bool) {
+		if !p.matchChar('"') {
+			return
+		}
+		match = true
+		return
+	}
+	/* 235 DoubleQuoteEnd <- '"' */
+	p.rules[ruleDoubleQuoteEnd] = func() (match bool) {
+		if !p.matchChar('"') {
+			return
+		}
+		match = true
+		return
+	}
+	/* 239 Note <- (&{p.extension.Notes} NonindentSpace RawNoteReference ':' Sp StartList (RawNoteBlock { a = cons(yy, a) }) (&Indent RawNoteBlock { a = cons(yy, a) })* {   yy = p.mkList(NOTE, a)
+	    yy.contents.str = ref.contents.str
+	}) */
+	p.rules[ruleNote] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !(p.extension.Notes) {
+			goto ko
+		}
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		if !p.rules[ruleRawNoteReference]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		if !p.matchChar(':') {
+			goto ko
+		}
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleRawNoteBlock]() {
+			goto ko
+		}
+		p.do(101)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			{
+				position2 := p.position
+				if !p.rules[ruleIndent]() {
+					goto out
+				}
+				p.position = position2
+			}
+			if !p.rules[ruleRawNoteBlock]() {
+				goto out
+			}
+			p.do(102)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(103)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 241 Notes <- (StartList ((Note { a = cons(b, a) }) / SkipBlock)* { p.notes = reverse(a) } commit) */
+	p.rules[ruleNotes] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			{
+				position2, thunkPosition2 := p.position, p.thunkPosition
+				if !p.rules[ruleNote]() {
+					goto nextAlt
+				}
+				p.doarg(yySet, -2)
+				p.do(106)
+				goto ok
+			nextAlt:
+				p.position, p.thunkPosition = position2, thunkPosition2
+				if !p.rules[ruleSkipBlock]() {
+					goto out
+				}
+			}
+		ok:
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(107)
+		if !(p.commit(thunkPosition0)) {
+			goto ko
+		}
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 242 RawNoteBlock <- (StartList (!BlankLine OptionallyIndentedLine { a = cons(yy, a) })+ (< BlankLine* > { a = cons(p.mkString(yytext), a) }) {   yy = p.mkStringFromList(a, true)
+	       p.state.heap.hasGlobals = true
+	   yy.key = RAW
+	 }) */
+	p.rules[ruleRawNoteBlock] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleBlankLine]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleOptionallyIndentedLine]() {
+			goto ko
+		}
+		p.do(108)
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleBlankLine]() {
+				goto ok4
+			}
+			goto out
+		ok4:
+			if !p.rules[ruleOptionallyIndentedLine]() {
+				goto out
+			}
+			p.do(108)
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.begin = p.position
+	loop5:
+		if !p.rules[ruleBlankLine]() {
+			goto out6
+		}
+		goto loop5
+	out6:
+		p.end = p.position
+		p.do(109)
+		p.do(110)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 243 DefinitionList <- (&{p.extension.Dlists} StartList (Definition { a = cons(yy, a) })+ { yy = p.mkList(DEFINITIONLIST, a) }) */
+	p.rules[ruleDefinitionList] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !(p.extension.Dlists) {
+			goto ko
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleDefinition]() {
+			goto ko
+		}
+		p.do(111)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleDefinition]() {
+				goto out
+			}
+			p.do(111)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(112)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 244 Definition <- (&(NonindentSpace !Defmark Nonspacechar RawLine BlankLine? Defmark) StartList (DListTitle { a = cons(yy, a) })+ (DefTight / DefLoose) {
+		for e := yy.children; e != nil; e = e.next {
+			e.key = DEFDATA
+		}
+		a = cons(yy, a)
+	} { yy = p.mkList(LIST, a) }) */
+	p.rules[ruleDefinition] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		{
+			position1 := p.position
+			if !p.rules[ruleNonindentSpace]() {
+				goto ko
+			}
+			if !p.rules[ruleDefmark]() {
+				goto ok
+			}
+			goto ko
+		ok:
+			if !p.rules[ruleNonspacechar]() {
+				goto ko
+			}
+			if !p.rules[ruleRawLine]() {
+				goto ko
+			}
+			if !p.rules[ruleBlankLine]() {
+				goto ko3
+			}
+		ko3:
+			if !p.rules[ruleDefmark]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleDListTitle]() {
+			goto ko
+		}
+		p.do(113)
+	loop:
+		{
+			position2, thunkPosition2 := p.position, p.thunkPosition
+			if !p.rules[ruleDListTitle]() {
+				goto out
+			}
+			p.do(113)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position2, thunkPosition2
+		}
+		if !p.rules[ruleDefTight]() {
+			goto nextAlt
+		}
+		goto ok7
+	nextAlt:
+		if !p.rules[ruleDefLoose]() {
+			goto ko
+		}
+	ok7:
+		p.do(114)
+		p.do(115)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 245 DListTitle <- (NonindentSpace !Defmark &Nonspacechar StartList (!Endline Inline { a = cons(yy, a) })+ Sp Newline {	yy = p.mkList(LIST, a)
+		yy.key = DEFTITLE
+	}) */
+	p.rules[ruleDListTitle] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		if !p.rules[ruleDefmark]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		{
+			position1 := p.position
+			if !p.rules[ruleNonspacechar]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleEndline]() {
+			goto ok5
+		}
+		goto ko
+	ok5:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.do(116)
+	loop:
+		{
+			position2 := p.position
+			if !p.rules[ruleEndline]() {
+				goto ok6
+			}
+			goto out
+		ok6:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.do(116)
+			goto loop
+		out:
+			p.position = position2
+		}
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		p.do(117)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 246 DefTight <- (&Defmark ListTight) */
+	p.rules[ruleDefTight] = func() (match bool) {
+		{
+			position1 := p.position
+			if !p.rules[ruleDefmark]() {
+				return
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleListTight]() {
+			return
+		}
+		match = true
+		return
+	}
+	/* 247 DefLoose <- (BlankLine &Defmark ListLoose) */
+	p.rules[ruleDefLoose] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleBlankLine]() {
+			goto ko
+		}
+		{
+			position1 := p.position
+			if !p.rules[ruleDefmark]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleListLoose]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 248 Defmark <- (NonindentSpace ((&[~] '~') | (&[:] ':')) Spacechar+) */
+	p.rules[ruleDefmark] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '~':
+				p.position++ // matchChar
+			case ':':
+				p.position++ // matchChar
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpacechar]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleSpacechar]() {
+			goto out
+		}
+		goto loop
+	out:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 249 DefMarker <- (&{p.extension.Dlists} Defmark) */
+	p.rules[ruleDefMarker] = func() (match bool) {
+		if !(p.extension.Dlists) {
+			return
+		}
+		if !p.rules[ruleDefmark]() {
+			return
+		}
+		match = true
+		return
+	}
+	/* 251 ListMarker <- (&((&[:~] DefMarker) | (&[*+\-] Bullet) | (&[(0-9A-Za-z] Enumerator)) NonindentSpace < Nonspacechar+ > Spacechar+ { yy = p.mkString(yytext) }) */
+	p.rules[ruleListMarker] = func() (match bool) {
+		position0 := p.position
+		{
+			position1 := p.position
+			{
+				if p.position == len(p.Buffer) {
+					goto ko
+				}
+				switch p.Buffer[p.position] {
+				case ':', '~':
+					if !p.rules[ruleDefMarker]() {
+						goto ko
+					}
+				case '*', '+', '-':
+					if !p.rules[ruleBullet]() {
+						goto ko
+					}
+				default:
+					if !p.rules[ruleEnumerator]() {
+						goto ko
+					}
+				}
+			}
+			p.position = position1
+		}
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.rules[ruleNonspacechar]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleNonspacechar]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		if !p.rules[ruleSpacechar]() {
+			goto ko
+		}
+	loop3:
+		if !p.rules[ruleSpacechar]() {
+			goto out4
+		}
+		goto loop3
+	out4:
+		p.do(118)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 252 FancyEnumerator <- (&{p.extension.FancyLists} (('(' EnumeratorValue ')') / (EnumeratorValue ((&[)] ')') | (&[.] '.'))))) */
+	p.rules[ruleFancyEnumerator] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.FancyLists) {
+			goto ko
+		}
+		{
+			position1 := p.position
+			if !p.matchChar('(') {
+				goto nextAlt
+			}
+			if !p.rules[ruleEnumeratorValue]() {
+				goto nextAlt
+			}
+			if !p.matchChar(')') {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			p.position = position1
+			if !p.rules[ruleEnumeratorValue]() {
+				goto ko
+			}
+			{
+				if p.position == len(p.Buffer) {
+					goto ko
+				}
+				switch p.Buffer[p.position] {
+				case ')':
+					p.position++ // matchChar
+				case '.':
+					p.position++ // matchChar
+				default:
+					goto ko
+				}
+			}
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 253 EnumeratorValue <- ([0-9]+ / [ivxlcdm]+ / [IVXLCDM]+ / [A-Za-z]) */
+	p.rules[ruleEnumeratorValue] = func() (match bool) {
+		if !p.matchClass(0) {
+			goto nextAlt
+		}
+	loop:
+		if !p.matchClass(0) {
+			goto out
+		}
+		goto loop
+	out:
+		goto ok
+	nextAlt:
+		if !p.matchClass(8) {
+			goto nextAlt3
+		}
+	loop4:
+		if !p.matchClass(8) {
+			goto out5
+		}
+		goto loop4
+	out5:
+		goto ok
+	nextAlt3:
+		if !p.matchClass(9) {
+			goto nextAlt6
+		}
+	loop7:
+		if !p.matchClass(9) {
+			goto out8
+		}
+		goto loop7
+	out8:
+		goto ok
+	nextAlt6:
+		if !p.matchClass(2) {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 254 ListIndent <- (Indent / (&{p.extension.LaxSublists} ('   ' / '  '))) */
+	p.rules[ruleListIndent] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleIndent]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		p.position = position0
+		if !(p.extension.LaxSublists) {
+			goto ko
+		}
+		if !p.matchString("   ") {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.matchString("  ") {
+			goto ko
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 260 InlineDoc <- (Inlines { p.tree = yy } commit) */
+	p.rules[ruleInlineDoc] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !p.rules[ruleInlines]() {
+			goto ko
+		}
+		p.do(120)
+		if !(p.commit(thunkPosition0)) {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 271 Container <- (&{p.extension.Containers} NonindentSpace < ContainerOpen ContainerInner* > ContainerClose { yy = p.mkContainer(yytext) }) */
+	p.rules[ruleContainer] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.Containers) {
+			goto ko
+		}
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.rules[ruleContainerOpen]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleContainerInner]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		if !p.rules[ruleContainerClose]() {
+			goto ko
+		}
+		p.do(128)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 272 ContainerOpen <- (':::' ':'* Sp TagChar+ Sp ('{' (!'}' !Newline .)* '}' Sp)? Newline) */
+	p.rules[ruleContainerOpen] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString(":::") {
+			goto ko
+		}
+	loop:
+		if !p.matchChar(':') {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleTagChar]() {
+			goto ko
+		}
+	loop3:
+		if !p.rules[ruleTagChar]() {
+			goto out4
+		}
+		goto loop3
+	out4:
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		{
+			position1 := p.position
+			if !p.matchChar('{') {
+				goto out5
+			}
+		loop6:
+			{
+				position2 := p.position
+				if p.peekChar('}') {
+					goto out7
+				}
+				if !p.rules[ruleNewline]() {
+					goto ok
+				}
+				goto out7
+			ok:
+				if !p.matchDot() {
+					goto out7
+				}
+				goto loop6
+			out7:
+				p.position = position2
+			}
+			if !p.matchChar('}') {
+				goto out5
+			}
+			if !p.rules[ruleSp]() {
+				goto out5
+			}
+			goto ok8
+		out5:
+			p.position = position1
+		}
+	ok8:
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 273 ContainerInner <- ((NonindentSpace ContainerOpen ContainerInner* ContainerClose) / (!ContainerClose ContainerLine)) */
+	p.rules[ruleContainerInner] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNonindentSpace]() {
+			goto nextAlt
+		}
+		if !p.rules[ruleContainerOpen]() {
+			goto nextAlt
+		}
+	loop:
+		if !p.rules[ruleContainerInner]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.rules[ruleContainerClose]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		p.position = position0
+		if !p.rules[ruleContainerClose]() {
+			goto ok4
+		}
+		goto ko
+	ok4:
+		if !p.rules[ruleContainerLine]() {
+			goto ko
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 274 ContainerClose <- (NonindentSpace ':::' ':'* Sp (Newline / Eof)) */
+	p.rules[ruleContainerClose] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		if !p.matchString(":::") {
+			goto ko
+		}
+	loop:
+		if !p.matchChar(':') {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleEof]() {
+			goto ko
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 275 ContainerLine <- ((!Newline .)* Newline) */
+	p.rules[ruleContainerLine] = func() (match bool) {
+		position0 := p.position
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleNewline]() {
+				goto ok
+			}
+			goto out
+		ok:
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 276 Comment <- (&{p.extension.Comments} StartList (CommentText Newline { a = cons(yy, a) })+ BlankLine* { yy = p.mkComment(a) }) */
+	p.rules[ruleComment] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !(p.extension.Comments) {
+			goto ko
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleCommentText]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		p.do(129)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleCommentText]() {
+				goto out
+			}
+			if !p.rules[ruleNewline]() {
+				goto out
+			}
+			p.do(129)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+	loop3:
+		if !p.rules[ruleBlankLine]() {
+			goto out4
+		}
+		goto loop3
+	out4:
+		p.do(130)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 277 InlineComment <- (&{p.extension.Comments} Sp Newline StartList CommentText { a = cons(yy, a) } (Newline CommentText { a = cons(yy, a) })* { yy = p.mkComment(a) }) */
+	p.rules[ruleInlineComment] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !(p.extension.Comments) {
+			goto ko
+		}
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleCommentText]() {
+			goto ko
+		}
+		p.do(131)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleNewline]() {
+				goto out
+			}
+			if !p.rules[ruleCommentText]() {
+				goto out
+			}
+			p.do(132)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(133)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 278 CommentText <- (NonindentSpace ('%%' / '//') ' '? < (!Newline .)* > { yy = p.mkString(yytext) }) */
+	p.rules[ruleCommentText] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		if !p.matchString("%%") {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchString("//") {
+			goto ko
+		}
+	ok:
+		p.matchChar(' ')
+		p.begin = p.position
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleNewline]() {
+				goto ok4
+			}
+			goto out
+		ok4:
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.end = p.position
+		p.do(134)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 279 RuleTest <- (&{p.rules[p.testRule]()} { p.tree = yy } commit) */
+	p.rules[ruleRuleTest] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !(p.rules[p.testRule]()) {
+			goto ko
+		}
+		p.do(136)
+		if !(p.commit(thunkPosition0)) {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 280 ExampleEnumerator <- (&{p.extension.Examples} '(@' ((&[_] '_') | (&[\-] '-') | (&[0-9A-Za-z] [A-Za-z0-9]))* ')') */
+	p.rules[ruleExampleEnumerator] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.Examples) {
+			goto ko
+		}
+		if !p.matchString("(@") {
+			goto ko
+		}
+	loop:
+		{
+			if p.position == len(p.Buffer) {
+				goto out
+			}
+			switch p.Buffer[p.position] {
+			case '_':
+				p.position++ // matchChar
+			case '-':
+				p.position++ // matchChar
+			default:
+				if !p.matchClass(5) {
+					goto out
+				}
+			}
+		}
+		goto loop
+	out:
+		if !p.matchChar(')') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 281 GridTable <- (&{p.extension.GridTables} < GridBorder (GridRowLine+ GridBorder)+ > BlankLine* { yy = p.mkGridTable(yytext) }) */
+	p.rules[ruleGridTable] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !(p.extension.GridTables) {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.rules[ruleGridBorder]() {
+			goto ko
+		}
+		if !p.rules[ruleGridRowLine]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleGridRowLine]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.rules[ruleGridBorder]() {
+			goto ko
+		}
+	loop3:
+		{
+			position1 := p.position
+			if !p.rules[ruleGridRowLine]() {
+				goto out4
+			}
+		loop5:
+			if !p.rules[ruleGridRowLine]() {
+				goto out6
+			}
+			goto loop5
+		out6:
+			if !p.rules[ruleGridBorder]() {
+				goto out4
+			}
+			goto loop3
+		out4:
+			p.position = position1
+		}
+		p.end = p.position
+	loop7:
+		if !p.rules[ruleBlankLine]() {
+			goto out8
+		}
+		goto loop7
+	out8:
+		p.do(137)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 282 GridBorder <- (NonindentSpace '+' ((('-'+) / ('='+)) '+')+ Sp Newline) */
+	p.rules[ruleGridBorder] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		if !p.matchChar('+') {
+			goto ko
+		}
+		if !p.matchChar('-') {
+			goto nextAlt
+		}
+	loop:
+		if !p.matchChar('-') {
+			goto out
+		}
+		goto loop
+	out:
+		goto ok
+	nextAlt:
+		if !p.matchChar('=') {
+			goto ko
+		}
+	loop3:
+		if !p.matchChar('=') {
+			goto ok
+		}
+		goto loop3
+	ok:
+		if !p.matchChar('+') {
+			goto ko
+		}
+	loop4:
+		{
+			position1 := p.position
+			if !p.matchChar('-') {
+				goto nextAlt6
+			}
+		loop7:
+			if !p.matchChar('-') {
+				goto ok9
+			}
+			goto loop7
+		nextAlt6:
+			if !p.matchChar('=') {
+				goto out5
+			}
+		loop8:
+			if !p.matchChar('=') {
+				goto ok9
+			}
+			goto loop8
+		ok9:
+			if !p.matchChar('+') {
+				goto out5
+			}
+			goto loop4
+		out5:
+			p.position = position1
+		}
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 283 GridRowLine <- (NonindentSpace '|' (!Newline .)* Newline) */
+	p.rules[ruleGridRowLine] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleNonindentSpace]() {
+			goto ko
+		}
+		if !p.matchChar('|') {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleNewline]() {
+				goto ok
+			}
+			goto out
+		ok:
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+}
@@ -0,0 +1,4847 @@
+/* Rules of the grammar in parser.leg matching HTML blocks.
+ * Generated by leg, and split off parser.leg.go by gen.go.
+ */
+
+package markdown
+
+func (p *yyParser) htmlBlockRules() {
+	/* 31 HtmlBlockOpenAddress <- ('<' Spnl ((&[A] 'ADDRESS') | (&[a] 'address')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenAddress] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'A':
+				p.position++
+				if !p.matchString("DDRESS") {
+					goto ko
+				}
+			case 'a':
+				p.position++
+				if !p.matchString("ddress") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 32 HtmlBlockCloseAddress <- ('<' Spnl '/' ((&[A] 'ADDRESS') | (&[a] 'address')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseAddress] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'A':
+				p.position++
+				if !p.matchString("DDRESS") {
+					goto ko
+				}
+			case 'a':
+				p.position++
+				if !p.matchString("ddress") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 33 HtmlBlockAddress <- (HtmlBlockOpenAddress (HtmlBlockAddress / (!HtmlBlockCloseAddress .))* HtmlBlockCloseAddress) */
+	p.rules[ruleHtmlBlockAddress] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenAddress]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockAddress]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseAddress]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseAddress]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 34 HtmlBlockOpenBlockquote <- ('<' Spnl ((&[B] 'BLOCKQUOTE') | (&[b] 'blockquote')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenBlockquote] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'B':
+				p.position++
+				if !p.matchString("LOCKQUOTE") {
+					goto ko
+				}
+			case 'b':
+				p.position++
+				if !p.matchString("lockquote") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 35 HtmlBlockCloseBlockquote <- ('<' Spnl '/' ((&[B] 'BLOCKQUOTE') | (&[b] 'blockquote')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseBlockquote] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'B':
+				p.position++
+				if !p.matchString("LOCKQUOTE") {
+					goto ko
+				}
+			case 'b':
+				p.position++
+				if !p.matchString("lockquote") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 36 HtmlBlockBlockquote <- (HtmlBlockOpenBlockquote (HtmlBlockBlockquote / (!HtmlBlockCloseBlockquote .))* HtmlBlockCloseBlockquote) */
+	p.rules[ruleHtmlBlockBlockquote] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenBlockquote]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockBlockquote]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseBlockquote]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseBlockquote]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 37 HtmlBlockOpenCenter <- ('<' Spnl ((&[C] 'CENTER') | (&[c] 'center')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenCenter] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'C':
+				p.position++
+				if !p.matchString("ENTER") {
+					goto ko
+				}
+			case 'c':
+				p.position++
+				if !p.matchString("enter") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 38 HtmlBlockCloseCenter <- ('<' Spnl '/' ((&[C] 'CENTER') | (&[c] 'center')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseCenter] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'C':
+				p.position++
+				if !p.matchString("ENTER") {
+					goto ko
+				}
+			case 'c':
+				p.position++
+				if !p.matchString("enter") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 39 HtmlBlockCenter <- (HtmlBlockOpenCenter (HtmlBlockCenter / (!HtmlBlockCloseCenter .))* HtmlBlockCloseCenter) */
+	p.rules[ruleHtmlBlockCenter] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenCenter]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockCenter]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseCenter]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseCenter]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 40 HtmlBlockOpenDir <- ('<' Spnl ((&[D] 'DIR') | (&[d] 'dir')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenDir] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++
+				if !p.matchString("IR") {
+					goto ko
+				}
+			case 'd':
+				p.position++
+				if !p.matchString("ir") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 41 HtmlBlockCloseDir <- ('<' Spnl '/' ((&[D] 'DIR') | (&[d] 'dir')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseDir] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++
+				if !p.matchString("IR") {
+					goto ko
+				}
+			case 'd':
+				p.position++
+				if !p.matchString("ir") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 42 HtmlBlockDir <- (HtmlBlockOpenDir (HtmlBlockDir / (!HtmlBlockCloseDir .))* HtmlBlockCloseDir) */
+	p.rules[ruleHtmlBlockDir] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenDir]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockDir]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseDir]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseDir]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 43 HtmlBlockOpenDiv <- ('<' Spnl ((&[D] 'DIV') | (&[d] 'div')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenDiv] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++
+				if !p.matchString("IV") {
+					goto ko
+				}
+			case 'd':
+				p.position++
+				if !p.matchString("iv") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 44 HtmlBlockCloseDiv <- ('<' Spnl '/' ((&[D] 'DIV') | (&[d] 'div')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseDiv] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++
+				if !p.matchString("IV") {
+					goto ko
+				}
+			case 'd':
+				p.position++
+				if !p.matchString("iv") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 45 HtmlBlockDiv <- (HtmlBlockOpenDiv (HtmlBlockDiv / (!HtmlBlockCloseDiv .))* HtmlBlockCloseDiv) */
+	p.rules[ruleHtmlBlockDiv] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenDiv]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockDiv]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseDiv]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseDiv]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 46 HtmlBlockOpenDl <- ('<' Spnl ((&[D] 'DL') | (&[d] 'dl')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenDl] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++ // matchString(`DL`)
+				if !p.matchChar('L') {
+					goto ko
+				}
+			case 'd':
+				p.position++ // matchString(`dl`)
+				if !p.matchChar('l') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 47 HtmlBlockCloseDl <- ('<' Spnl '/' ((&[D] 'DL') | (&[d] 'dl')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseDl] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++ // matchString(`DL`)
+				if !p.matchChar('L') {
+					goto ko
+				}
+			case 'd':
+				p.position++ // matchString(`dl`)
+				if !p.matchChar('l') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 48 HtmlBlockDl <- (HtmlBlockOpenDl (HtmlBlockDl / (!HtmlBlockCloseDl .))* HtmlBlockCloseDl) */
+	p.rules[ruleHtmlBlockDl] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenDl]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockDl]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseDl]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseDl]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 49 HtmlBlockOpenFieldset <- ('<' Spnl ((&[F] 'FIELDSET') | (&[f] 'fieldset')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenFieldset] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'F':
+				p.position++
+				if !p.matchString("IELDSET") {
+					goto ko
+				}
+			case 'f':
+				p.position++
+				if !p.matchString("ieldset") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 50 HtmlBlockCloseFieldset <- ('<' Spnl '/' ((&[F] 'FIELDSET') | (&[f] 'fieldset')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseFieldset] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'F':
+				p.position++
+				if !p.matchString("IELDSET") {
+					goto ko
+				}
+			case 'f':
+				p.position++
+				if !p.matchString("ieldset") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 51 HtmlBlockFieldset <- (HtmlBlockOpenFieldset (HtmlBlockFieldset / (!HtmlBlockCloseFieldset .))* HtmlBlockCloseFieldset) */
+	p.rules[ruleHtmlBlockFieldset] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenFieldset]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockFieldset]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseFieldset]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseFieldset]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 52 HtmlBlockOpenForm <- ('<' Spnl ((&[F] 'FORM') | (&[f] 'form')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenForm] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'F':
+				p.position++
+				if !p.matchString("ORM") {
+					goto ko
+				}
+			case 'f':
+				p.position++
+				if !p.matchString("orm") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 53 HtmlBlockCloseForm <- ('<' Spnl '/' ((&[F] 'FORM') | (&[f] 'form')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseForm] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'F':
+				p.position++
+				if !p.matchString("ORM") {
+					goto ko
+				}
+			case 'f':
+				p.position++
+				if !p.matchString("orm") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 54 HtmlBlockForm <- (HtmlBlockOpenForm (HtmlBlockForm / (!HtmlBlockCloseForm .))* HtmlBlockCloseForm) */
+	p.rules[ruleHtmlBlockForm] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenForm]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockForm]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseForm]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseForm]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 55 HtmlBlockOpenH1 <- ('<' Spnl ((&[H] 'H1') | (&[h] 'h1')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenH1] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H1`)
+				if !p.matchChar('1') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h1`)
+				if !p.matchChar('1') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 56 HtmlBlockCloseH1 <- ('<' Spnl '/' ((&[H] 'H1') | (&[h] 'h1')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseH1] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H1`)
+				if !p.matchChar('1') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h1`)
+				if !p.matchChar('1') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 57 HtmlBlockH1 <- (HtmlBlockOpenH1 (HtmlBlockH1 / (!HtmlBlockCloseH1 .))* HtmlBlockCloseH1) */
+	p.rules[ruleHtmlBlockH1] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenH1]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockH1]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseH1]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseH1]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 58 HtmlBlockOpenH2 <- ('<' Spnl ((&[H] 'H2') | (&[h] 'h2')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenH2] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H2`)
+				if !p.matchChar('2') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h2`)
+				if !p.matchChar('2') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 59 HtmlBlockCloseH2 <- ('<' Spnl '/' ((&[H] 'H2') | (&[h] 'h2')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseH2] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H2`)
+				if !p.matchChar('2') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h2`)
+				if !p.matchChar('2') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 60 HtmlBlockH2 <- (HtmlBlockOpenH2 (HtmlBlockH2 / (!HtmlBlockCloseH2 .))* HtmlBlockCloseH2) */
+	p.rules[ruleHtmlBlockH2] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenH2]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockH2]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseH2]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseH2]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 61 HtmlBlockOpenH3 <- ('<' Spnl ((&[H] 'H3') | (&[h] 'h3')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenH3] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H3`)
+				if !p.matchChar('3') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h3`)
+				if !p.matchChar('3') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 62 HtmlBlockCloseH3 <- ('<' Spnl '/' ((&[H] 'H3') | (&[h] 'h3')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseH3] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H3`)
+				if !p.matchChar('3') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h3`)
+				if !p.matchChar('3') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 63 HtmlBlockH3 <- (HtmlBlockOpenH3 (HtmlBlockH3 / (!HtmlBlockCloseH3 .))* HtmlBlockCloseH3) */
+	p.rules[ruleHtmlBlockH3] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenH3]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockH3]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseH3]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseH3]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 64 HtmlBlockOpenH4 <- ('<' Spnl ((&[H] 'H4') | (&[h] 'h4')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenH4] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H4`)
+				if !p.matchChar('4') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h4`)
+				if !p.matchChar('4') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 65 HtmlBlockCloseH4 <- ('<' Spnl '/' ((&[H] 'H4') | (&[h] 'h4')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseH4] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H4`)
+				if !p.matchChar('4') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h4`)
+				if !p.matchChar('4') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 66 HtmlBlockH4 <- (HtmlBlockOpenH4 (HtmlBlockH4 / (!HtmlBlockCloseH4 .))* HtmlBlockCloseH4) */
+	p.rules[ruleHtmlBlockH4] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenH4]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockH4]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseH4]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseH4]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 67 HtmlBlockOpenH5 <- ('<' Spnl ((&[H] 'H5') | (&[h] 'h5')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenH5] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H5`)
+				if !p.matchChar('5') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h5`)
+				if !p.matchChar('5') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 68 HtmlBlockCloseH5 <- ('<' Spnl '/' ((&[H] 'H5') | (&[h] 'h5')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseH5] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H5`)
+				if !p.matchChar('5') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h5`)
+				if !p.matchChar('5') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 69 HtmlBlockH5 <- (HtmlBlockOpenH5 (HtmlBlockH5 / (!HtmlBlockCloseH5 .))* HtmlBlockCloseH5) */
+	p.rules[ruleHtmlBlockH5] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenH5]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockH5]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseH5]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseH5]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 70 HtmlBlockOpenH6 <- ('<' Spnl ((&[H] 'H6') | (&[h] 'h6')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenH6] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H6`)
+				if !p.matchChar('6') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h6`)
+				if !p.matchChar('6') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 71 HtmlBlockCloseH6 <- ('<' Spnl '/' ((&[H] 'H6') | (&[h] 'h6')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseH6] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++ // matchString(`H6`)
+				if !p.matchChar('6') {
+					goto ko
+				}
+			case 'h':
+				p.position++ // matchString(`h6`)
+				if !p.matchChar('6') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 72 HtmlBlockH6 <- (HtmlBlockOpenH6 (HtmlBlockH6 / (!HtmlBlockCloseH6 .))* HtmlBlockCloseH6) */
+	p.rules[ruleHtmlBlockH6] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenH6]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockH6]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseH6]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseH6]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 73 HtmlBlockOpenMenu <- ('<' Spnl ((&[M] 'MENU') | (&[m] 'menu')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenMenu] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'M':
+				p.position++
+				if !p.matchString("ENU") {
+					goto ko
+				}
+			case 'm':
+				p.position++
+				if !p.matchString("enu") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 74 HtmlBlockCloseMenu <- ('<' Spnl '/' ((&[M] 'MENU') | (&[m] 'menu')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseMenu] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'M':
+				p.position++
+				if !p.matchString("ENU") {
+					goto ko
+				}
+			case 'm':
+				p.position++
+				if !p.matchString("enu") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 75 HtmlBlockMenu <- (HtmlBlockOpenMenu (HtmlBlockMenu / (!HtmlBlockCloseMenu .))* HtmlBlockCloseMenu) */
+	p.rules[ruleHtmlBlockMenu] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenMenu]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockMenu]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseMenu]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseMenu]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 76 HtmlBlockOpenNoframes <- ('<' Spnl ((&[N] 'NOFRAMES') | (&[n] 'noframes')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenNoframes] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'N':
+				p.position++
+				if !p.matchString("OFRAMES") {
+					goto ko
+				}
+			case 'n':
+				p.position++
+				if !p.matchString("oframes") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 77 HtmlBlockCloseNoframes <- ('<' Spnl '/' ((&[N] 'NOFRAMES') | (&[n] 'noframes')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseNoframes] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'N':
+				p.position++
+				if !p.matchString("OFRAMES") {
+					goto ko
+				}
+			case 'n':
+				p.position++
+				if !p.matchString("oframes") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 78 HtmlBlockNoframes <- (HtmlBlockOpenNoframes (HtmlBlockNoframes / (!HtmlBlockCloseNoframes .))* HtmlBlockCloseNoframes) */
+	p.rules[ruleHtmlBlockNoframes] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenNoframes]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockNoframes]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseNoframes]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseNoframes]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 79 HtmlBlockOpenNoscript <- ('<' Spnl ((&[N] 'NOSCRIPT') | (&[n] 'noscript')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenNoscript] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'N':
+				p.position++
+				if !p.matchString("OSCRIPT") {
+					goto ko
+				}
+			case 'n':
+				p.position++
+				if !p.matchString("oscript") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 80 HtmlBlockCloseNoscript <- ('<' Spnl '/' ((&[N] 'NOSCRIPT') | (&[n] 'noscript')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseNoscript] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'N':
+				p.position++
+				if !p.matchString("OSCRIPT") {
+					goto ko
+				}
+			case 'n':
+				p.position++
+				if !p.matchString("oscript") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 81 HtmlBlockNoscript <- (HtmlBlockOpenNoscript (HtmlBlockNoscript / (!HtmlBlockCloseNoscript .))* HtmlBlockCloseNoscript) */
+	p.rules[ruleHtmlBlockNoscript] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenNoscript]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockNoscript]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseNoscript]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseNoscript]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 82 HtmlBlockOpenOl <- ('<' Spnl ((&[O] 'OL') | (&[o] 'ol')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenOl] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'O':
+				p.position++ // matchString(`OL`)
+				if !p.matchChar('L') {
+					goto ko
+				}
+			case 'o':
+				p.position++ // matchString(`ol`)
+				if !p.matchChar('l') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 83 HtmlBlockCloseOl <- ('<' Spnl '/' ((&[O] 'OL') | (&[o] 'ol')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseOl] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'O':
+				p.position++ // matchString(`OL`)
+				if !p.matchChar('L') {
+					goto ko
+				}
+			case 'o':
+				p.position++ // matchString(`ol`)
+				if !p.matchChar('l') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 84 HtmlBlockOl <- (HtmlBlockOpenOl (HtmlBlockOl / (!HtmlBlockCloseOl .))* HtmlBlockCloseOl) */
+	p.rules[ruleHtmlBlockOl] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenOl]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockOl]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseOl]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseOl]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 85 HtmlBlockOpenP <- ('<' Spnl ((&[P] 'P') | (&[p] 'p')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenP] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'P':
+				p.position++ // matchChar
+			case 'p':
+				p.position++ // matchChar
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 86 HtmlBlockCloseP <- ('<' Spnl '/' ((&[P] 'P') | (&[p] 'p')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseP] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'P':
+				p.position++ // matchChar
+			case 'p':
+				p.position++ // matchChar
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 87 HtmlBlockP <- (HtmlBlockOpenP (HtmlBlockP / (!HtmlBlockCloseP .))* HtmlBlockCloseP) */
+	p.rules[ruleHtmlBlockP] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenP]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockP]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseP]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseP]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 88 HtmlBlockOpenPre <- ('<' Spnl ((&[P] 'PRE') | (&[p] 'pre')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenPre] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'P':
+				p.position++
+				if !p.matchString("RE") {
+					goto ko
+				}
+			case 'p':
+				p.position++
+				if !p.matchString("re") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 89 HtmlBlockClosePre <- ('<' Spnl '/' ((&[P] 'PRE') | (&[p] 'pre')) Spnl '>') */
+	p.rules[ruleHtmlBlockClosePre] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'P':
+				p.position++
+				if !p.matchString("RE") {
+					goto ko
+				}
+			case 'p':
+				p.position++
+				if !p.matchString("re") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 90 HtmlBlockPre <- (HtmlBlockOpenPre (HtmlBlockPre / (!HtmlBlockClosePre .))* HtmlBlockClosePre) */
+	p.rules[ruleHtmlBlockPre] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenPre]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockPre]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockClosePre]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockClosePre]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 91 HtmlBlockOpenTable <- ('<' Spnl ((&[T] 'TABLE') | (&[t] 'table')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenTable] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++
+				if !p.matchString("ABLE") {
+					goto ko
+				}
+			case 't':
+				p.position++
+				if !p.matchString("able") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 92 HtmlBlockCloseTable <- ('<' Spnl '/' ((&[T] 'TABLE') | (&[t] 'table')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseTable] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++
+				if !p.matchString("ABLE") {
+					goto ko
+				}
+			case 't':
+				p.position++
+				if !p.matchString("able") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 93 HtmlBlockTable <- (HtmlBlockOpenTable (HtmlBlockTable / (!HtmlBlockCloseTable .))* HtmlBlockCloseTable) */
+	p.rules[ruleHtmlBlockTable] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenTable]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockTable]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseTable]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseTable]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 94 HtmlBlockOpenUl <- ('<' Spnl ((&[U] 'UL') | (&[u] 'ul')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenUl] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'U':
+				p.position++ // matchString(`UL`)
+				if !p.matchChar('L') {
+					goto ko
+				}
+			case 'u':
+				p.position++ // matchString(`ul`)
+				if !p.matchChar('l') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 95 HtmlBlockCloseUl <- ('<' Spnl '/' ((&[U] 'UL') | (&[u] 'ul')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseUl] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'U':
+				p.position++ // matchString(`UL`)
+				if !p.matchChar('L') {
+					goto ko
+				}
+			case 'u':
+				p.position++ // matchString(`ul`)
+				if !p.matchChar('l') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 96 HtmlBlockUl <- (HtmlBlockOpenUl (HtmlBlockUl / (!HtmlBlockCloseUl .))* HtmlBlockCloseUl) */
+	p.rules[ruleHtmlBlockUl] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenUl]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockUl]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseUl]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseUl]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 97 HtmlBlockOpenDd <- ('<' Spnl ((&[D] 'DD') | (&[d] 'dd')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenDd] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++ // matchString(`DD`)
+				if !p.matchChar('D') {
+					goto ko
+				}
+			case 'd':
+				p.position++ // matchString(`dd`)
+				if !p.matchChar('d') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 98 HtmlBlockCloseDd <- ('<' Spnl '/' ((&[D] 'DD') | (&[d] 'dd')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseDd] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++ // matchString(`DD`)
+				if !p.matchChar('D') {
+					goto ko
+				}
+			case 'd':
+				p.position++ // matchString(`dd`)
+				if !p.matchChar('d') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 99 HtmlBlockDd <- (HtmlBlockOpenDd (HtmlBlockDd / (!HtmlBlockCloseDd .))* HtmlBlockCloseDd) */
+	p.rules[ruleHtmlBlockDd] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenDd]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockDd]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseDd]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseDd]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 100 HtmlBlockOpenDt <- ('<' Spnl ((&[D] 'DT') | (&[d] 'dt')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenDt] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++ // matchString(`DT`)
+				if !p.matchChar('T') {
+					goto ko
+				}
+			case 'd':
+				p.position++ // matchString(`dt`)
+				if !p.matchChar('t') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 101 HtmlBlockCloseDt <- ('<' Spnl '/' ((&[D] 'DT') | (&[d] 'dt')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseDt] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'D':
+				p.position++ // matchString(`DT`)
+				if !p.matchChar('T') {
+					goto ko
+				}
+			case 'd':
+				p.position++ // matchString(`dt`)
+				if !p.matchChar('t') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 102 HtmlBlockDt <- (HtmlBlockOpenDt (HtmlBlockDt / (!HtmlBlockCloseDt .))* HtmlBlockCloseDt) */
+	p.rules[ruleHtmlBlockDt] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenDt]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockDt]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseDt]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseDt]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 103 HtmlBlockOpenFrameset <- ('<' Spnl ((&[F] 'FRAMESET') | (&[f] 'frameset')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenFrameset] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'F':
+				p.position++
+				if !p.matchString("RAMESET") {
+					goto ko
+				}
+			case 'f':
+				p.position++
+				if !p.matchString("rameset") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 104 HtmlBlockCloseFrameset <- ('<' Spnl '/' ((&[F] 'FRAMESET') | (&[f] 'frameset')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseFrameset] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'F':
+				p.position++
+				if !p.matchString("RAMESET") {
+					goto ko
+				}
+			case 'f':
+				p.position++
+				if !p.matchString("rameset") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 105 HtmlBlockFrameset <- (HtmlBlockOpenFrameset (HtmlBlockFrameset / (!HtmlBlockCloseFrameset .))* HtmlBlockCloseFrameset) */
+	p.rules[ruleHtmlBlockFrameset] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenFrameset]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockFrameset]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseFrameset]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseFrameset]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 106 HtmlBlockOpenLi <- ('<' Spnl ((&[L] 'LI') | (&[l] 'li')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenLi] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'L':
+				p.position++ // matchString(`LI`)
+				if !p.matchChar('I') {
+					goto ko
+				}
+			case 'l':
+				p.position++ // matchString(`li`)
+				if !p.matchChar('i') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 107 HtmlBlockCloseLi <- ('<' Spnl '/' ((&[L] 'LI') | (&[l] 'li')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseLi] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'L':
+				p.position++ // matchString(`LI`)
+				if !p.matchChar('I') {
+					goto ko
+				}
+			case 'l':
+				p.position++ // matchString(`li`)
+				if !p.matchChar('i') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 108 HtmlBlockLi <- (HtmlBlockOpenLi (HtmlBlockLi / (!HtmlBlockCloseLi .))* HtmlBlockCloseLi) */
+	p.rules[ruleHtmlBlockLi] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenLi]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockLi]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseLi]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseLi]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 109 HtmlBlockOpenTbody <- ('<' Spnl ((&[T] 'TBODY') | (&[t] 'tbody')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenTbody] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++
+				if !p.matchString("BODY") {
+					goto ko
+				}
+			case 't':
+				p.position++
+				if !p.matchString("body") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 110 HtmlBlockCloseTbody <- ('<' Spnl '/' ((&[T] 'TBODY') | (&[t] 'tbody')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseTbody] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++
+				if !p.matchString("BODY") {
+					goto ko
+				}
+			case 't':
+				p.position++
+				if !p.matchString("body") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 111 HtmlBlockTbody <- (HtmlBlockOpenTbody (HtmlBlockTbody / (!HtmlBlockCloseTbody .))* HtmlBlockCloseTbody) */
+	p.rules[ruleHtmlBlockTbody] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenTbody]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockTbody]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseTbody]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseTbody]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 112 HtmlBlockOpenTd <- ('<' Spnl ((&[T] 'TD') | (&[t] 'td')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenTd] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++ // matchString(`TD`)
+				if !p.matchChar('D') {
+					goto ko
+				}
+			case 't':
+				p.position++ // matchString(`td`)
+				if !p.matchChar('d') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 113 HtmlBlockCloseTd <- ('<' Spnl '/' ((&[T] 'TD') | (&[t] 'td')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseTd] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++ // matchString(`TD`)
+				if !p.matchChar('D') {
+					goto ko
+				}
+			case 't':
+				p.position++ // matchString(`td`)
+				if !p.matchChar('d') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 114 HtmlBlockTd <- (HtmlBlockOpenTd (HtmlBlockTd / (!HtmlBlockCloseTd .))* HtmlBlockCloseTd) */
+	p.rules[ruleHtmlBlockTd] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenTd]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockTd]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseTd]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseTd]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 115 HtmlBlockOpenTfoot <- ('<' Spnl ((&[T] 'TFOOT') | (&[t] 'tfoot')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenTfoot] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++
+				if !p.matchString("FOOT") {
+					goto ko
+				}
+			case 't':
+				p.position++
+				if !p.matchString("foot") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 116 HtmlBlockCloseTfoot <- ('<' Spnl '/' ((&[T] 'TFOOT') | (&[t] 'tfoot')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseTfoot] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++
+				if !p.matchString("FOOT") {
+					goto ko
+				}
+			case 't':
+				p.position++
+				if !p.matchString("foot") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 117 HtmlBlockTfoot <- (HtmlBlockOpenTfoot (HtmlBlockTfoot / (!HtmlBlockCloseTfoot .))* HtmlBlockCloseTfoot) */
+	p.rules[ruleHtmlBlockTfoot] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenTfoot]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockTfoot]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseTfoot]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseTfoot]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 118 HtmlBlockOpenTh <- ('<' Spnl ((&[T] 'TH') | (&[t] 'th')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenTh] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++ // matchString(`TH`)
+				if !p.matchChar('H') {
+					goto ko
+				}
+			case 't':
+				p.position++ // matchString(`th`)
+				if !p.matchChar('h') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 119 HtmlBlockCloseTh <- ('<' Spnl '/' ((&[T] 'TH') | (&[t] 'th')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseTh] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++ // matchString(`TH`)
+				if !p.matchChar('H') {
+					goto ko
+				}
+			case 't':
+				p.position++ // matchString(`th`)
+				if !p.matchChar('h') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 120 HtmlBlockTh <- (HtmlBlockOpenTh (HtmlBlockTh / (!HtmlBlockCloseTh .))* HtmlBlockCloseTh) */
+	p.rules[ruleHtmlBlockTh] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenTh]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockTh]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseTh]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseTh]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 121 HtmlBlockOpenThead <- ('<' Spnl ((&[T] 'THEAD') | (&[t] 'thead')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenThead] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++
+				if !p.matchString("HEAD") {
+					goto ko
+				}
+			case 't':
+				p.position++
+				if !p.matchString("head") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 122 HtmlBlockCloseThead <- ('<' Spnl '/' ((&[T] 'THEAD') | (&[t] 'thead')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseThead] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++
+				if !p.matchString("HEAD") {
+					goto ko
+				}
+			case 't':
+				p.position++
+				if !p.matchString("head") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 123 HtmlBlockThead <- (HtmlBlockOpenThead (HtmlBlockThead / (!HtmlBlockCloseThead .))* HtmlBlockCloseThead) */
+	p.rules[ruleHtmlBlockThead] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenThead]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockThead]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseThead]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseThead]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 124 HtmlBlockOpenTr <- ('<' Spnl ((&[T] 'TR') | (&[t] 'tr')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenTr] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++ // matchString(`TR`)
+				if !p.matchChar('R') {
+					goto ko
+				}
+			case 't':
+				p.position++ // matchString(`tr`)
+				if !p.matchChar('r') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 125 HtmlBlockCloseTr <- ('<' Spnl '/' ((&[T] 'TR') | (&[t] 'tr')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseTr] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'T':
+				p.position++ // matchString(`TR`)
+				if !p.matchChar('R') {
+					goto ko
+				}
+			case 't':
+				p.position++ // matchString(`tr`)
+				if !p.matchChar('r') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 126 HtmlBlockTr <- (HtmlBlockOpenTr (HtmlBlockTr / (!HtmlBlockCloseTr .))* HtmlBlockCloseTr) */
+	p.rules[ruleHtmlBlockTr] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenTr]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockTr]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			if !p.rules[ruleHtmlBlockCloseTr]() {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.matchDot() {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseTr]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 127 HtmlBlockOpenScript <- ('<' Spnl ((&[S] 'SCRIPT') | (&[s] 'script')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenScript] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'S':
+				p.position++
+				if !p.matchString("CRIPT") {
+					goto ko
+				}
+			case 's':
+				p.position++
+				if !p.matchString("cript") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 128 HtmlBlockCloseScript <- ('<' Spnl '/' ((&[S] 'SCRIPT') | (&[s] 'script')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseScript] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'S':
+				p.position++
+				if !p.matchString("CRIPT") {
+					goto ko
+				}
+			case 's':
+				p.position++
+				if !p.matchString("cript") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 129 HtmlBlockScript <- (HtmlBlockOpenScript (!HtmlBlockCloseScript .)* HtmlBlockCloseScript) */
+	p.rules[ruleHtmlBlockScript] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenScript]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockCloseScript]() {
+				goto ok
+			}
+			goto out
+		ok:
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseScript]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 130 HtmlBlockOpenHead <- ('<' Spnl ((&[H] 'HEAD') | (&[h] 'head')) Spnl HtmlAttribute* '>') */
+	p.rules[ruleHtmlBlockOpenHead] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++
+				if !p.matchString("EAD") {
+					goto ko
+				}
+			case 'h':
+				p.position++
+				if !p.matchString("ead") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 131 HtmlBlockCloseHead <- ('<' Spnl '/' ((&[H] 'HEAD') | (&[h] 'head')) Spnl '>') */
+	p.rules[ruleHtmlBlockCloseHead] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case 'H':
+				p.position++
+				if !p.matchString("EAD") {
+					goto ko
+				}
+			case 'h':
+				p.position++
+				if !p.matchString("ead") {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 132 HtmlBlockHead <- (HtmlBlockOpenHead (!HtmlBlockCloseHead .)* HtmlBlockCloseHead) */
+	p.rules[ruleHtmlBlockHead] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHtmlBlockOpenHead]() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleHtmlBlockCloseHead]() {
+				goto ok
+			}
+			goto out
+		ok:
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.rules[ruleHtmlBlockCloseHead]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 133 HtmlBlockInTags <- (HtmlBlockAddress / HtmlBlockBlockquote / HtmlBlockCenter / HtmlBlockDir / HtmlBlockDiv / HtmlBlockDl / HtmlBlockFieldset / HtmlBlockForm / HtmlBlockH1 / HtmlBlockH2 / HtmlBlockH3 / HtmlBlockH4 / HtmlBlockH5 / HtmlBlockH6 / HtmlBlockMenu / HtmlBlockNoframes / HtmlBlockNoscript / HtmlBlockOl / HtmlBlockP / HtmlBlockPre / HtmlBlockTable / HtmlBlockUl / HtmlBlockDd / HtmlBlockDt / HtmlBlockFrameset / HtmlBlockLi / HtmlBlockTbody / HtmlBlockTd / HtmlBlockTfoot / HtmlBlockTh / HtmlBlockThead / HtmlBlockTr / HtmlBlockScript / HtmlBlockHead) */
+	p.rules[ruleHtmlBlockInTags] = func() (match bool) {
+		if !p.rules[ruleHtmlBlockAddress]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleHtmlBlockBlockquote]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleHtmlBlockCenter]() {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+		if !p.rules[ruleHtmlBlockDir]() {
+			goto nextAlt5
+		}
+		goto ok
+	nextAlt5:
+		if !p.rules[ruleHtmlBlockDiv]() {
+			goto nextAlt6
+		}
+		goto ok
+	nextAlt6:
+		if !p.rules[ruleHtmlBlockDl]() {
+			goto nextAlt7
+		}
+		goto ok
+	nextAlt7:
+		if !p.rules[ruleHtmlBlockFieldset]() {
+			goto nextAlt8
+		}
+		goto ok
+	nextAlt8:
+		if !p.rules[ruleHtmlBlockForm]() {
+			goto nextAlt9
+		}
+		goto ok
+	nextAlt9:
+		if !p.rules[ruleHtmlBlockH1]() {
+			goto nextAlt10
+		}
+		goto ok
+	nextAlt10:
+		if !p.rules[ruleHtmlBlockH2]() {
+			goto nextAlt11
+		}
+		goto ok
+	nextAlt11:
+		if !p.rules[ruleHtmlBlockH3]() {
+			goto nextAlt12
+		}
+		goto ok
+	nextAlt12:
+		if !p.rules[ruleHtmlBlockH4]() {
+			goto nextAlt13
+		}
+		goto ok
+	nextAlt13:
+		if !p.rules[ruleHtmlBlockH5]() {
+			goto nextAlt14
+		}
+		goto ok
+	nextAlt14:
+		if !p.rules[ruleHtmlBlockH6]() {
+			goto nextAlt15
+		}
+		goto ok
+	nextAlt15:
+		if !p.rules[ruleHtmlBlockMenu]() {
+			goto nextAlt16
+		}
+		goto ok
+	nextAlt16:
+		if !p.rules[ruleHtmlBlockNoframes]() {
+			goto nextAlt17
+		}
+		goto ok
+	nextAlt17:
+		if !p.rules[ruleHtmlBlockNoscript]() {
+			goto nextAlt18
+		}
+		goto ok
+	nextAlt18:
+		if !p.rules[ruleHtmlBlockOl]() {
+			goto nextAlt19
+		}
+		goto ok
+	nextAlt19:
+		if !p.rules[ruleHtmlBlockP]() {
+			goto nextAlt20
+		}
+		goto ok
+	nextAlt20:
+		if !p.rules[ruleHtmlBlockPre]() {
+			goto nextAlt21
+		}
+		goto ok
+	nextAlt21:
+		if !p.rules[ruleHtmlBlockTable]() {
+			goto nextAlt22
+		}
+		goto ok
+	nextAlt22:
+		if !p.rules[ruleHtmlBlockUl]() {
+			goto nextAlt23
+		}
+		goto ok
+	nextAlt23:
+		if !p.rules[ruleHtmlBlockDd]() {
+			goto nextAlt24
+		}
+		goto ok
+	nextAlt24:
+		if !p.rules[ruleHtmlBlockDt]() {
+			goto nextAlt25
+		}
+		goto ok
+	nextAlt25:
+		if !p.rules[ruleHtmlBlockFrameset]() {
+			goto nextAlt26
+		}
+		goto ok
+	nextAlt26:
+		if !p.rules[ruleHtmlBlockLi]() {
+			goto nextAlt27
+		}
+		goto ok
+	nextAlt27:
+		if !p.rules[ruleHtmlBlockTbody]() {
+			goto nextAlt28
+		}
+		goto ok
+	nextAlt28:
+		if !p.rules[ruleHtmlBlockTd]() {
+			goto nextAlt29
+		}
+		goto ok
+	nextAlt29:
+		if !p.rules[ruleHtmlBlockTfoot]() {
+			goto nextAlt30
+		}
+		goto ok
+	nextAlt30:
+		if !p.rules[ruleHtmlBlockTh]() {
+			goto nextAlt31
+		}
+		goto ok
+	nextAlt31:
+		if !p.rules[ruleHtmlBlockThead]() {
+			goto nextAlt32
+		}
+		goto ok
+	nextAlt32:
+		if !p.rules[ruleHtmlBlockTr]() {
+			goto nextAlt33
+		}
+		goto ok
+	nextAlt33:
+		if !p.rules[ruleHtmlBlockScript]() {
+			goto nextAlt34
+		}
+		goto ok
+	nextAlt34:
+		if !p.rules[ruleHtmlBlockHead]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 134 HtmlBlock <- (&'<' < (HtmlBlockInTags / HtmlComment / HtmlBlockSelfClosing) > BlankLine+ { yy = p.rawHTML(yytext, HTMLBLOCK) }) */
+	p.rules[ruleHtmlBlock] = func() (match bool) {
+		position0 := p.position
+		if !p.peekChar('<') {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.rules[ruleHtmlBlockInTags]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleHtmlComment]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleHtmlBlockSelfClosing]() {
+			goto ko
+		}
+	ok:
+		p.end = p.position
+		if !p.rules[ruleBlankLine]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleBlankLine]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.do(41)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 135 HtmlBlockSelfClosing <- ('<' Spnl HtmlBlockType Spnl HtmlAttribute* '/' Spnl '>') */
+	p.rules[ruleHtmlBlockSelfClosing] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.rules[ruleHtmlBlockType]() {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('/') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 136 HtmlBlockType <- ('dir' / 'div' / 'dl' / 'fieldset' / 'form' / 'h1' / 'h2' / 'h3' / 'h4' / 'h5' / 'h6' / 'noframes' / 'p' / 'table' / 'dd' / 'tbody' / 'td' / 'tfoot' / 'th' / 'thead' / 'DIR' / 'DIV' / 'DL' / 'FIELDSET' / 'FORM' / 'H1' / 'H2' / 'H3' / 'H4' / 'H5' / 'H6' / 'NOFRAMES' / 'P' / 'TABLE' / 'DD' / 'TBODY' / 'TD' / 'TFOOT' / 'TH' / 'THEAD' / ((&[S] 'SCRIPT') | (&[T] 'TR') | (&[L] 'LI') | (&[F] 'FRAMESET') | (&[D] 'DT') | (&[U] 'UL') | (&[P] 'PRE') | (&[O] 'OL') | (&[N] 'NOSCRIPT') | (&[M] 'MENU') | (&[I] 'ISINDEX') | (&[H] 'HR') | (&[C] 'CENTER') | (&[B] 'BLOCKQUOTE') | (&[A] 'ADDRESS') | (&[s] 'script') | (&[t] 'tr') | (&[l] 'li') | (&[f] 'frameset') | (&[d] 'dt') | (&[u] 'ul') | (&[p] 'pre') | (&[o] 'ol') | (&[n] 'noscript') | (&[m] 'menu') | (&[i] 'isindex') | (&[h] 'hr') | (&[c] 'center') | (&[b] 'blockquote') | (&[a] 'address'))) */
+	p.rules[ruleHtmlBlockType] = func() (match bool) {
+		if !p.matchString("dir") {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchString("div") {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.matchString("dl") {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+		if !p.matchString("fieldset") {
+			goto nextAlt5
+		}
+		goto ok
+	nextAlt5:
+		if !p.matchString("form") {
+			goto nextAlt6
+		}
+		goto ok
+	nextAlt6:
+		if !p.matchString("h1") {
+			goto nextAlt7
+		}
+		goto ok
+	nextAlt7:
+		if !p.matchString("h2") {
+			goto nextAlt8
+		}
+		goto ok
+	nextAlt8:
+		if !p.matchString("h3") {
+			goto nextAlt9
+		}
+		goto ok
+	nextAlt9:
+		if !p.matchString("h4") {
+			goto nextAlt10
+		}
+		goto ok
+	nextAlt10:
+		if !p.matchString("h5") {
+			goto nextAlt11
+		}
+		goto ok
+	nextAlt11:
+		if !p.matchString("h6") {
+			goto nextAlt12
+		}
+		goto ok
+	nextAlt12:
+		if !p.matchString("noframes") {
+			goto nextAlt13
+		}
+		goto ok
+	nextAlt13:
+		if !p.matchChar('p') {
+			goto nextAlt14
+		}
+		goto ok
+	nextAlt14:
+		if !p.matchString("table") {
+			goto nextAlt15
+		}
+		goto ok
+	nextAlt15:
+		if !p.matchString("dd") {
+			goto nextAlt16
+		}
+		goto ok
+	nextAlt16:
+		if !p.matchString("tbody") {
+			goto nextAlt17
+		}
+		goto ok
+	nextAlt17:
+		if !p.matchString("td") {
+			goto nextAlt18
+		}
+		goto ok
+	nextAlt18:
+		if !p.matchString("tfoot") {
+			goto nextAlt19
+		}
+		goto ok
+	nextAlt19:
+		if !p.matchString("th") {
+			goto nextAlt20
+		}
+		goto ok
+	nextAlt20:
+		if !p.matchString("thead") {
+			goto nextAlt21
+		}
+		goto ok
+	nextAlt21:
+		if !p.matchString("DIR") {
+			goto nextAlt22
+		}
+		goto ok
+	nextAlt22:
+		if !p.matchString("DIV") {
+			goto nextAlt23
+		}
+		goto ok
+	nextAlt23:
+		if !p.matchString("DL") {
+			goto nextAlt24
+		}
+		goto ok
+	nextAlt24:
+		if !p.matchString("FIELDSET") {
+			goto nextAlt25
+		}
+		goto ok
+	nextAlt25:
+		if !p.matchString("FORM") {
+			goto nextAlt26
+		}
+		goto ok
+	nextAlt26:
+		if !p.matchString("H1") {
+			goto nextAlt27
+		}
+		goto ok
+	nextAlt27:
+		if !p.matchString("H2") {
+			goto nextAlt28
+		}
+		goto ok
+	nextAlt28:
+		if !p.matchString("H3") {
+			goto nextAlt29
+		}
+		goto ok
+	nextAlt29:
+		if !p.matchString("H4") {
+			goto nextAlt30
+		}
+		goto ok
+	nextAlt30:
+		if !p.matchString("H5") {
+			goto nextAlt31
+		}
+		goto ok
+	nextAlt31:
+		if !p.matchString("H6") {
+			goto nextAlt32
+		}
+		goto ok
+	nextAlt32:
+		if !p.matchString("NOFRAMES") {
+			goto nextAlt33
+		}
+		goto ok
+	nextAlt33:
+		if !p.matchChar('P') {
+			goto nextAlt34
+		}
+		goto ok
+	nextAlt34:
+		if !p.matchString("TABLE") {
+			goto nextAlt35
+		}
+		goto ok
+	nextAlt35:
+		if !p.matchString("DD") {
+			goto nextAlt36
+		}
+		goto ok
+	nextAlt36:
+		if !p.matchString("TBODY") {
+			goto nextAlt37
+		}
+		goto ok
+	nextAlt37:
+		if !p.matchString("TD") {
+			goto nextAlt38
+		}
+		goto ok
+	nextAlt38:
+		if !p.matchString("TFOOT") {
+			goto nextAlt39
+		}
+		goto ok
+	nextAlt39:
+		if !p.matchString("TH") {
+			goto nextAlt40
+		}
+		goto ok
+	nextAlt40:
+		if !p.matchString("THEAD") {
+			goto nextAlt41
+		}
+		goto ok
+	nextAlt41:
+		{
+			if p.position == len(p.Buffer) {
+				return
+			}
+			switch p.Buffer[p.position] {
+			case 'S':
+				p.position++
+				if !p.matchString("CRIPT") {
+					return
+				}
+			case 'T':
+				p.position++ // matchString(`TR`)
+				if !p.matchChar('R') {
+					return
+				}
+			case 'L':
+				p.position++ // matchString(`LI`)
+				if !p.matchChar('I') {
+					return
+				}
+			case 'F':
+				p.position++
+				if !p.matchString("RAMESET") {
+					return
+				}
+			case 'D':
+				p.position++ // matchString(`DT`)
+				if !p.matchChar('T') {
+					return
+				}
+			case 'U':
+				p.position++ // matchString(`UL`)
+				if !p.matchChar('L') {
+					return
+				}
+			case 'P':
+				p.position++
+				if !p.matchString("RE") {
+					return
+				}
+			case 'O':
+				p.position++ // matchString(`OL`)
+				if !p.matchChar('L') {
+					return
+				}
+			case 'N':
+				p.position++
+				if !p.matchString("OSCRIPT") {
+					return
+				}
+			case 'M':
+				p.position++
+				if !p.matchString("ENU") {
+					return
+				}
+			case 'I':
+				p.position++
+				if !p.matchString("SINDEX") {
+					return
+				}
+			case 'H':
+				p.position++ // matchString(`HR`)
+				if !p.matchChar('R') {
+					return
+				}
+			case 'C':
+				p.position++
+				if !p.matchString("ENTER") {
+					return
+				}
+			case 'B':
+				p.position++
+				if !p.matchString("LOCKQUOTE") {
+					return
+				}
+			case 'A':
+				p.position++
+				if !p.matchString("DDRESS") {
+					return
+				}
+			case 's':
+				p.position++
+				if !p.matchString("cript") {
+					return
+				}
+			case 't':
+				p.position++ // matchString(`tr`)
+				if !p.matchChar('r') {
+					return
+				}
+			case 'l':
+				p.position++ // matchString(`li`)
+				if !p.matchChar('i') {
+					return
+				}
+			case 'f':
+				p.position++
+				if !p.matchString("rameset") {
+					return
+				}
+			case 'd':
+				p.position++ // matchString(`dt`)
+				if !p.matchChar('t') {
+					return
+				}
+			case 'u':
+				p.position++ // matchString(`ul`)
+				if !p.matchChar('l') {
+					return
+				}
+			case 'p':
+				p.position++
+				if !p.matchString("re") {
+					return
+				}
+			case 'o':
+				p.position++ // matchString(`ol`)
+				if !p.matchChar('l') {
+					return
+				}
+			case 'n':
+				p.position++
+				if !p.matchString("oscript") {
+					return
+				}
+			case 'm':
+				p.position++
+				if !p.matchString("enu") {
+					return
+				}
+			case 'i':
+				p.position++
+				if !p.matchString("sindex") {
+					return
+				}
+			case 'h':
+				p.position++ // matchString(`hr`)
+				if !p.matchChar('r') {
+					return
+				}
+			case 'c':
+				p.position++
+				if !p.matchString("enter") {
+					return
+				}
+			case 'b':
+				p.position++
+				if !p.matchString("lockquote") {
+					return
+				}
+			case 'a':
+				p.position++
+				if !p.matchString("ddress") {
+					return
+				}
+			default:
+				return
+			}
+		}
+	ok:
+		match = true
+		return
+	}
+}
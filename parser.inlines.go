@@ -0,0 +1,4512 @@
+/* Rules of the grammar in parser.leg matching inline elements.
+ * Generated by leg, and split off parser.leg.go by gen.go.
+ */
+
+package markdown
+
+func (p *yyParser) inlineRules() {
+	/* 6 AtxStart <- (&'#' < ('######' / '#####' / '####' / '###' / '##' / '#') > { yy = p.mkElem(H1 + (len(yytext) - 1)) }) */
+	p.rules[ruleAtxStart] = func() (match bool) {
+		position0 := p.position
+		if !p.peekChar('#') {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.matchString("######") {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchString("#####") {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.matchString("####") {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+		if !p.matchString("###") {
+			goto nextAlt5
+		}
+		goto ok
+	nextAlt5:
+		if !p.matchString("##") {
+			goto nextAlt6
+		}
+		goto ok
+	nextAlt6:
+		if !p.matchChar('#') {
+			goto ko
+		}
+	ok:
+		p.end = p.position
+		p.do(5)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 142 Inline <- (RawText / SmartSymbol / IndexTerm / Tag / Str / Endline / UlOrStarLine / Space / Strong / Emph / Strike / Spoiler / Image / Link / NoteReference / InlineNote / Code / RawHtml / Entity / EscapedChar / Smart / Symbol) */
+	p.rules[ruleInline] = func() (match bool) {
+		if !p.rules[ruleRawText]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleSmartSymbol]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleIndexTerm]() {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+		if !p.rules[ruleTag]() {
+			goto nextAlt5
+		}
+		goto ok
+	nextAlt5:
+		if !p.rules[ruleStr]() {
+			goto nextAlt6
+		}
+		goto ok
+	nextAlt6:
+		if !p.rules[ruleEndline]() {
+			goto nextAlt7
+		}
+		goto ok
+	nextAlt7:
+		if !p.rules[ruleUlOrStarLine]() {
+			goto nextAlt8
+		}
+		goto ok
+	nextAlt8:
+		if !p.rules[ruleSpace]() {
+			goto nextAlt9
+		}
+		goto ok
+	nextAlt9:
+		if !p.rules[ruleStrong]() {
+			goto nextAlt10
+		}
+		goto ok
+	nextAlt10:
+		if !p.rules[ruleEmph]() {
+			goto nextAlt11
+		}
+		goto ok
+	nextAlt11:
+		if !p.rules[ruleStrike]() {
+			goto nextAlt12
+		}
+		goto ok
+	nextAlt12:
+		if !p.rules[ruleSpoiler]() {
+			goto nextAlt13
+		}
+		goto ok
+	nextAlt13:
+		if !p.rules[ruleImage]() {
+			goto nextAlt14
+		}
+		goto ok
+	nextAlt14:
+		if !p.rules[ruleLink]() {
+			goto nextAlt15
+		}
+		goto ok
+	nextAlt15:
+		if !p.rules[ruleNoteReference]() {
+			goto nextAlt16
+		}
+		goto ok
+	nextAlt16:
+		if !p.rules[ruleInlineNote]() {
+			goto nextAlt17
+		}
+		goto ok
+	nextAlt17:
+		if !p.rules[ruleCode]() {
+			goto nextAlt18
+		}
+		goto ok
+	nextAlt18:
+		if !p.rules[ruleRawHtml]() {
+			goto nextAlt19
+		}
+		goto ok
+	nextAlt19:
+		if !p.rules[ruleEntity]() {
+			goto nextAlt20
+		}
+		goto ok
+	nextAlt20:
+		if !p.rules[ruleEscapedChar]() {
+			goto nextAlt21
+		}
+		goto ok
+	nextAlt21:
+		if !p.rules[ruleSmart]() {
+			goto nextAlt22
+		}
+		goto ok
+	nextAlt22:
+		if !p.rules[ruleSymbol]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 143 Space <- (Spacechar+ { yy = p.mkString(" ")
+	   yy.key = SPACE }) */
+	p.rules[ruleSpace] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleSpacechar]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleSpacechar]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.do(46)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 144 Str <- (StartList < NormalChar+ > { a = cons(p.mkString(yytext), a) } (StrChunk { a = cons(yy, a) })* { if a.next == nil { yy = a; } else { yy = p.mkList(LIST, a) } }) */
+	p.rules[ruleStr] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		p.begin = p.position
+		if !p.rules[ruleNormalChar]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleNormalChar]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		p.do(47)
+	loop3:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleStrChunk]() {
+				goto out4
+			}
+			p.do(48)
+			goto loop3
+		out4:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(49)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 145 StrChunk <- ((< (NormalChar / ('_'+ &Alphanumeric) / IntrawordStars)+ > { yy = p.mkString(yytext) }) / AposChunk) */
+	p.rules[ruleStrChunk] = func() (match bool) {
+		position0 := p.position
+		{
+			position1 := p.position
+			p.begin = p.position
+			if !p.rules[ruleNormalChar]() {
+				goto nextAlt6
+			}
+			goto ok5
+		nextAlt6:
+			if !p.matchChar('_') {
+				goto nextAlt7
+			}
+		loop8:
+			if !p.matchChar('_') {
+				goto out9
+			}
+			goto loop8
+		out9:
+			{
+				position2 := p.position
+				if !p.rules[ruleAlphanumeric]() {
+					goto nextAlt7
+				}
+				p.position = position2
+			}
+			goto ok5
+		nextAlt7:
+			p.position = position1
+			if !p.rules[ruleIntrawordStars]() {
+				goto nextAlt
+			}
+		ok5:
+		loop:
+			{
+				position2 := p.position
+				if !p.rules[ruleNormalChar]() {
+					goto nextAlt11
+				}
+				goto ok10
+			nextAlt11:
+				if !p.matchChar('_') {
+					goto nextAlt14
+				}
+			loop12:
+				if !p.matchChar('_') {
+					goto out13
+				}
+				goto loop12
+			out13:
+				{
+					position4 := p.position
+					if !p.rules[ruleAlphanumeric]() {
+						goto nextAlt14
+					}
+					p.position = position4
+				}
+				goto ok10
+			nextAlt14:
+				p.position = position2
+				if !p.rules[ruleIntrawordStars]() {
+					goto out
+				}
+			ok10:
+				goto loop
+			out:
+				p.position = position2
+			}
+			p.end = p.position
+			p.do(50)
+			goto ok
+		nextAlt:
+			p.position = position1
+			if !p.rules[ruleAposChunk]() {
+				goto ko
+			}
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 146 AposChunk <- (&{p.extension.Smart} '\'' &Alphanumeric { yy = p.mkElem(APOSTROPHE) }) */
+	p.rules[ruleAposChunk] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.Smart) {
+			goto ko
+		}
+		if !p.matchChar('\'') {
+			goto ko
+		}
+		{
+			position1 := p.position
+			if !p.rules[ruleAlphanumeric]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		p.do(51)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 147 EscapedChar <- ('\\' !Newline < ([-\\`|*_{}[\]()#+.!><] / (&{p.extension.AllEscapes} [!-/:-@[-`{-~])) > { yy = p.mkString(yytext) }) */
+	p.rules[ruleEscapedChar] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('\\') {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		p.begin = p.position
+		if !p.matchClass(1) {
+			goto nextAlt
+		}
+		goto ok3
+	nextAlt:
+		if !(p.extension.AllEscapes) {
+			goto ko
+		}
+		if !p.matchClass(15) {
+			goto ko
+		}
+	ok3:
+		p.end = p.position
+		p.do(52)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 148 Entity <- ((HexEntity / DecEntity / CharEntity) { yy = p.mkString(p.numericEntity(yytext)); yy.key = HTML }) */
+	p.rules[ruleEntity] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleHexEntity]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleDecEntity]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleCharEntity]() {
+			goto ko
+		}
+	ok:
+		p.do(53)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 149 Endline <- (LineBreak / TerminalEndline / NormalEndline) */
+	p.rules[ruleEndline] = func() (match bool) {
+		if !p.rules[ruleLineBreak]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleTerminalEndline]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleNormalEndline]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 150 NormalEndline <- (Sp Newline !BlankLine !'>' !AtxStart !(Line ((&[\-] '-'+) | (&[=] '='+)) Newline) { yy = p.mkString("\n")
+	   yy.key = SPACE }) */
+	p.rules[ruleNormalEndline] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		if !p.rules[ruleBlankLine]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if p.peekChar('>') {
+			goto ko
+		}
+		if !p.rules[ruleAtxStart]() {
+			goto ok2
+		}
+		goto ko
+	ok2:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleLine]() {
+				goto ok3
+			}
+			{
+				if p.position == len(p.Buffer) {
+					goto ok3
+				}
+				switch p.Buffer[p.position] {
+				case '-':
+					if !p.matchChar('-') {
+						goto ok3
+					}
+				loop:
+					if !p.matchChar('-') {
+						goto out
+					}
+					goto loop
+				out:
+					break
+				case '=':
+					if !p.matchChar('=') {
+						goto ok3
+					}
+				loop7:
+					if !p.matchChar('=') {
+						goto out8
+					}
+					goto loop7
+				out8:
+					break
+				default:
+					goto ok3
+				}
+			}
+			if !p.rules[ruleNewline]() {
+				goto ok3
+			}
+			goto ko
+		ok3:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		p.do(54)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 151 TerminalEndline <- (Sp Newline !. { yy = nil }) */
+	p.rules[ruleTerminalEndline] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		if p.position < len(p.Buffer) {
+			goto ko
+		}
+		p.do(55)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 152 LineBreak <- ('  ' NormalEndline { yy = p.mkElem(LINEBREAK) }) */
+	p.rules[ruleLineBreak] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !p.matchString("  ") {
+			goto ko
+		}
+		if !p.rules[ruleNormalEndline]() {
+			goto ko
+		}
+		p.do(56)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 153 Symbol <- (< SpecialChar > { yy = p.mkString(yytext) }) */
+	p.rules[ruleSymbol] = func() (match bool) {
+		position0 := p.position
+		p.begin = p.position
+		if !p.rules[ruleSpecialChar]() {
+			goto ko
+		}
+		p.end = p.position
+		p.do(57)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 154 UlOrStarLine <- ((UlLine / StarLine) { yy = p.mkString(yytext) }) */
+	p.rules[ruleUlOrStarLine] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleUlLine]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleStarLine]() {
+			goto ko
+		}
+	ok:
+		p.do(58)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 155 StarLine <- ((&[*] (< '****' '*'* >)) | (&[\t ] (< Spacechar '*'+ &Spacechar >))) */
+	p.rules[ruleStarLine] = func() (match bool) {
+		position0 := p.position
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '*':
+				p.begin = p.position
+				if !p.matchString("****") {
+					goto ko
+				}
+			loop:
+				if !p.matchChar('*') {
+					goto out
+				}
+				goto loop
+			out:
+				p.end = p.position
+			case '\t', ' ':
+				p.begin = p.position
+				if !p.rules[ruleSpacechar]() {
+					goto ko
+				}
+				if !p.matchChar('*') {
+					goto ko
+				}
+			loop4:
+				if !p.matchChar('*') {
+					goto out5
+				}
+				goto loop4
+			out5:
+				{
+					position1 := p.position
+					if !p.rules[ruleSpacechar]() {
+						goto ko
+					}
+					p.position = position1
+				}
+				p.end = p.position
+			default:
+				goto ko
+			}
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 156 UlLine <- ((&[_] (< '____' '_'* >)) | (&[\t ] (< Spacechar '_'+ &Spacechar >))) */
+	p.rules[ruleUlLine] = func() (match bool) {
+		position0 := p.position
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '_':
+				p.begin = p.position
+				if !p.matchString("____") {
+					goto ko
+				}
+			loop:
+				if !p.matchChar('_') {
+					goto out
+				}
+				goto loop
+			out:
+				p.end = p.position
+			case '\t', ' ':
+				p.begin = p.position
+				if !p.rules[ruleSpacechar]() {
+					goto ko
+				}
+				if !p.matchChar('_') {
+					goto ko
+				}
+			loop4:
+				if !p.matchChar('_') {
+					goto out5
+				}
+				goto loop4
+			out5:
+				{
+					position1 := p.position
+					if !p.rules[ruleSpacechar]() {
+						goto ko
+					}
+					p.position = position1
+				}
+				p.end = p.position
+			default:
+				goto ko
+			}
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 157 Emph <- (&{p.enterNested()} ((((&[_] EmphUl) | (&[*] EmphStar)) &{p.leaveNested(true)}) / &{p.leaveNested(false)})) */
+	p.rules[ruleEmph] = func() (match bool) {
+		if !(p.enterNested()) {
+			return
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto nextAlt
+			}
+			switch p.Buffer[p.position] {
+			case '_':
+				if !p.rules[ruleEmphUl]() {
+					goto nextAlt
+				}
+			case '*':
+				if !p.rules[ruleEmphStar]() {
+					goto nextAlt
+				}
+			default:
+				goto nextAlt
+			}
+		}
+		if !(p.leaveNested(true)) {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !(p.leaveNested(false)) {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 158 Whitespace <- ((&[\n\r] Newline) | (&[\t ] Spacechar)) */
+	p.rules[ruleWhitespace] = func() (match bool) {
+		{
+			if p.position == len(p.Buffer) {
+				return
+			}
+			switch p.Buffer[p.position] {
+			case '\n', '\r':
+				if !p.rules[ruleNewline]() {
+					return
+				}
+			case '\t', ' ':
+				if !p.rules[ruleSpacechar]() {
+					return
+				}
+			default:
+				return
+			}
+		}
+		match = true
+		return
+	}
+	/* 159 EmphStar <- ('*' !Whitespace StartList ((!'*' Inline { a = cons(b, a) }) / (Strong { a = cons(b, a) }))+ '*' { yy = p.mkList(EMPH, a) }) */
+	p.rules[ruleEmphStar] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.matchChar('*') {
+			goto ko
+		}
+		if !p.rules[ruleWhitespace]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if p.peekChar('*') {
+				goto nextAlt
+			}
+			if !p.rules[ruleInline]() {
+				goto nextAlt
+			}
+			p.doarg(yySet, -2)
+			p.do(59)
+			goto ok4
+		nextAlt:
+			p.position, p.thunkPosition = position1, thunkPosition1
+			if !p.rules[ruleStrong]() {
+				goto ko
+			}
+			p.doarg(yySet, -2)
+			p.do(60)
+		}
+	ok4:
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			{
+				position3, thunkPosition3 := p.position, p.thunkPosition
+				if p.peekChar('*') {
+					goto nextAlt7
+				}
+				if !p.rules[ruleInline]() {
+					goto nextAlt7
+				}
+				p.doarg(yySet, -2)
+				p.do(59)
+				goto ok6
+			nextAlt7:
+				p.position, p.thunkPosition = position3, thunkPosition3
+				if !p.rules[ruleStrong]() {
+					goto out
+				}
+				p.doarg(yySet, -2)
+				p.do(60)
+			}
+		ok6:
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.matchChar('*') {
+			goto ko
+		}
+		p.do(61)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 160 EmphUl <- ('_' !Whitespace StartList ((!'_' Inline { a = cons(b, a) }) / (Strong { a = cons(b, a) }))+ '_' { yy = p.mkList(EMPH, a) }) */
+	p.rules[ruleEmphUl] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.matchChar('_') {
+			goto ko
+		}
+		if !p.rules[ruleWhitespace]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if p.peekChar('_') {
+				goto nextAlt
+			}
+			if !p.rules[ruleInline]() {
+				goto nextAlt
+			}
+			p.doarg(yySet, -2)
+			p.do(62)
+			goto ok4
+		nextAlt:
+			p.position, p.thunkPosition = position1, thunkPosition1
+			if !p.rules[ruleStrong]() {
+				goto ko
+			}
+			p.doarg(yySet, -2)
+			p.do(63)
+		}
+	ok4:
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			{
+				position3, thunkPosition3 := p.position, p.thunkPosition
+				if p.peekChar('_') {
+					goto nextAlt7
+				}
+				if !p.rules[ruleInline]() {
+					goto nextAlt7
+				}
+				p.doarg(yySet, -2)
+				p.do(62)
+				goto ok6
+			nextAlt7:
+				p.position, p.thunkPosition = position3, thunkPosition3
+				if !p.rules[ruleStrong]() {
+					goto out
+				}
+				p.doarg(yySet, -2)
+				p.do(63)
+			}
+		ok6:
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.matchChar('_') {
+			goto ko
+		}
+		p.do(64)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 161 Strong <- (&{p.enterNested()} ((((&[_] StrongUl) | (&[*] StrongStar)) &{p.leaveNested(true)}) / &{p.leaveNested(false)})) */
+	p.rules[ruleStrong] = func() (match bool) {
+		if !(p.enterNested()) {
+			return
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto nextAlt
+			}
+			switch p.Buffer[p.position] {
+			case '_':
+				if !p.rules[ruleStrongUl]() {
+					goto nextAlt
+				}
+			case '*':
+				if !p.rules[ruleStrongStar]() {
+					goto nextAlt
+				}
+			default:
+				goto nextAlt
+			}
+		}
+		if !(p.leaveNested(true)) {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !(p.leaveNested(false)) {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 162 StrongStar <- ('**' !Whitespace StartList (!'**' Inline { a = cons(b, a) })+ '**' { yy = p.mkList(STRONG, a) }) */
+	p.rules[ruleStrongStar] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.matchString("**") {
+			goto ko
+		}
+		if !p.rules[ruleWhitespace]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchString("**") {
+			goto ok4
+		}
+		goto ko
+	ok4:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		p.do(65)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.matchString("**") {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.doarg(yySet, -2)
+			p.do(65)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.matchString("**") {
+			goto ko
+		}
+		p.do(66)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 163 StrongUl <- ('__' !Whitespace StartList (!'__' Inline { a = cons(b, a) })+ '__' { yy = p.mkList(STRONG, a) }) */
+	p.rules[ruleStrongUl] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.matchString("__") {
+			goto ko
+		}
+		if !p.rules[ruleWhitespace]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchString("__") {
+			goto ok4
+		}
+		goto ko
+	ok4:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		p.do(67)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.matchString("__") {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.doarg(yySet, -2)
+			p.do(67)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.matchString("__") {
+			goto ko
+		}
+		p.do(68)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 166 Strike <- (&{p.extension.Strike} &{p.enterNested()} ((StrikeText &{p.leaveNested(true)}) / &{p.leaveNested(false)})) */
+	p.rules[ruleStrike] = func() (match bool) {
+		if !(p.extension.Strike) {
+			return
+		}
+		if !(p.enterNested()) {
+			return
+		}
+		if !p.rules[ruleStrikeText]() {
+			goto nextAlt
+		}
+		if !(p.leaveNested(true)) {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !(p.leaveNested(false)) {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 167 Image <- ('!' (ExplicitLink / ReferenceLink) {	if yy.key == LINK {
+			yy.key = p.imageKey(yy.contents.link.url)
+		} else {
+			result := yy
+			yy.children = cons(p.mkString("!"), result.children)
+		}
+	}) */
+	p.rules[ruleImage] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		if !p.matchChar('!') {
+			goto ko
+		}
+		if !p.rules[ruleExplicitLink]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleReferenceLink]() {
+			goto ko
+		}
+	ok:
+		p.do(72)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 168 Link <- (ExplicitLink / ReferenceLink / AutoLink) */
+	p.rules[ruleLink] = func() (match bool) {
+		if !p.rules[ruleExplicitLink]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleReferenceLink]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleAutoLink]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 169 ReferenceLink <- (ReferenceLinkDouble / ReferenceLinkSingle) */
+	p.rules[ruleReferenceLink] = func() (match bool) {
+		if !p.rules[ruleReferenceLinkDouble]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleReferenceLinkSingle]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 170 ReferenceLinkDouble <- (Label < Spnl > !'[]' Label {
+	    if match, found := p.findReference(b.children); found {
+	        yy = p.mkLink(a.children, match.url, match.title);
+	        yy.contents.link.ref = match
+	        a = nil
+	        b = nil
+	    } else {
+	        p.undefined("reference", b.children)
+	        result := p.mkElem(LIST)
+	        result.children = cons(p.mkString("["), cons(a, cons(p.mkString("]"), cons(p.mkString(yytext),
+	                            cons(p.mkString("["), cons(b, p.mkString("]")))))))
+	        yy = result
+	    }
+	}) */
+	p.rules[ruleReferenceLinkDouble] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleLabel]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		p.begin = p.position
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		p.end = p.position
+		if !p.matchString("[]") {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleLabel]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		p.do(73)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 171 ReferenceLinkSingle <- (Label < (Spnl '[]')? > {
+	    if match, found := p.findReference(a.children); found {
+	        yy = p.mkLink(a.children, match.url, match.title)
+	        yy.contents.link.ref = match
+	        a = nil
+	    } else {
+	        // A shortcut reference, [label], is not
+	        // reported, as it might well be prose,
+	        // like a[i] or [RFC 1234], only [label][].
+	        if yytext != "" {
+	            p.undefined("reference", a.children)
+	        }
+	        result := p.mkElem(LIST)
+	        result.children = cons(p.mkString("["), cons(a, cons(p.mkString("]"), p.mkString(yytext))));
+	        yy = result
+	    }
+	}) */
+	p.rules[ruleReferenceLinkSingle] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.rules[ruleLabel]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		p.begin = p.position
+		{
+			position1 := p.position
+			if !p.rules[ruleSpnl]() {
+				goto ko1
+			}
+			if !p.matchString("[]") {
+				goto ko1
+			}
+			goto ok
+		ko1:
+			p.position = position1
+		}
+	ok:
+		p.end = p.position
+		p.do(74)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 172 ExplicitLink <- (Label '(' Sp Source Spnl Title Sp ')' { yy = p.mkLink(l.children, s.contents.str, t.contents.str)
+	   s = nil
+	   t = nil
+	   l = nil }) */
+	p.rules[ruleExplicitLink] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 3)
+		if !p.rules[ruleLabel]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchChar('(') {
+			goto ko
+		}
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleSource]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.rules[ruleTitle]() {
+			goto ko
+		}
+		p.doarg(yySet, -3)
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.matchChar(')') {
+			goto ko
+		}
+		p.do(75)
+		p.doarg(yyPop, 3)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 173 Source <- ((('<' < SourceContents > '>') / (< SourceContents >)) { yy = p.mkString(yytext) }) */
+	p.rules[ruleSource] = func() (match bool) {
+		position0 := p.position
+		{
+			position1 := p.position
+			if !p.matchChar('<') {
+				goto nextAlt
+			}
+			p.begin = p.position
+			if !p.rules[ruleSourceContents]() {
+				goto nextAlt
+			}
+			p.end = p.position
+			if !p.matchChar('>') {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			p.position = position1
+			p.begin = p.position
+			if !p.rules[ruleSourceContents]() {
+				goto ko
+			}
+			p.end = p.position
+		}
+	ok:
+		p.do(76)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 174 SourceContents <- ((!'(' !')' !'>' Nonspacechar)+ / ('(' SourceContents ')'))* */
+	p.rules[ruleSourceContents] = func() (match bool) {
+	loop:
+		{
+			position1 := p.position
+			if p.position == len(p.Buffer) {
+				goto nextAlt
+			}
+			switch p.Buffer[p.position] {
+			case '(', ')', '>':
+				goto nextAlt
+			default:
+				if !p.rules[ruleNonspacechar]() {
+					goto nextAlt
+				}
+			}
+		loop5:
+			if p.position == len(p.Buffer) {
+				goto out6
+			}
+			switch p.Buffer[p.position] {
+			case '(', ')', '>':
+				goto out6
+			default:
+				if !p.rules[ruleNonspacechar]() {
+					goto out6
+				}
+			}
+			goto loop5
+		out6:
+			goto ok
+		nextAlt:
+			if !p.matchChar('(') {
+				goto out
+			}
+			if !p.rules[ruleSourceContents]() {
+				goto out
+			}
+			if !p.matchChar(')') {
+				goto out
+			}
+		ok:
+			goto loop
+		out:
+			p.position = position1
+		}
+		match = true
+		return
+	}
+	/* 175 Title <- ((TitleSingle / TitleDouble / (< '' >)) { yy = p.mkString(yytext) }) */
+	p.rules[ruleTitle] = func() (match bool) {
+		if !p.rules[ruleTitleSingle]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleTitleDouble]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		p.begin = p.position
+		p.end = p.position
+	ok:
+		p.do(77)
+		match = true
+		return
+	}
+	/* 176 TitleSingle <- ('\'' < (!('\'' Sp ((&[)] ')') | (&[\n\r] Newline))) .)* > '\'') */
+	p.rules[ruleTitleSingle] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('\'') {
+			goto ko
+		}
+		p.begin = p.position
+	loop:
+		{
+			position1 := p.position
+			{
+				position2 := p.position
+				if !p.matchChar('\'') {
+					goto ok
+				}
+				if !p.rules[ruleSp]() {
+					goto ok
+				}
+				{
+					if p.position == len(p.Buffer) {
+						goto ok
+					}
+					switch p.Buffer[p.position] {
+					case ')':
+						p.position++ // matchChar
+					case '\n', '\r':
+						if !p.rules[ruleNewline]() {
+							goto ok
+						}
+					default:
+						goto ok
+					}
+				}
+				goto out
+			ok:
+				p.position = position2
+			}
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.end = p.position
+		if !p.matchChar('\'') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 177 TitleDouble <- ('"' < (!('"' Sp ((&[)] ')') | (&[\n\r] Newline))) .)* > '"') */
+	p.rules[ruleTitleDouble] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('"') {
+			goto ko
+		}
+		p.begin = p.position
+	loop:
+		{
+			position1 := p.position
+			{
+				position2 := p.position
+				if !p.matchChar('"') {
+					goto ok
+				}
+				if !p.rules[ruleSp]() {
+					goto ok
+				}
+				{
+					if p.position == len(p.Buffer) {
+						goto ok
+					}
+					switch p.Buffer[p.position] {
+					case ')':
+						p.position++ // matchChar
+					case '\n', '\r':
+						if !p.rules[ruleNewline]() {
+							goto ok
+						}
+					default:
+						goto ok
+					}
+				}
+				goto out
+			ok:
+				p.position = position2
+			}
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.end = p.position
+		if !p.matchChar('"') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 178 AutoLink <- (AutoLinkUrl / AutoLinkEmail) */
+	p.rules[ruleAutoLink] = func() (match bool) {
+		if !p.rules[ruleAutoLinkUrl]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleAutoLinkEmail]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 179 AutoLinkUrl <- ('<' < [A-Za-z]+ '://' (!Newline !'>' .)+ > '>' {   yy = p.autoLinkURL(yytext) }) */
+	p.rules[ruleAutoLinkUrl] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.matchClass(2) {
+			goto ko
+		}
+	loop:
+		if !p.matchClass(2) {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchString("://") {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if p.peekChar('>') {
+			goto ko
+		}
+		if !p.matchDot() {
+			goto ko
+		}
+	loop3:
+		{
+			position1 := p.position
+			if !p.rules[ruleNewline]() {
+				goto ok6
+			}
+			goto out4
+		ok6:
+			if p.peekChar('>') {
+				goto out4
+			}
+			if !p.matchDot() {
+				goto out4
+			}
+			goto loop3
+		out4:
+			p.position = position1
+		}
+		p.end = p.position
+		if !p.matchChar('>') {
+			goto ko
+		}
+		p.do(78)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 180 AutoLinkEmail <- ('<' 'mailto:'? < [-A-Za-z0-9+_./!%~$]+ '@' (!Newline !'>' .)+ > '>' {
+	    yy = p.mkLink(p.mkString(yytext), "mailto:"+yytext, "")
+	}) */
+	p.rules[ruleAutoLinkEmail] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.matchString("mailto:") {
+			goto ko1
+		}
+	ko1:
+		p.begin = p.position
+		if !p.matchClass(3) {
+			goto ko
+		}
+	loop:
+		if !p.matchClass(3) {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar('@') {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ok7
+		}
+		goto ko
+	ok7:
+		if p.peekChar('>') {
+			goto ko
+		}
+		if !p.matchDot() {
+			goto ko
+		}
+	loop5:
+		{
+			position1 := p.position
+			if !p.rules[ruleNewline]() {
+				goto ok8
+			}
+			goto out6
+		ok8:
+			if p.peekChar('>') {
+				goto out6
+			}
+			if !p.matchDot() {
+				goto out6
+			}
+			goto loop5
+		out6:
+			p.position = position1
+		}
+		p.end = p.position
+		if !p.matchChar('>') {
+			goto ko
+		}
+		p.do(79)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 182 Label <- ('[' ((!'^' &{p.extension.Notes}) / (&. &{!p.extension.Notes})) StartList (!']' Inline { a = cons(yy, a) })* ']' { yy = p.mkList(LIST, a) }) */
+	p.rules[ruleLabel] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !p.matchChar('[') {
+			goto ko
+		}
+		if p.peekChar('^') {
+			goto nextAlt
+		}
+		if !(p.extension.Notes) {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !(p.position < len(p.Buffer)) {
+			goto ko
+		}
+		if !(!p.extension.Notes) {
+			goto ko
+		}
+	ok:
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+	loop:
+		{
+			position1 := p.position
+			if p.peekChar(']') {
+				goto out
+			}
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.do(81)
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.matchChar(']') {
+			goto ko
+		}
+		p.do(82)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 190 Ticks1 <- ('`' !'`') */
+	p.rules[ruleTicks1] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('`') {
+			goto ko
+		}
+		if p.peekChar('`') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 191 Ticks2 <- ('``' !'`') */
+	p.rules[ruleTicks2] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("``") {
+			goto ko
+		}
+		if p.peekChar('`') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 192 Ticks3 <- ('```' !'`') */
+	p.rules[ruleTicks3] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("```") {
+			goto ko
+		}
+		if p.peekChar('`') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 193 Ticks4 <- ('````' !'`') */
+	p.rules[ruleTicks4] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("````") {
+			goto ko
+		}
+		if p.peekChar('`') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 194 Ticks5 <- ('`````' !'`') */
+	p.rules[ruleTicks5] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("`````") {
+			goto ko
+		}
+		if p.peekChar('`') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 195 Code <- (((Ticks1 Sp < ((!'`' Nonspacechar)+ / ((&[`] (!Ticks1 '`'+)) | (&[\t\n\r ] (!(Sp Ticks1) ((&[\n\r] (Newline !BlankLine)) | (&[\t ] Spacechar))))))+ > Sp Ticks1) / (Ticks2 Sp < ((!'`' Nonspacechar)+ / ((&[`] (!Ticks2 '`'+)) | (&[\t\n\r ] (!(Sp Ticks2) ((&[\n\r] (Newline !BlankLine)) | (&[\t ] Spacechar))))))+ > Sp Ticks2) / (Ticks3 Sp < ((!'`' Nonspacechar)+ / ((&[`] (!Ticks3 '`'+)) | (&[\t\n\r ] (!(Sp Ticks3) ((&[\n\r] (Newline !BlankLine)) | (&[\t ] Spacechar))))))+ > Sp Ticks3) / (Ticks4 Sp < ((!'`' Nonspacechar)+ / ((&[`] (!Ticks4 '`'+)) | (&[\t\n\r ] (!(Sp Ticks4) ((&[\n\r] (Newline !BlankLine)) | (&[\t ] Spacechar))))))+ > Sp Ticks4) / (Ticks5 Sp < ((!'`' Nonspacechar)+ / ((&[`] (!Ticks5 '`'+)) | (&[\t\n\r ] (!(Sp Ticks5) ((&[\n\r] (Newline !BlankLine)) | (&[\t ] Spacechar))))))+ > Sp Ticks5)) { yy = p.mkString(yytext); yy.key = CODE }) */
+	p.rules[ruleCode] = func() (match bool) {
+		position0 := p.position
+		{
+			position1 := p.position
+			if !p.rules[ruleTicks1]() {
+				goto nextAlt
+			}
+			if !p.rules[ruleSp]() {
+				goto nextAlt
+			}
+			p.begin = p.position
+			if p.peekChar('`') {
+				goto nextAlt6
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto nextAlt6
+			}
+		loop7:
+			if p.peekChar('`') {
+				goto out8
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto out8
+			}
+			goto loop7
+		out8:
+			goto ok5
+		nextAlt6:
+			{
+				if p.position == len(p.Buffer) {
+					goto nextAlt
+				}
+				switch p.Buffer[p.position] {
+				case '`':
+					if !p.rules[ruleTicks1]() {
+						goto ok10
+					}
+					goto nextAlt
+				ok10:
+					if !p.matchChar('`') {
+						goto nextAlt
+					}
+				loop11:
+					if !p.matchChar('`') {
+						goto out12
+					}
+					goto loop11
+				out12:
+					break
+				default:
+					{
+						position2 := p.position
+						if !p.rules[ruleSp]() {
+							goto ok13
+						}
+						if !p.rules[ruleTicks1]() {
+							goto ok13
+						}
+						goto nextAlt
+					ok13:
+						p.position = position2
+					}
+					{
+						if p.position == len(p.Buffer) {
+							goto nextAlt
+						}
+						switch p.Buffer[p.position] {
+						case '\n', '\r':
+							if !p.rules[ruleNewline]() {
+								goto nextAlt
+							}
+							if !p.rules[ruleBlankLine]() {
+								goto ok15
+							}
+							goto nextAlt
+						ok15:
+							break
+						case '\t', ' ':
+							if !p.rules[ruleSpacechar]() {
+								goto nextAlt
+							}
+						default:
+							goto nextAlt
+						}
+					}
+				}
+			}
+		ok5:
+		loop:
+			{
+				position2 := p.position
+				if p.peekChar('`') {
+					goto nextAlt17
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto nextAlt17
+				}
+			loop18:
+				if p.peekChar('`') {
+					goto out19
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto out19
+				}
+				goto loop18
+			out19:
+				goto ok16
+			nextAlt17:
+				{
+					if p.position == len(p.Buffer) {
+						goto out
+					}
+					switch p.Buffer[p.position] {
+					case '`':
+						if !p.rules[ruleTicks1]() {
+							goto ok21
+						}
+						goto out
+					ok21:
+						if !p.matchChar('`') {
+							goto out
+						}
+					loop22:
+						if !p.matchChar('`') {
+							goto out23
+						}
+						goto loop22
+					out23:
+						break
+					default:
+						{
+							position4 := p.position
+							if !p.rules[ruleSp]() {
+								goto ok24
+							}
+							if !p.rules[ruleTicks1]() {
+								goto ok24
+							}
+							goto out
+						ok24:
+							p.position = position4
+						}
+						{
+							if p.position == len(p.Buffer) {
+								goto out
+							}
+							switch p.Buffer[p.position] {
+							case '\n', '\r':
+								if !p.rules[ruleNewline]() {
+									goto out
+								}
+								if !p.rules[ruleBlankLine]() {
+									goto ok26
+								}
+								goto out
+							ok26:
+								break
+							case '\t', ' ':
+								if !p.rules[ruleSpacechar]() {
+									goto out
+								}
+							default:
+								goto out
+							}
+						}
+					}
+				}
+			ok16:
+				goto loop
+			out:
+				p.position = position2
+			}
+			p.end = p.position
+			if !p.rules[ruleSp]() {
+				goto nextAlt
+			}
+			if !p.rules[ruleTicks1]() {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			p.position = position1
+			if !p.rules[ruleTicks2]() {
+				goto nextAlt27
+			}
+			if !p.rules[ruleSp]() {
+				goto nextAlt27
+			}
+			p.begin = p.position
+			if p.peekChar('`') {
+				goto nextAlt31
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto nextAlt31
+			}
+		loop32:
+			if p.peekChar('`') {
+				goto out33
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto out33
+			}
+			goto loop32
+		out33:
+			goto ok30
+		nextAlt31:
+			{
+				if p.position == len(p.Buffer) {
+					goto nextAlt27
+				}
+				switch p.Buffer[p.position] {
+				case '`':
+					if !p.rules[ruleTicks2]() {
+						goto ok35
+					}
+					goto nextAlt27
+				ok35:
+					if !p.matchChar('`') {
+						goto nextAlt27
+					}
+				loop36:
+					if !p.matchChar('`') {
+						goto out37
+					}
+					goto loop36
+				out37:
+					break
+				default:
+					{
+						position5 := p.position
+						if !p.rules[ruleSp]() {
+							goto ok38
+						}
+						if !p.rules[ruleTicks2]() {
+							goto ok38
+						}
+						goto nextAlt27
+					ok38:
+						p.position = position5
+					}
+					{
+						if p.position == len(p.Buffer) {
+							goto nextAlt27
+						}
+						switch p.Buffer[p.position] {
+						case '\n', '\r':
+							if !p.rules[ruleNewline]() {
+								goto nextAlt27
+							}
+							if !p.rules[ruleBlankLine]() {
+								goto ok40
+							}
+							goto nextAlt27
+						ok40:
+							break
+						case '\t', ' ':
+							if !p.rules[ruleSpacechar]() {
+								goto nextAlt27
+							}
+						default:
+							goto nextAlt27
+						}
+					}
+				}
+			}
+		ok30:
+		loop28:
+			{
+				position5 := p.position
+				if p.peekChar('`') {
+					goto nextAlt42
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto nextAlt42
+				}
+			loop43:
+				if p.peekChar('`') {
+					goto out44
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto out44
+				}
+				goto loop43
+			out44:
+				goto ok41
+			nextAlt42:
+				{
+					if p.position == len(p.Buffer) {
+						goto out29
+					}
+					switch p.Buffer[p.position] {
+					case '`':
+						if !p.rules[ruleTicks2]() {
+							goto ok46
+						}
+						goto out29
+					ok46:
+						if !p.matchChar('`') {
+							goto out29
+						}
+					loop47:
+						if !p.matchChar('`') {
+							goto out48
+						}
+						goto loop47
+					out48:
+						break
+					default:
+						{
+							position7 := p.position
+							if !p.rules[ruleSp]() {
+								goto ok49
+							}
+							if !p.rules[ruleTicks2]() {
+								goto ok49
+							}
+							goto out29
+						ok49:
+							p.position = position7
+						}
+						{
+							if p.position == len(p.Buffer) {
+								goto out29
+							}
+							switch p.Buffer[p.position] {
+							case '\n', '\r':
+								if !p.rules[ruleNewline]() {
+									goto out29
+								}
+								if !p.rules[ruleBlankLine]() {
+									goto ok51
+								}
+								goto out29
+							ok51:
+								break
+							case '\t', ' ':
+								if !p.rules[ruleSpacechar]() {
+									goto out29
+								}
+							default:
+								goto out29
+							}
+						}
+					}
+				}
+			ok41:
+				goto loop28
+			out29:
+				p.position = position5
+			}
+			p.end = p.position
+			if !p.rules[ruleSp]() {
+				goto nextAlt27
+			}
+			if !p.rules[ruleTicks2]() {
+				goto nextAlt27
+			}
+			goto ok
+		nextAlt27:
+			p.position = position1
+			if !p.rules[ruleTicks3]() {
+				goto nextAlt52
+			}
+			if !p.rules[ruleSp]() {
+				goto nextAlt52
+			}
+			p.begin = p.position
+			if p.peekChar('`') {
+				goto nextAlt56
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto nextAlt56
+			}
+		loop57:
+			if p.peekChar('`') {
+				goto out58
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto out58
+			}
+			goto loop57
+		out58:
+			goto ok55
+		nextAlt56:
+			{
+				if p.position == len(p.Buffer) {
+					goto nextAlt52
+				}
+				switch p.Buffer[p.position] {
+				case '`':
+					if !p.rules[ruleTicks3]() {
+						goto ok60
+					}
+					goto nextAlt52
+				ok60:
+					if !p.matchChar('`') {
+						goto nextAlt52
+					}
+				loop61:
+					if !p.matchChar('`') {
+						goto out62
+					}
+					goto loop61
+				out62:
+					break
+				default:
+					{
+						position8 := p.position
+						if !p.rules[ruleSp]() {
+							goto ok63
+						}
+						if !p.rules[ruleTicks3]() {
+							goto ok63
+						}
+						goto nextAlt52
+					ok63:
+						p.position = position8
+					}
+					{
+						if p.position == len(p.Buffer) {
+							goto nextAlt52
+						}
+						switch p.Buffer[p.position] {
+						case '\n', '\r':
+							if !p.rules[ruleNewline]() {
+								goto nextAlt52
+							}
+							if !p.rules[ruleBlankLine]() {
+								goto ok65
+							}
+							goto nextAlt52
+						ok65:
+							break
+						case '\t', ' ':
+							if !p.rules[ruleSpacechar]() {
+								goto nextAlt52
+							}
+						default:
+							goto nextAlt52
+						}
+					}
+				}
+			}
+		ok55:
+		loop53:
+			{
+				position8 := p.position
+				if p.peekChar('`') {
+					goto nextAlt67
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto nextAlt67
+				}
+			loop68:
+				if p.peekChar('`') {
+					goto out69
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto out69
+				}
+				goto loop68
+			out69:
+				goto ok66
+			nextAlt67:
+				{
+					if p.position == len(p.Buffer) {
+						goto out54
+					}
+					switch p.Buffer[p.position] {
+					case '`':
+						if !p.rules[ruleTicks3]() {
+							goto ok71
+						}
+						goto out54
+					ok71:
+						if !p.matchChar('`') {
+							goto out54
+						}
+					loop72:
+						if !p.matchChar('`') {
+							goto out73
+						}
+						goto loop72
+					out73:
+						break
+					default:
+						{
+							position10 := p.position
+							if !p.rules[ruleSp]() {
+								goto ok74
+							}
+							if !p.rules[ruleTicks3]() {
+								goto ok74
+							}
+							goto out54
+						ok74:
+							p.position = position10
+						}
+						{
+							if p.position == len(p.Buffer) {
+								goto out54
+							}
+							switch p.Buffer[p.position] {
+							case '\n', '\r':
+								if !p.rules[ruleNewline]() {
+									goto out54
+								}
+								if !p.rules[ruleBlankLine]() {
+									goto ok76
+								}
+								goto out54
+							ok76:
+								break
+							case '\t', ' ':
+								if !p.rules[ruleSpacechar]() {
+									goto out54
+								}
+							default:
+								goto out54
+							}
+						}
+					}
+				}
+			ok66:
+				goto loop53
+			out54:
+				p.position = position8
+			}
+			p.end = p.position
+			if !p.rules[ruleSp]() {
+				goto nextAlt52
+			}
+			if !p.rules[ruleTicks3]() {
+				goto nextAlt52
+			}
+			goto ok
+		nextAlt52:
+			p.position = position1
+			if !p.rules[ruleTicks4]() {
+				goto nextAlt77
+			}
+			if !p.rules[ruleSp]() {
+				goto nextAlt77
+			}
+			p.begin = p.position
+			if p.peekChar('`') {
+				goto nextAlt81
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto nextAlt81
+			}
+		loop82:
+			if p.peekChar('`') {
+				goto out83
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto out83
+			}
+			goto loop82
+		out83:
+			goto ok80
+		nextAlt81:
+			{
+				if p.position == len(p.Buffer) {
+					goto nextAlt77
+				}
+				switch p.Buffer[p.position] {
+				case '`':
+					if !p.rules[ruleTicks4]() {
+						goto ok85
+					}
+					goto nextAlt77
+				ok85:
+					if !p.matchChar('`') {
+						goto nextAlt77
+					}
+				loop86:
+					if !p.matchChar('`') {
+						goto out87
+					}
+					goto loop86
+				out87:
+					break
+				default:
+					{
+						position11 := p.position
+						if !p.rules[ruleSp]() {
+							goto ok88
+						}
+						if !p.rules[ruleTicks4]() {
+							goto ok88
+						}
+						goto nextAlt77
+					ok88:
+						p.position = position11
+					}
+					{
+						if p.position == len(p.Buffer) {
+							goto nextAlt77
+						}
+						switch p.Buffer[p.position] {
+						case '\n', '\r':
+							if !p.rules[ruleNewline]() {
+								goto nextAlt77
+							}
+							if !p.rules[ruleBlankLine]() {
+								goto ok90
+							}
+							goto nextAlt77
+						ok90:
+							break
+						case '\t', ' ':
+							if !p.rules[ruleSpacechar]() {
+								goto nextAlt77
+							}
+						default:
+							goto nextAlt77
+						}
+					}
+				}
+			}
+		ok80:
+		loop78:
+			{
+				position11 := p.position
+				if p.peekChar('`') {
+					goto nextAlt92
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto nextAlt92
+				}
+			loop93:
+				if p.peekChar('`') {
+					goto out94
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto out94
+				}
+				goto loop93
+			out94:
+				goto ok91
+			nextAlt92:
+				{
+					if p.position == len(p.Buffer) {
+						goto out79
+					}
+					switch p.Buffer[p.position] {
+					case '`':
+						if !p.rules[ruleTicks4]() {
+							goto ok96
+						}
+						goto out79
+					ok96:
+						if !p.matchChar('`') {
+							goto out79
+						}
+					loop97:
+						if !p.matchChar('`') {
+							goto out98
+						}
+						goto loop97
+					out98:
+						break
+					default:
+						{
+							position13 := p.position
+							if !p.rules[ruleSp]() {
+								goto ok99
+							}
+							if !p.rules[ruleTicks4]() {
+								goto ok99
+							}
+							goto out79
+						ok99:
+							p.position = position13
+						}
+						{
+							if p.position == len(p.Buffer) {
+								goto out79
+							}
+							switch p.Buffer[p.position] {
+							case '\n', '\r':
+								if !p.rules[ruleNewline]() {
+									goto out79
+								}
+								if !p.rules[ruleBlankLine]() {
+									goto ok101
+								}
+								goto out79
+							ok101:
+								break
+							case '\t', ' ':
+								if !p.rules[ruleSpacechar]() {
+									goto out79
+								}
+							default:
+								goto out79
+							}
+						}
+					}
+				}
+			ok91:
+				goto loop78
+			out79:
+				p.position = position11
+			}
+			p.end = p.position
+			if !p.rules[ruleSp]() {
+				goto nextAlt77
+			}
+			if !p.rules[ruleTicks4]() {
+				goto nextAlt77
+			}
+			goto ok
+		nextAlt77:
+			p.position = position1
+			if !p.rules[ruleTicks5]() {
+				goto ko
+			}
+			if !p.rules[ruleSp]() {
+				goto ko
+			}
+			p.begin = p.position
+			if p.peekChar('`') {
+				goto nextAlt105
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto nextAlt105
+			}
+		loop106:
+			if p.peekChar('`') {
+				goto out107
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto out107
+			}
+			goto loop106
+		out107:
+			goto ok104
+		nextAlt105:
+			{
+				if p.position == len(p.Buffer) {
+					goto ko
+				}
+				switch p.Buffer[p.position] {
+				case '`':
+					if !p.rules[ruleTicks5]() {
+						goto ok109
+					}
+					goto ko
+				ok109:
+					if !p.matchChar('`') {
+						goto ko
+					}
+				loop110:
+					if !p.matchChar('`') {
+						goto out111
+					}
+					goto loop110
+				out111:
+					break
+				default:
+					{
+						position14 := p.position
+						if !p.rules[ruleSp]() {
+							goto ok112
+						}
+						if !p.rules[ruleTicks5]() {
+							goto ok112
+						}
+						goto ko
+					ok112:
+						p.position = position14
+					}
+					{
+						if p.position == len(p.Buffer) {
+							goto ko
+						}
+						switch p.Buffer[p.position] {
+						case '\n', '\r':
+							if !p.rules[ruleNewline]() {
+								goto ko
+							}
+							if !p.rules[ruleBlankLine]() {
+								goto ok114
+							}
+							goto ko
+						ok114:
+							break
+						case '\t', ' ':
+							if !p.rules[ruleSpacechar]() {
+								goto ko
+							}
+						default:
+							goto ko
+						}
+					}
+				}
+			}
+		ok104:
+		loop102:
+			{
+				position14 := p.position
+				if p.peekChar('`') {
+					goto nextAlt116
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto nextAlt116
+				}
+			loop117:
+				if p.peekChar('`') {
+					goto out118
+				}
+				if !p.rules[ruleNonspacechar]() {
+					goto out118
+				}
+				goto loop117
+			out118:
+				goto ok115
+			nextAlt116:
+				{
+					if p.position == len(p.Buffer) {
+						goto out103
+					}
+					switch p.Buffer[p.position] {
+					case '`':
+						if !p.rules[ruleTicks5]() {
+							goto ok120
+						}
+						goto out103
+					ok120:
+						if !p.matchChar('`') {
+							goto out103
+						}
+					loop121:
+						if !p.matchChar('`') {
+							goto out122
+						}
+						goto loop121
+					out122:
+						break
+					default:
+						{
+							position16 := p.position
+							if !p.rules[ruleSp]() {
+								goto ok123
+							}
+							if !p.rules[ruleTicks5]() {
+								goto ok123
+							}
+							goto out103
+						ok123:
+							p.position = position16
+						}
+						{
+							if p.position == len(p.Buffer) {
+								goto out103
+							}
+							switch p.Buffer[p.position] {
+							case '\n', '\r':
+								if !p.rules[ruleNewline]() {
+									goto out103
+								}
+								if !p.rules[ruleBlankLine]() {
+									goto ok125
+								}
+								goto out103
+							ok125:
+								break
+							case '\t', ' ':
+								if !p.rules[ruleSpacechar]() {
+									goto out103
+								}
+							default:
+								goto out103
+							}
+						}
+					}
+				}
+			ok115:
+				goto loop102
+			out103:
+				p.position = position14
+			}
+			p.end = p.position
+			if !p.rules[ruleSp]() {
+				goto ko
+			}
+			if !p.rules[ruleTicks5]() {
+				goto ko
+			}
+		}
+	ok:
+		p.do(87)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 196 RawHtml <- (< (HtmlComment / HtmlBlockScript / HtmlTag) > { yy = p.rawHTML(yytext, HTML) }) */
+	p.rules[ruleRawHtml] = func() (match bool) {
+		position0 := p.position
+		p.begin = p.position
+		if !p.rules[ruleHtmlComment]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleHtmlBlockScript]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.rules[ruleHtmlTag]() {
+			goto ko
+		}
+	ok:
+		p.end = p.position
+		p.do(88)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 197 BlankLine <- (Sp Newline) */
+	p.rules[ruleBlankLine] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 198 Quoted <- ((&[\'] ('\'' (!'\'' .)* '\'')) | (&[\"] ('"' (!'"' .)* '"'))) */
+	p.rules[ruleQuoted] = func() (match bool) {
+		position0 := p.position
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '\'':
+				p.position++ // matchChar
+			loop:
+				if p.position == len(p.Buffer) {
+					goto out
+				}
+				switch p.Buffer[p.position] {
+				case '\'':
+					goto out
+				default:
+					p.position++
+				}
+				goto loop
+			out:
+				if !p.matchChar('\'') {
+					goto ko
+				}
+			case '"':
+				p.position++ // matchChar
+			loop4:
+				if p.position == len(p.Buffer) {
+					goto out5
+				}
+				switch p.Buffer[p.position] {
+				case '"':
+					goto out5
+				default:
+					p.position++
+				}
+				goto loop4
+			out5:
+				if !p.matchChar('"') {
+					goto ko
+				}
+			default:
+				goto ko
+			}
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 199 HtmlAttribute <- ((&{p.attrBoundary(p.position)} ((&[.] '.') | (&[@] '@') | (&[:] ':') | (&[_] '_') | (&[\-] '-') | (&[0-9A-Za-z] [A-Za-z0-9]))+ / ((&[\-] '-') | (&[0-9A-Za-z] [A-Za-z0-9]))+) Spnl ('=' Spnl (Quoted / (!'>' Nonspacechar)+))? Spnl) */
+	p.rules[ruleHtmlAttribute] = func() (match bool) {
+		position0 := p.position
+		if !(p.attrBoundary(p.position)) {
+			goto nextAlt1
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto nextAlt1
+			}
+			switch p.Buffer[p.position] {
+			case '.', '@', ':', '_', '-':
+				p.position++ // matchChar
+			default:
+				if !p.matchClass(5) {
+					goto nextAlt1
+				}
+			}
+		}
+	loop2:
+		{
+			if p.position == len(p.Buffer) {
+				goto out3
+			}
+			switch p.Buffer[p.position] {
+			case '.', '@', ':', '_', '-':
+				p.position++ // matchChar
+			default:
+				if !p.matchClass(5) {
+					goto out3
+				}
+			}
+		}
+		goto loop2
+	out3:
+		goto out
+	nextAlt1:
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '-':
+				p.position++ // matchChar
+			default:
+				if !p.matchClass(5) {
+					goto ko
+				}
+			}
+		}
+	loop:
+		{
+			if p.position == len(p.Buffer) {
+				goto out
+			}
+			switch p.Buffer[p.position] {
+			case '-':
+				p.position++ // matchChar
+			default:
+				if !p.matchClass(5) {
+					goto out
+				}
+			}
+		}
+		goto loop
+	out:
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		{
+			position1 := p.position
+			if !p.matchChar('=') {
+				goto ko5
+			}
+			if !p.rules[ruleSpnl]() {
+				goto ko5
+			}
+			if !p.rules[ruleQuoted]() {
+				goto nextAlt
+			}
+			goto ok7
+		nextAlt:
+			if p.peekChar('>') {
+				goto ko5
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto ko5
+			}
+		loop9:
+			if p.peekChar('>') {
+				goto out10
+			}
+			if !p.rules[ruleNonspacechar]() {
+				goto out10
+			}
+			goto loop9
+		out10:
+		ok7:
+			goto ok6
+		ko5:
+			p.position = position1
+		}
+	ok6:
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 200 HtmlComment <- ('<!--' (!'-->' .)* '-->') */
+	p.rules[ruleHtmlComment] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("<!--") {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.matchString("-->") {
+				goto ok
+			}
+			goto out
+		ok:
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.matchString("-->") {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 201 HtmlTag <- ('<' Spnl '/'? [A-Za-z0-9]+ Spnl HtmlAttribute* '/'? Spnl '>') */
+	p.rules[ruleHtmlTag] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('<') {
+			goto ko
+		}
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		p.matchChar('/')
+		if !p.matchClass(5) {
+			goto ko
+		}
+	loop:
+		if !p.matchClass(5) {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+	loop3:
+		if !p.rules[ruleHtmlAttribute]() {
+			goto out4
+		}
+		goto loop3
+	out4:
+		p.matchChar('/')
+		if !p.rules[ruleSpnl]() {
+			goto ko
+		}
+		if !p.matchChar('>') {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 203 Spacechar <- ((&[\t] '\t') | (&[ ] ' ')) */
+	p.rules[ruleSpacechar] = func() (match bool) {
+		{
+			if p.position == len(p.Buffer) {
+				return
+			}
+			switch p.Buffer[p.position] {
+			case '\t':
+				p.position++ // matchChar
+			case ' ':
+				p.position++ // matchChar
+			default:
+				return
+			}
+		}
+		match = true
+		return
+	}
+	/* 204 Nonspacechar <- (!Spacechar !Newline .) */
+	p.rules[ruleNonspacechar] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleSpacechar]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleNewline]() {
+			goto ok2
+		}
+		goto ko
+	ok2:
+		if !p.matchDot() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 205 Newline <- ((&[\r] ('\r' '\n'?)) | (&[\n] '\n')) */
+	p.rules[ruleNewline] = func() (match bool) {
+		position0 := p.position
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '\r':
+				p.position++ // matchChar
+				p.matchChar('\n')
+			case '\n':
+				p.position++ // matchChar
+			default:
+				goto ko
+			}
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 206 Sp <- Spacechar* */
+	p.rules[ruleSp] = func() (match bool) {
+	loop:
+		if !p.rules[ruleSpacechar]() {
+			goto out
+		}
+		goto loop
+	out:
+		match = true
+		return
+	}
+	/* 207 Spnl <- (Sp (Newline Sp)?) */
+	p.rules[ruleSpnl] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleSp]() {
+			goto ko
+		}
+		{
+			position1 := p.position
+			if !p.rules[ruleNewline]() {
+				goto ko1
+			}
+			if !p.rules[ruleSp]() {
+				goto ko1
+			}
+			goto ok
+		ko1:
+			p.position = position1
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 208 SpecialChar <- ('\'' / '"' / ((&[\\] '\\') | (&[#] '#') | (&[!] '!') | (&[<] '<') | (&[)] ')') | (&[(] '(') | (&[\]] ']') | (&[\[] '[') | (&[&] '&') | (&[`] '`') | (&[_] '_') | (&[*] '*') | (&[~] '~') | (&[\"\'\-.^] ExtendedSpecialChar))) */
+	p.rules[ruleSpecialChar] = func() (match bool) {
+		if !p.matchChar('\'') {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchChar('"') {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		{
+			if p.position == len(p.Buffer) {
+				return
+			}
+			switch p.Buffer[p.position] {
+			case '\\':
+				p.position++ // matchChar
+			case '#':
+				p.position++ // matchChar
+			case '!':
+				p.position++ // matchChar
+			case '<':
+				p.position++ // matchChar
+			case ')':
+				p.position++ // matchChar
+			case '(':
+				p.position++ // matchChar
+			case ']':
+				p.position++ // matchChar
+			case '[':
+				p.position++ // matchChar
+			case '&':
+				p.position++ // matchChar
+			case '`':
+				p.position++ // matchChar
+			case '_':
+				p.position++ // matchChar
+			case '*':
+				p.position++ // matchChar
+			case '~':
+				p.position++ // matchChar
+			default:
+				if !p.rules[ruleExtendedSpecialChar]() {
+					return
+				}
+			}
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 209 NormalChar <- (!((&[\n\r] Newline) | (&[\t ] Spacechar) | (&[!-#&-*\-.<\[-`~] SpecialChar)) .) */
+	p.rules[ruleNormalChar] = func() (match bool) {
+		position0 := p.position
+		{
+			if p.position == len(p.Buffer) {
+				goto ok
+			}
+			switch p.Buffer[p.position] {
+			case '\n', '\r':
+				if !p.rules[ruleNewline]() {
+					goto ok
+				}
+			case '\t', ' ':
+				if !p.rules[ruleSpacechar]() {
+					goto ok
+				}
+			default:
+				if !p.rules[ruleSpecialChar]() {
+					goto ok
+				}
+			}
+		}
+		goto ko
+	ok:
+		if !p.matchDot() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 210 Alphanumeric <- ((&[\377] '\377') | (&[\376] '\376') | (&[\375] '\375') | (&[\374] '\374') | (&[\373] '\373') | (&[\372] '\372') | (&[\371] '\371') | (&[\370] '\370') | (&[\367] '\367') | (&[\366] '\366') | (&[\365] '\365') | (&[\364] '\364') | (&[\363] '\363') | (&[\362] '\362') | (&[\361] '\361') | (&[\360] '\360') | (&[\357] '\357') | (&[\356] '\356') | (&[\355] '\355') | (&[\354] '\354') | (&[\353] '\353') | (&[\352] '\352') | (&[\351] '\351') | (&[\350] '\350') | (&[\347] '\347') | (&[\346] '\346') | (&[\345] '\345') | (&[\344] '\344') | (&[\343] '\343') | (&[\342] '\342') | (&[\341] '\341') | (&[\340] '\340') | (&[\337] '\337') | (&[\336] '\336') | (&[\335] '\335') | (&[\334] '\334') | (&[\333] '\333') | (&[\332] '\332') | (&[\331] '\331') | (&[\330] '\330') | (&[\327] '\327') | (&[\326] '\326') | (&[\325] '\325') | (&[\324] '\324') | (&[\323] '\323') | (&[\322] '\322') | (&[\321] '\321') | (&[\320] '\320') | (&[\317] '\317') | (&[\316] '\316') | (&[\315] '\315') | (&[\314] '\314') | (&[\313] '\313') | (&[\312] '\312') | (&[\311] '\311') | (&[\310] '\310') | (&[\307] '\307') | (&[\306] '\306') | (&[\305] '\305') | (&[\304] '\304') | (&[\303] '\303') | (&[\302] '\302') | (&[\301] '\301') | (&[\300] '\300') | (&[\277] '\277') | (&[\276] '\276') | (&[\275] '\275') | (&[\274] '\274') | (&[\273] '\273') | (&[\272] '\272') | (&[\271] '\271') | (&[\270] '\270') | (&[\267] '\267') | (&[\266] '\266') | (&[\265] '\265') | (&[\264] '\264') | (&[\263] '\263') | (&[\262] '\262') | (&[\261] '\261') | (&[\260] '\260') | (&[\257] '\257') | (&[\256] '\256') | (&[\255] '\255') | (&[\254] '\254') | (&[\253] '\253') | (&[\252] '\252') | (&[\251] '\251') | (&[\250] '\250') | (&[\247] '\247') | (&[\246] '\246') | (&[\245] '\245') | (&[\244] '\244') | (&[\243] '\243') | (&[\242] '\242') | (&[\241] '\241') | (&[\240] '\240') | (&[\237] '\237') | (&[\236] '\236') | (&[\235] '\235') | (&[\234] '\234') | (&[\233] '\233') | (&[\232] '\232') | (&[\231] '\231') | (&[\230] '\230') | (&[\227] '\227') | (&[\226] '\226') | (&[\225] '\225') | (&[\224] '\224') | (&[\223] '\223') | (&[\222] '\222') | (&[\221] '\221') | (&[\220] '\220') | (&[\217] '\217') | (&[\216] '\216') | (&[\215] '\215') | (&[\214] '\214') | (&[\213] '\213') | (&[\212] '\212') | (&[\211] '\211') | (&[\210] '\210') | (&[\207] '\207') | (&[\206] '\206') | (&[\205] '\205') | (&[\204] '\204') | (&[\203] '\203') | (&[\202] '\202') | (&[\201] '\201') | (&[\200] '\200') | (&[0-9A-Za-z] [0-9A-Za-z])) */
+	p.rules[ruleAlphanumeric] = func() (match bool) {
+		{
+			if p.position == len(p.Buffer) {
+				return
+			}
+			switch p.Buffer[p.position] {
+			case '\377':
+				p.position++ // matchChar
+			case '\376':
+				p.position++ // matchChar
+			case '\375':
+				p.position++ // matchChar
+			case '\374':
+				p.position++ // matchChar
+			case '\373':
+				p.position++ // matchChar
+			case '\372':
+				p.position++ // matchChar
+			case '\371':
+				p.position++ // matchChar
+			case '\370':
+				p.position++ // matchChar
+			case '\367':
+				p.position++ // matchChar
+			case '\366':
+				p.position++ // matchChar
+			case '\365':
+				p.position++ // matchChar
+			case '\364':
+				p.position++ // matchChar
+			case '\363':
+				p.position++ // matchChar
+			case '\362':
+				p.position++ // matchChar
+			case '\361':
+				p.position++ // matchChar
+			case '\360':
+				p.position++ // matchChar
+			case '\357':
+				p.position++ // matchChar
+			case '\356':
+				p.position++ // matchChar
+			case '\355':
+				p.position++ // matchChar
+			case '\354':
+				p.position++ // matchChar
+			case '\353':
+				p.position++ // matchChar
+			case '\352':
+				p.position++ // matchChar
+			case '\351':
+				p.position++ // matchChar
+			case '\350':
+				p.position++ // matchChar
+			case '\347':
+				p.position++ // matchChar
+			case '\346':
+				p.position++ // matchChar
+			case '\345':
+				p.position++ // matchChar
+			case '\344':
+				p.position++ // matchChar
+			case '\343':
+				p.position++ // matchChar
+			case '\342':
+				p.position++ // matchChar
+			case '\341':
+				p.position++ // matchChar
+			case '\340':
+				p.position++ // matchChar
+			case '\337':
+				p.position++ // matchChar
+			case '\336':
+				p.position++ // matchChar
+			case '\335':
+				p.position++ // matchChar
+			case '\334':
+				p.position++ // matchChar
+			case '\333':
+				p.position++ // matchChar
+			case '\332':
+				p.position++ // matchChar
+			case '\331':
+				p.position++ // matchChar
+			case '\330':
+				p.position++ // matchChar
+			case '\327':
+				p.position++ // matchChar
+			case '\326':
+				p.position++ // matchChar
+			case '\325':
+				p.position++ // matchChar
+			case '\324':
+				p.position++ // matchChar
+			case '\323':
+				p.position++ // matchChar
+			case '\322':
+				p.position++ // matchChar
+			case '\321':
+				p.position++ // matchChar
+			case '\320':
+				p.position++ // matchChar
+			case '\317':
+				p.position++ // matchChar
+			case '\316':
+				p.position++ // matchChar
+			case '\315':
+				p.position++ // matchChar
+			case '\314':
+				p.position++ // matchChar
+			case '\313':
+				p.position++ // matchChar
+			case '\312':
+				p.position++ // matchChar
+			case '\311':
+				p.position++ // matchChar
+			case '\310':
+				p.position++ // matchChar
+			case '\307':
+				p.position++ // matchChar
+			case '\306':
+				p.position++ // matchChar
+			case '\305':
+				p.position++ // matchChar
+			case '\304':
+				p.position++ // matchChar
+			case '\303':
+				p.position++ // matchChar
+			case '\302':
+				p.position++ // matchChar
+			case '\301':
+				p.position++ // matchChar
+			case '\300':
+				p.position++ // matchChar
+			case '\277':
+				p.position++ // matchChar
+			case '\276':
+				p.position++ // matchChar
+			case '\275':
+				p.position++ // matchChar
+			case '\274':
+				p.position++ // matchChar
+			case '\273':
+				p.position++ // matchChar
+			case '\272':
+				p.position++ // matchChar
+			case '\271':
+				p.position++ // matchChar
+			case '\270':
+				p.position++ // matchChar
+			case '\267':
+				p.position++ // matchChar
+			case '\266':
+				p.position++ // matchChar
+			case '\265':
+				p.position++ // matchChar
+			case '\264':
+				p.position++ // matchChar
+			case '\263':
+				p.position++ // matchChar
+			case '\262':
+				p.position++ // matchChar
+			case '\261':
+				p.position++ // matchChar
+			case '\260':
+				p.position++ // matchChar
+			case '\257':
+				p.position++ // matchChar
+			case '\256':
+				p.position++ // matchChar
+			case '\255':
+				p.position++ // matchChar
+			case '\254':
+				p.position++ // matchChar
+			case '\253':
+				p.position++ // matchChar
+			case '\252':
+				p.position++ // matchChar
+			case '\251':
+				p.position++ // matchChar
+			case '\250':
+				p.position++ // matchChar
+			case '\247':
+				p.position++ // matchChar
+			case '\246':
+				p.position++ // matchChar
+			case '\245':
+				p.position++ // matchChar
+			case '\244':
+				p.position++ // matchChar
+			case '\243':
+				p.position++ // matchChar
+			case '\242':
+				p.position++ // matchChar
+			case '\241':
+				p.position++ // matchChar
+			case '\240':
+				p.position++ // matchChar
+			case '\237':
+				p.position++ // matchChar
+			case '\236':
+				p.position++ // matchChar
+			case '\235':
+				p.position++ // matchChar
+			case '\234':
+				p.position++ // matchChar
+			case '\233':
+				p.position++ // matchChar
+			case '\232':
+				p.position++ // matchChar
+			case '\231':
+				p.position++ // matchChar
+			case '\230':
+				p.position++ // matchChar
+			case '\227':
+				p.position++ // matchChar
+			case '\226':
+				p.position++ // matchChar
+			case '\225':
+				p.position++ // matchChar
+			case '\224':
+				p.position++ // matchChar
+			case '\223':
+				p.position++ // matchChar
+			case '\222':
+				p.position++ // matchChar
+			case '\221':
+				p.position++ // matchChar
+			case '\220':
+				p.position++ // matchChar
+			case '\217':
+				p.position++ // matchChar
+			case '\216':
+				p.position++ // matchChar
+			case '\215':
+				p.position++ // matchChar
+			case '\214':
+				p.position++ // matchChar
+			case '\213':
+				p.position++ // matchChar
+			case '\212':
+				p.position++ // matchChar
+			case '\211':
+				p.position++ // matchChar
+			case '\210':
+				p.position++ // matchChar
+			case '\207':
+				p.position++ // matchChar
+			case '\206':
+				p.position++ // matchChar
+			case '\205':
+				p.position++ // matchChar
+			case '\204':
+				p.position++ // matchChar
+			case '\203':
+				p.position++ // matchChar
+			case '\202':
+				p.position++ // matchChar
+			case '\201':
+				p.position++ // matchChar
+			case '\200':
+				p.position++ // matchChar
+			default:
+				if !p.matchClass(4) {
+					return
+				}
+			}
+		}
+		match = true
+		return
+	}
+	/* 212 Digit <- [0-9] */
+	p.rules[ruleDigit] = func() (match bool) {
+		if !p.matchClass(0) {
+			return
+		}
+		match = true
+		return
+	}
+	/* 213 HexEntity <- (< '&' '#' [Xx] [0-9a-fA-F]+ ';' >) */
+	p.rules[ruleHexEntity] = func() (match bool) {
+		position0 := p.position
+		p.begin = p.position
+		if !p.matchChar('&') {
+			goto ko
+		}
+		if !p.matchChar('#') {
+			goto ko
+		}
+		if !p.matchClass(6) {
+			goto ko
+		}
+		if !p.matchClass(7) {
+			goto ko
+		}
+	loop:
+		if !p.matchClass(7) {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar(';') {
+			goto ko
+		}
+		p.end = p.position
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 214 DecEntity <- (< '&' '#' [0-9]+ > ';' >) */
+	p.rules[ruleDecEntity] = func() (match bool) {
+		position0 := p.position
+		p.begin = p.position
+		if !p.matchChar('&') {
+			goto ko
+		}
+		if !p.matchChar('#') {
+			goto ko
+		}
+		if !p.matchClass(0) {
+			goto ko
+		}
+	loop:
+		if !p.matchClass(0) {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		if !p.matchChar(';') {
+			goto ko
+		}
+		p.end = p.position
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 215 CharEntity <- (< '&' [A-Za-z0-9]+ ';' >) */
+	p.rules[ruleCharEntity] = func() (match bool) {
+		position0 := p.position
+		p.begin = p.position
+		if !p.matchChar('&') {
+			goto ko
+		}
+		if !p.matchClass(5) {
+			goto ko
+		}
+	loop:
+		if !p.matchClass(5) {
+			goto out
+		}
+		goto loop
+	out:
+		if !p.matchChar(';') {
+			goto ko
+		}
+		p.end = p.position
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 220 StartList <- (&. { yy = nil }) */
+	p.rules[ruleStartList] = func() (match bool) {
+		if !(p.position < len(p.Buffer)) {
+			return
+		}
+		p.do(89)
+		match = true
+		return
+	}
+	/* 221 Line <- (RawLine { yy = p.mkString(yytext) }) */
+	p.rules[ruleLine] = func() (match bool) {
+		position0 := p.position
+		if !p.rules[ruleRawLine]() {
+			goto ko
+		}
+		p.do(90)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 222 RawLine <- ((< (!'\r' !'\n' .)* Newline >) / (< .+ > !.)) */
+	p.rules[ruleRawLine] = func() (match bool) {
+		position0 := p.position
+		{
+			position1 := p.position
+			p.begin = p.position
+		loop:
+			if p.position == len(p.Buffer) {
+				goto out
+			}
+			switch p.Buffer[p.position] {
+			case '\r', '\n':
+				goto out
+			default:
+				p.position++
+			}
+			goto loop
+		out:
+			if !p.rules[ruleNewline]() {
+				goto nextAlt
+			}
+			p.end = p.position
+			goto ok
+		nextAlt:
+			p.position = position1
+			p.begin = p.position
+			if !p.matchDot() {
+				goto ko
+			}
+		loop5:
+			if !p.matchDot() {
+				goto out6
+			}
+			goto loop5
+		out6:
+			p.end = p.position
+			if p.position < len(p.Buffer) {
+				goto ko
+			}
+		}
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 224 ExtendedSpecialChar <- ((&[>|] (&{p.extension.Spoilers} ((&[>] '>') | (&[|] '|')))) | (&[^] (&{p.extension.Notes} '^')) | (&[\"\'\-.] (&{p.extension.Smart} ((&[\"] '"') | (&[\'] '\'') | (&[\-] '-') | (&[.] '.'))))) */
+	p.rules[ruleExtendedSpecialChar] = func() (match bool) {
+		position0 := p.position
+		{
+			if p.position == len(p.Buffer) {
+				goto ko
+			}
+			switch p.Buffer[p.position] {
+			case '>', '|':
+				if !(p.extension.Spoilers) {
+					goto ko
+				}
+				p.position++ // matchChar
+			case '^':
+				if !(p.extension.Notes) {
+					goto ko
+				}
+				if !p.matchChar('^') {
+					goto ko
+				}
+			default:
+				if !(p.extension.Smart) {
+					goto ko
+				}
+				{
+					if p.position == len(p.Buffer) {
+						goto ko
+					}
+					switch p.Buffer[p.position] {
+					case '"':
+						p.position++ // matchChar
+					case '\'':
+						p.position++ // matchChar
+					case '-':
+						p.position++ // matchChar
+					case '.':
+						p.position++ // matchChar
+					default:
+						goto ko
+					}
+				}
+			}
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 225 Smart <- (&{p.extension.Smart} (SingleQuoted / ((&[\'] Apostrophe) | (&[\"] DoubleQuoted) | (&[\-] Dash) | (&[.] Ellipsis)))) */
+	p.rules[ruleSmart] = func() (match bool) {
+		if !(p.extension.Smart) {
+			return
+		}
+		if !p.rules[ruleSingleQuoted]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		{
+			if p.position == len(p.Buffer) {
+				return
+			}
+			switch p.Buffer[p.position] {
+			case '\'':
+				if !p.rules[ruleApostrophe]() {
+					return
+				}
+			case '"':
+				if !p.rules[ruleDoubleQuoted]() {
+					return
+				}
+			case '-':
+				if !p.rules[ruleDash]() {
+					return
+				}
+			case '.':
+				if !p.rules[ruleEllipsis]() {
+					return
+				}
+			default:
+				return
+			}
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 226 Apostrophe <- ('\'' { yy = p.mkElem(APOSTROPHE) }) */
+	p.rules[ruleApostrophe] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('\'') {
+			goto ko
+		}
+		p.do(91)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 227 Ellipsis <- (('...' / '. . .') { yy = p.mkElem(ELLIPSIS) }) */
+	p.rules[ruleEllipsis] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("...") {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchString(". . .") {
+			goto ko
+		}
+	ok:
+		p.do(92)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 228 Dash <- (EmDash / EnDash) */
+	p.rules[ruleDash] = func() (match bool) {
+		if !p.rules[ruleEmDash]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.rules[ruleEnDash]() {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 229 EnDash <- ('-' &[0-9] { yy = p.mkElem(ENDASH) }) */
+	p.rules[ruleEnDash] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('-') {
+			goto ko
+		}
+		if !p.peekClass(0) {
+			goto ko
+		}
+		p.do(93)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 230 EmDash <- (('---' / '--') { yy = p.mkElem(EMDASH) }) */
+	p.rules[ruleEmDash] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("---") {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchString("--") {
+			goto ko
+		}
+	ok:
+		p.do(94)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 231 SingleQuoteStart <- ('\'' !((&[\n\r] Newline) | (&[\t ] Spacechar))) */
+	p.rules[ruleSingleQuoteStart] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('\'') {
+			goto ko
+		}
+		{
+			if p.position == len(p.Buffer) {
+				goto ok
+			}
+			switch p.Buffer[p.position] {
+			case '\n', '\r':
+				if !p.rules[ruleNewline]() {
+					goto ok
+				}
+			case '\t', ' ':
+				if !p.rules[ruleSpacechar]() {
+					goto ok
+				}
+			default:
+				goto ok
+			}
+		}
+		goto ko
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 232 SingleQuoteEnd <- ('\'' !Alphanumeric) */
+	p.rules[ruleSingleQuoteEnd] = func() (match bool) {
+		position0 := p.position
+		if !p.matchChar('\'') {
+			goto ko
+		}
+		if !p.rules[ruleAlphanumeric]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 233 SingleQuoted <- (SingleQuoteStart StartList (!SingleQuoteEnd Inline { a = cons(b, a) })+ SingleQuoteEnd { yy = p.mkList(SINGLEQUOTED, a) }) */
+	p.rules[ruleSingleQuoted] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.rules[ruleSingleQuoteStart]() {
+			goto ko
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.rules[ruleSingleQuoteEnd]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		p.do(95)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.rules[ruleSingleQuoteEnd]() {
+				goto ok4
+			}
+			goto out
+		ok4:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.doarg(yySet, -2)
+			p.do(95)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.rules[ruleSingleQuoteEnd]() {
+			goto ko
+		}
+		p.do(96)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 236 DoubleQuoted <- ('"' StartList (!'"' Inline { a = cons(b, a) })+ '"' { yy = p.mkList(DOUBLEQUOTED, a) }) */
+	p.rules[ruleDoubleQuoted] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.matchChar('"') {
+			goto ko
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if p.peekChar('"') {
+			goto ko
+		}
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		p.do(97)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if p.peekChar('"') {
+				goto out
+			}
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.doarg(yySet, -2)
+			p.do(97)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.matchChar('"') {
+			goto ko
+		}
+		p.do(98)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 237 NoteReference <- (&{p.extension.Notes} RawNoteReference {
+	    p.state.heap.hasGlobals = true
+	    if match, ok := p.find_note(ref.contents.str); ok {
+	        yy = p.mkElem(NOTE)
+	        yy.children = match.children
+	        yy.contents.str = ""
+	        yy.contents.link = &link{label: ref}
+	    } else {
+	        p.undefined("note", ref)
+	        yy = p.mkString("[^"+ref.contents.str+"]")
+	    }
+	}) */
+	p.rules[ruleNoteReference] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !(p.extension.Notes) {
+			goto ko
+		}
+		if !p.rules[ruleRawNoteReference]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		p.do(99)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 238 RawNoteReference <- ('[^' < (!Newline !']' .)+ > ']' { yy = p.mkString(yytext) }) */
+	p.rules[ruleRawNoteReference] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("[^") {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.rules[ruleNewline]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if p.peekChar(']') {
+			goto ko
+		}
+		if !p.matchDot() {
+			goto ko
+		}
+	loop:
+		{
+			position1 := p.position
+			if !p.rules[ruleNewline]() {
+				goto ok4
+			}
+			goto out
+		ok4:
+			if p.peekChar(']') {
+				goto out
+			}
+			if !p.matchDot() {
+				goto out
+			}
+			goto loop
+		out:
+			p.position = position1
+		}
+		p.end = p.position
+		if !p.matchChar(']') {
+			goto ko
+		}
+		p.do(100)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 240 InlineNote <- (&{p.extension.Notes} '^[' StartList (!']' Inline { a = cons(yy, a) })+ ']' { yy = p.mkList(NOTE, a)
+	   p.state.heap.hasGlobals = true
+	   yy.contents.str = "" }) */
+	p.rules[ruleInlineNote] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 1)
+		if !(p.extension.Notes) {
+			goto ko
+		}
+		if !p.matchString("^[") {
+			goto ko
+		}
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if p.peekChar(']') {
+			goto ko
+		}
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.do(104)
+	loop:
+		{
+			position1 := p.position
+			if p.peekChar(']') {
+				goto out
+			}
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.do(104)
+			goto loop
+		out:
+			p.position = position1
+		}
+		if !p.matchChar(']') {
+			goto ko
+		}
+		p.do(105)
+		p.doarg(yyPop, 1)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 255 SmartSymbol <- (&{p.extension.Smart} < ((&{p.extension.Primes} Prime) / (&{p.extension.Arrows} Arrow) / (&{p.extension.Symbols} '(' ([cC] / [rR] / ([tT] [mM])) ')') / (&{p.extension.Fractions} [1-9] '/' [1-9] !(Alphanumeric / '/'))) > { yy = p.mkString(smartSymbol(yytext)) }) */
+	p.rules[ruleSmartSymbol] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.Smart) {
+			goto ko
+		}
+		p.begin = p.position
+		if !(p.extension.Primes) {
+			goto nextAlt
+		}
+		if !p.rules[rulePrime]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !(p.extension.Arrows) {
+			goto nextAlt3
+		}
+		if !p.rules[ruleArrow]() {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !(p.extension.Symbols) {
+			goto nextAlt4
+		}
+		if !p.matchChar('(') {
+			goto nextAlt4
+		}
+		if !p.matchClass(10) {
+			goto nextAlt6
+		}
+		goto ok5
+	nextAlt6:
+		if !p.matchClass(11) {
+			goto nextAlt7
+		}
+		goto ok5
+	nextAlt7:
+		if !p.matchClass(12) {
+			goto nextAlt4
+		}
+		if !p.matchClass(13) {
+			goto nextAlt4
+		}
+	ok5:
+		if !p.matchChar(')') {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+		p.position = position0
+		if !(p.extension.Fractions) {
+			goto ko
+		}
+		if !p.matchClass(14) {
+			goto ko
+		}
+		if !p.matchChar('/') {
+			goto ko
+		}
+		if !p.matchClass(14) {
+			goto ko
+		}
+		if p.rules[ruleAlphanumeric]() {
+			goto ko
+		}
+		if p.peekChar('/') {
+			goto ko
+		}
+	ok:
+		p.end = p.position
+		p.do(119)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 256 Prime <- ([0-9]+ (('\'' [0-9]+ '"') / '\'' / '"') !Alphanumeric) */
+	p.rules[rulePrime] = func() (match bool) {
+		position0 := p.position
+		if !p.matchClass(0) {
+			goto ko
+		}
+	loop:
+		if !p.matchClass(0) {
+			goto out
+		}
+		goto loop
+	out:
+		{
+			position1 := p.position
+			if !p.matchChar('\'') {
+				goto nextAlt
+			}
+			if !p.matchClass(0) {
+				goto nextAlt
+			}
+		loop3:
+			if !p.matchClass(0) {
+				goto out4
+			}
+			goto loop3
+		out4:
+			if !p.matchChar('"') {
+				goto nextAlt
+			}
+			goto ok
+		nextAlt:
+			p.position = position1
+			if !p.matchChar('\'') {
+				goto nextAlt5
+			}
+			goto ok
+		nextAlt5:
+			if !p.matchChar('"') {
+				goto ko
+			}
+		}
+	ok:
+		if p.rules[ruleAlphanumeric]() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 257 Arrow <- ('<=>' / '<->' / '<-' / '->' / '=>') */
+	p.rules[ruleArrow] = func() (match bool) {
+		if !p.matchString("<=>") {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchString("<->") {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.matchString("<-") {
+			goto nextAlt4
+		}
+		goto ok
+	nextAlt4:
+		if !p.matchString("->") {
+			goto nextAlt5
+		}
+		goto ok
+	nextAlt5:
+		if !p.matchString("=>") {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 258 StrikeText <- ('~~' !Whitespace StartList (!'~~' Inline { a = cons(b, a) })+ '~~' { yy = p.mkList(STRIKE, a) }) */
+	p.rules[ruleStrikeText] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.matchString("~~") {
+			goto ko
+		}
+		if !p.rules[ruleWhitespace]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchString("~~") {
+			goto ok4
+		}
+		goto ko
+	ok4:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		p.do(70)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.matchString("~~") {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.doarg(yySet, -2)
+			p.do(70)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.matchString("~~") {
+			goto ko
+		}
+		p.do(71)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 259 IntrawordStars <- (&{p.extension.NoIntraEmphasis} '*'+ &Alphanumeric) */
+	p.rules[ruleIntrawordStars] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.NoIntraEmphasis) {
+			goto ko
+		}
+		if !p.matchChar('*') {
+			goto ko
+		}
+	loop:
+		if !p.matchChar('*') {
+			goto out
+		}
+		goto loop
+	out:
+		{
+			position1 := p.position
+			if !p.rules[ruleAlphanumeric]() {
+				goto ko
+			}
+			p.position = position1
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 261 RawText <- (&{p.extension.BlocksOnly} < RawChar+ > { yy = p.mkString(yytext) }) */
+	p.rules[ruleRawText] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.BlocksOnly) {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.rules[ruleRawChar]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleRawChar]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		p.do(121)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 262 RawChar <- (!(Sp '#'* Sp Newline) .) */
+	p.rules[ruleRawChar] = func() (match bool) {
+		position0 := p.position
+		{
+			position1 := p.position
+			if !p.rules[ruleSp]() {
+				goto ok
+			}
+		loop:
+			if !p.matchChar('#') {
+				goto out
+			}
+			goto loop
+		out:
+			if !p.rules[ruleSp]() {
+				goto ok
+			}
+			if !p.rules[ruleNewline]() {
+				goto ok
+			}
+			goto ko
+		ok:
+			p.position = position1
+		}
+		if !p.matchDot() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 263 IndexTerm <- (&{p.extension.Index} (('[[' < IndexChar+ > ']]') / ('\\index{' < IndexBraceChar+ > '}')) { yy = p.mkString(yytext)
+	   yy.key = INDEXTERM }) */
+	p.rules[ruleIndexTerm] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.Index) {
+			goto ko
+		}
+		if !p.matchString("[[") {
+			goto nextAlt
+		}
+		p.begin = p.position
+		if !p.rules[ruleIndexChar]() {
+			goto nextAlt
+		}
+	loop:
+		if !p.rules[ruleIndexChar]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		if !p.matchString("]]") {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		p.position = position0
+		if !p.matchString("\\index{") {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.rules[ruleIndexBraceChar]() {
+			goto ko
+		}
+	loop4:
+		if !p.rules[ruleIndexBraceChar]() {
+			goto out5
+		}
+		goto loop4
+	out5:
+		p.end = p.position
+		if !p.matchChar('}') {
+			goto ko
+		}
+	ok:
+		p.do(122)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 264 IndexChar <- (!']]' !Newline .) */
+	p.rules[ruleIndexChar] = func() (match bool) {
+		position0 := p.position
+		if !p.matchString("]]") {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleNewline]() {
+			goto ok2
+		}
+		goto ko
+	ok2:
+		if !p.matchDot() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 265 IndexBraceChar <- (!'}' !Newline .) */
+	p.rules[ruleIndexBraceChar] = func() (match bool) {
+		position0 := p.position
+		if p.peekChar('}') {
+			goto ko
+		}
+		if !p.rules[ruleNewline]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.matchDot() {
+			goto ko
+		}
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 266 Spoiler <- (&{p.extension.Spoilers} &{p.enterNested()} (((SpoilerBars / SpoilerMarks) &{p.leaveNested(true)}) / &{p.leaveNested(false)})) */
+	p.rules[ruleSpoiler] = func() (match bool) {
+		if !(p.extension.Spoilers) {
+			return
+		}
+		if !(p.enterNested()) {
+			return
+		}
+		if !p.rules[ruleSpoilerBars]() {
+			goto nextAlt3
+		}
+		goto ok4
+	nextAlt3:
+		if !p.rules[ruleSpoilerMarks]() {
+			goto nextAlt
+		}
+	ok4:
+		if !(p.leaveNested(true)) {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !(p.leaveNested(false)) {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+	/* 267 SpoilerBars <- ('||' !Whitespace StartList (!'||' Inline { a = cons(b, a) })+ '||' { yy = p.mkList(SPOILER, a) }) */
+	p.rules[ruleSpoilerBars] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.matchString("||") {
+			goto ko
+		}
+		if !p.rules[ruleWhitespace]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchString("||") {
+			goto ok4
+		}
+		goto ko
+	ok4:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		p.do(123)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.matchString("||") {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.doarg(yySet, -2)
+			p.do(123)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.matchString("||") {
+			goto ko
+		}
+		p.do(124)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 268 SpoilerMarks <- ('>!' !Whitespace StartList (!'!<' Inline { a = cons(b, a) })+ '!<' { yy = p.mkList(SPOILER, a) }) */
+	p.rules[ruleSpoilerMarks] = func() (match bool) {
+		position0, thunkPosition0 := p.position, p.thunkPosition
+		p.doarg(yyPush, 2)
+		if !p.matchString(">!") {
+			goto ko
+		}
+		if !p.rules[ruleWhitespace]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if !p.rules[ruleStartList]() {
+			goto ko
+		}
+		p.doarg(yySet, -1)
+		if !p.matchString("!<") {
+			goto ok4
+		}
+		goto ko
+	ok4:
+		if !p.rules[ruleInline]() {
+			goto ko
+		}
+		p.doarg(yySet, -2)
+		p.do(125)
+	loop:
+		{
+			position1, thunkPosition1 := p.position, p.thunkPosition
+			if !p.matchString("!<") {
+				goto ok5
+			}
+			goto out
+		ok5:
+			if !p.rules[ruleInline]() {
+				goto out
+			}
+			p.doarg(yySet, -2)
+			p.do(125)
+			goto loop
+		out:
+			p.position, p.thunkPosition = position1, thunkPosition1
+		}
+		if !p.matchString("!<") {
+			goto ko
+		}
+		p.do(126)
+		p.doarg(yyPop, 2)
+		match = true
+		return
+	ko:
+		p.position, p.thunkPosition = position0, thunkPosition0
+		return
+	}
+	/* 269 Tag <- (&{p.extension.Tags} &{p.tagBoundary(p.position)} < ('#' / '@') !Digit !'-' TagChar+ > { yy = p.mkTag(yytext) }) */
+	p.rules[ruleTag] = func() (match bool) {
+		position0 := p.position
+		if !(p.extension.Tags) {
+			goto ko
+		}
+		if !(p.tagBoundary(p.position)) {
+			goto ko
+		}
+		p.begin = p.position
+		if !p.matchChar('#') {
+			if !p.matchChar('@') {
+				goto ko
+			}
+		}
+		if !p.rules[ruleDigit]() {
+			goto ok
+		}
+		goto ko
+	ok:
+		if p.peekChar('-') {
+			goto ko
+		}
+		if !p.rules[ruleTagChar]() {
+			goto ko
+		}
+	loop:
+		if !p.rules[ruleTagChar]() {
+			goto out
+		}
+		goto loop
+	out:
+		p.end = p.position
+		p.do(127)
+		match = true
+		return
+	ko:
+		p.position = position0
+		return
+	}
+	/* 270 TagChar <- (Alphanumeric / '_' / '-') */
+	p.rules[ruleTagChar] = func() (match bool) {
+		if !p.rules[ruleAlphanumeric]() {
+			goto nextAlt
+		}
+		goto ok
+	nextAlt:
+		if !p.matchChar('_') {
+			goto nextAlt3
+		}
+		goto ok
+	nextAlt3:
+		if !p.matchChar('-') {
+			return
+		}
+	ok:
+		match = true
+		return
+	}
+}
@@ -0,0 +1,292 @@
+package markdown
+
+// Handling of link reference definitions.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Policies for reference and note labels that are defined more
+// than once, see Extensions.DupRefs and DupNotes.
+const (
+	DupRefFirst = iota // the first definition is used
+	DupRefLast         // the last definition is used
+	DupRefNone         // none of the definitions is used
+)
+
+/* checkReferences - reports reference labels defined more than once,
+ * and removes definitions from the list of references according
+ * to the DupRefs policy. The lines of the definitions are looked
+ * up in src.
+ */
+func (p *Parser) checkReferences(src sourceLines) {
+	yy := &p.yy
+	policy := yy.extension.DupRefs
+
+	/* Labels are compared using their keys, so that
+	 * documents with many definitions, like glossaries,
+	 * are checked in linear time.
+	 */
+	last := make(map[string]*Node) /* last definition of each label */
+	dups := make(map[string]bool)
+	for ref := yy.references; ref != nil; ref = ref.next {
+		url := ref.contents.link.url
+		ref.line = src.findDef(func(text string) bool {
+			if yy.extension.Notes && strings.HasPrefix(text, "[^") {
+				return false
+			}
+			i := strings.Index(text, "]:")
+			return i != -1 && strings.Contains(text[i:], url)
+		})
+		key, ok := refKey(ref.contents.link.label)
+		if !ok {
+			continue
+		}
+		if last[key] != nil {
+			yy.diags = append(yy.diags, Diagnostic{
+				Line: ref.line,
+				Code: "duplicate-reference",
+				Msg:  fmt.Sprintf("reference %q defined more than once", inlineText(ref.contents.link.label)),
+			})
+			dups[key] = true
+		}
+		last[key] = ref
+	}
+	if len(dups) == 0 || policy == DupRefFirst {
+		return
+	}
+
+	/* keep a definition only if it is not one of the duplicates, or,
+	 * with DupRefLast, if it is the last definition of its label
+	 */
+	keep := func(ref *Node) bool {
+		key, ok := refKey(ref.contents.link.label)
+		if !ok || !dups[key] {
+			return true
+		}
+		return policy == DupRefLast && last[key] == ref
+	}
+	list := &yy.references
+	for ref := yy.references; ref != nil; ref = ref.next {
+		if keep(ref) {
+			*list = ref
+			list = &ref.next
+		}
+	}
+	*list = nil
+}
+
+/* indexReferences, indexNotes - build the maps used to look up
+ * references and notes by label. If a label is defined more than
+ * once, the first definition in the list is used, which makes the
+ * result independent of the order of iteration over maps; as to
+ * references, checkReferences has already applied the DupRefs
+ * policy then.
+ */
+func (p *yyParser) indexReferences() {
+	p.refIndex = make(map[string]*link)
+	for ref := p.references; ref != nil; ref = ref.next {
+		l := ref.contents.link
+		if key, ok := refKey(l.label); ok {
+			if _, dup := p.refIndex[key]; !dup {
+				p.refIndex[key] = l
+			}
+		}
+	}
+}
+
+func (p *yyParser) indexNotes() {
+	p.noteIndex = make(map[string]*Node)
+	for el := p.notes; el != nil; el = el.next {
+		if _, dup := p.noteIndex[el.contents.str]; !dup {
+			p.noteIndex[el.contents.str] = el
+		}
+	}
+}
+
+/* checkNotes - like checkReferences, reports note labels
+ * defined more than once, and applies the DupNotes policy
+ * to the index of notes
+ */
+func (p *yyParser) checkNotes(src sourceLines) {
+	var dups []string
+	last := make(map[string]*Node)
+	for el := p.notes; el != nil; el = el.next {
+		label := el.contents.str
+		el.line = src.findDef(func(text string) bool {
+			return strings.HasPrefix(text, "[^"+label+"]:")
+		})
+		if last[label] != nil {
+			p.diags = append(p.diags, Diagnostic{
+				Line: el.line,
+				Code: "duplicate-note",
+				Msg:  fmt.Sprintf("note %q defined more than once", label),
+			})
+			dups = append(dups, label)
+		}
+		last[label] = el
+	}
+	for _, label := range dups {
+		switch p.extension.DupNotes {
+		case DupRefLast:
+			p.noteIndex[label] = last[label]
+		case DupRefNone:
+			delete(p.noteIndex, label)
+		}
+	}
+}
+
+/* breakNoteCycles - replaces references to notes within their own
+ * contents, direct or through other notes, by the text of the
+ * reference, as the contents would otherwise contain themselves
+ */
+func (p *yyParser) breakNoteCycles() {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[*Node]int) /* by the contents of notes */
+	var walk func(list *Node, line int)
+	visit := func(contents *Node, line int) {
+		state[contents] = visiting
+		walk(contents, line)
+		state[contents] = visited
+	}
+	walk = func(list *Node, line int) {
+		for el := list; el != nil; el = el.next {
+			l := line
+			if el.line > 0 {
+				l = el.line
+			}
+			if el.key == NOTE && el.contents.link != nil {
+				switch state[el.children] {
+				case visiting:
+					label := el.contents.link.label.contents.str
+					p.diags = append(p.diags, Diagnostic{
+						Line: l,
+						Code: "note-cycle",
+						Msg:  fmt.Sprintf("note %q referenced within its own contents", label),
+					})
+					el.key = STR
+					el.contents.str = "[^" + label + "]"
+					el.contents.link = nil
+					el.children = nil
+				case 0:
+					visit(el.children, l)
+				}
+				continue
+			}
+			walk(el.children, l)
+			if el.contents.link != nil {
+				walk(el.contents.link.label, l)
+			}
+		}
+	}
+	for el := p.notes; el != nil; el = el.next {
+		if el.children != nil && state[el.children] == 0 {
+			visit(el.children, el.line)
+		}
+	}
+}
+
+/* refKey - returns a key for a reference label, which is the same
+ * for labels that are equal, ignoring case; false for labels
+ * containing links or images, which never match
+ */
+func refKey(label *Node) (string, bool) {
+	var b strings.Builder
+	ok := writeRefKey(&b, label)
+	return b.String(), ok
+}
+
+/* sameLabel - reports whether two reference labels match
+ */
+func sameLabel(l1, l2 *Node) bool {
+	k1, ok := refKey(l1)
+	k2, ok2 := refKey(l2)
+	return ok && ok2 && k1 == k2
+}
+
+func writeRefKey(b *strings.Builder, list *Node) bool {
+	for el := list; el != nil; el = el.next {
+		b.WriteString(strconv.Itoa(el.key))
+		switch el.key {
+		case LINK, IMAGE, MEDIA, EMBED:
+			return false
+		}
+		if el.contents.str != "" {
+			/* the text of strings and code, but also the
+			 * names of elements like tags and mentions
+			 */
+			s := strings.ToUpper(el.contents.str)
+			b.WriteString(":" + strconv.Itoa(len(s)) + ":" + s)
+		}
+		if el.children != nil {
+			b.WriteByte('(')
+			if !writeRefKey(b, el.children) {
+				return false
+			}
+			b.WriteByte(')')
+		}
+		b.WriteByte(' ')
+	}
+	return true
+}
+
+// A Reference describes a link reference definition, like
+//
+//	[label]: http://example.org/ "Title"
+type Reference struct {
+	Label string // plain text of the label
+	URL   string
+	Title string
+}
+
+// References returns the link reference definitions of the
+// document, in the order of their appearance. Definitions
+// dropped according to Extensions.DupRefs are not included.
+func (d *Document) References() []Reference {
+	refs := make([]Reference, len(d.refs))
+	for i, l := range d.refs {
+		refs[i] = Reference{Label: inlineText(l.label), URL: l.url, Title: l.title}
+	}
+	return refs
+}
+
+// RewriteReferences calls fn for each link reference definition
+// of the document. Changes fn makes to the URL or title of
+// a definition are applied to all links and images that have been
+// resolved with it, and to the definition's source, so that, for
+// example, a corpus of documents can be migrated to a different
+// URL scheme by rewriting the definitions, and writing the
+// documents again using ToMarkdown. Links with
+// inline URLs are not affected, and neither are links whose URL
+// has been changed using SetURL. Changes to the label are ignored.
+func (d *Document) RewriteReferences(fn func(ref *Reference)) {
+	changed := make(map[*link]bool)
+	for _, l := range d.refs {
+		r := Reference{Label: inlineText(l.label), URL: l.url, Title: l.title}
+		fn(&r)
+		if r.URL != l.url || r.Title != l.title {
+			l.url = r.URL
+			l.title = r.Title
+			changed[l] = true
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+	for _, tree := range d.blocks {
+		walkElements(tree, func(el *Node) {
+			if el.key != LINK && el.key != IMAGE && el.key != MEDIA && el.key != EMBED && el.key != REFERENCE {
+				return
+			}
+			if l := el.contents.link; changed[l.ref] {
+				l.url = l.ref.url
+				l.title = l.ref.title
+			}
+		})
+	}
+}
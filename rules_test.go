@@ -0,0 +1,358 @@
+package markdown
+
+// Tests of single grammar rules.
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// matchRule runs a single rule of the grammar on s, using the
+// RuleTest rule, and returns the number of bytes matched, and
+// the value of the rule, as formatted by nodeString.
+func matchRule(x *Extensions, rule int, s string) (n int, value string, ok bool) {
+	p := NewParser(x)
+	p.yy.testRule = rule
+	p.yy.ResetBuffer(s)
+	if err := p.yy.Parse(ruleRuleTest); err != nil {
+		p.yy.ResetBuffer("")
+		return 0, "", false
+	}
+	rest := p.yy.ResetBuffer("")
+	value = nodeString(p.yy.tree)
+	p.yy.tree = nil
+	return len(s) - len(rest), value, true
+}
+
+// nodeString formats a list of nodes, like
+//
+//	EMPH(STR("a") SPACE STR("b"))
+func nodeString(list *Node) string {
+	var b strings.Builder
+	for n := list; n != nil; n = n.next {
+		if n != list {
+			b.WriteByte(' ')
+		}
+		if n.key < numVAL && keynames[n.key] != "" {
+			b.WriteString(keynames[n.key])
+		} else {
+			b.WriteString(strconv.Itoa(n.key))
+		}
+		if n.contents.str != "" && n.key != SPACE {
+			b.WriteString(strconv.Quote(n.contents.str))
+		}
+		children := n.children
+		if l := n.contents.link; l != nil && (n.key == LINK || n.key == IMAGE) {
+			b.WriteString("<" + l.url + ">")
+			children = l.label
+		}
+		if children != nil {
+			b.WriteString("(" + nodeString(children) + ")")
+		}
+	}
+	return b.String()
+}
+
+func TestRules(t *testing.T) {
+	for _, tc := range []struct {
+		x     *Extensions
+		rule  int
+		input string
+		n     int // -1 if the rule must not match
+		value string
+	}{
+		{nil, ruleStr, "abc def", 3, `STR"abc"`},
+		{nil, ruleEmph, "*a b* c", 5, `EMPH(STR"a" SPACE STR"b")`},
+		{nil, ruleEmph, "*a b", -1, ""},
+		{nil, ruleStrong, "__a__", 5, `STRONG(STR"a")`},
+		{nil, ruleCode, "``a`b``", 7, `CODE"a` + "`" + `b"`},
+		{nil, ruleLink, "[a](/u)", 7, `LINK</u>(STR"a")`},
+		{nil, ruleAtxHeading, "## Title ##\n", 12, `H2(STR"Title")`},
+		{nil, ruleHorizontalRule, "* * *\n\n", 7, `HRULE`},
+		{nil, ruleHorizontalRule, "* *\n", -1, ""},
+		{&Extensions{Strike: true}, ruleStrike, "~~a~~", 5, `STRIKE(STR"a")`},
+		{&Extensions{Tags: true}, ruleTag, "#go-lang.", 8, `TAG"go-lang"`},
+		{&Extensions{Tags: true}, ruleTag, "#1", -1, ""},
+		{nil, ruleTag, "#go", -1, ""},
+		{&Extensions{Examples: true}, ruleExampleEnumerator, "(@good) x", 7, ""},
+		{nil, ruleExampleEnumerator, "(@) x", -1, ""},
+	} {
+		n, value, ok := matchRule(tc.x, tc.rule, tc.input)
+		switch {
+		case tc.n == -1 && ok:
+			t.Errorf("rule %d, %q: unexpected match of %d bytes: %s", tc.rule, tc.input, n, value)
+		case tc.n == -1:
+		case !ok:
+			t.Errorf("rule %d, %q: no match", tc.rule, tc.input)
+		case n != tc.n || value != tc.value:
+			t.Errorf("rule %d, %q: %d bytes matched: %s", tc.rule, tc.input, n, value)
+		}
+	}
+}
+
+// TestKeynames checks that keynames has an entry for each type
+// of element, named like its constant in parser.leg, so that
+// nodeString does not print bare numbers for new types.
+func TestKeynames(t *testing.T) {
+	leg, err := os.ReadFile("parser.leg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(leg)
+	i := strings.Index(s, "\tLIST = iota")
+	j := strings.Index(s, "\tnumVAL\n")
+	if i == -1 || j < i {
+		t.Fatal("element types not found in parser.leg")
+	}
+	key := 0
+	for _, line := range strings.Split(s[i:j], "\n") {
+		f := strings.Fields(line)
+		if len(f) == 0 || strings.HasPrefix(f[0], "/*") {
+			continue
+		}
+		if key < numVAL && keynames[key] != f[0] {
+			t.Errorf("keynames[%s] is %q", f[0], keynames[key])
+		}
+		key++
+	}
+	if key != numVAL {
+		t.Errorf("found %d element types in parser.leg, numVAL is %d", key, numVAL)
+	}
+}
+
+// generatedFiles returns the names of the files gen.go writes:
+// parser.leg.go, and the ones holding the rules by area.
+func generatedFiles(t *testing.T) []string {
+	names, err := filepath.Glob("parser.*.go")
+	if err != nil || len(names) == 0 {
+		t.Fatal("generated files not found")
+	}
+	return names
+}
+
+// TestGeneratedParser checks that the Go code of the grammar, the
+// actions and predicates of its rules, and the code preceding and
+// following them, is part of the generated files, so that a change
+// made to parser.leg, but not to the generated code, or the other
+// way round, is noticed even if leg is not at hand.
+func TestGeneratedParser(t *testing.T) {
+	leg, err := os.ReadFile("parser.leg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var code string
+	for _, name := range generatedFiles(t) {
+		gen, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		code += goTokens(string(gen)) + "\n"
+	}
+
+	s := string(leg)
+	i := strings.Index(s, "\n%}\n")
+	j := strings.Index(s, "\n%%\n")
+	if !strings.HasPrefix(s, "%{\n") || i == -1 || j < i {
+		t.Fatal("sections of parser.leg not found")
+	}
+	parts := []string{s[3:i], s[j+4:]}
+	parts = append(parts, legActions(s[i+4:j])...)
+	for _, part := range parts {
+		if !strings.Contains(code, goTokens(strings.ReplaceAll(part, "$$", "yy"))) {
+			t.Errorf("code of parser.leg not found in the generated files:\n%s", part)
+		}
+	}
+}
+
+// parserInterface lists the names defined outside of parser.leg
+// that are used by the actions of the grammar, as of version
+// parserInterfaceOf of the interface: functions, constants, and
+// the like, methods of yyParser, and fields of Extensions.
+const (
+	parserInterfaceOf = 20
+	parserInterface   = `
+		Extensions.AllEscapes Extensions.Arrows
+		Extensions.BlocksOnly Extensions.CodeTabs
+		Extensions.Comments Extensions.Containers Extensions.Dlists
+		Extensions.Examples Extensions.FancyLists
+		Extensions.FilterStyles Extensions.Fractions
+		Extensions.GridTables Extensions.Index Extensions.LaxSublists
+		Extensions.NoIntraEmphasis Extensions.Notes
+		Extensions.Primes Extensions.Smart Extensions.Spoilers
+		Extensions.Strike Extensions.Symbols Extensions.Tags
+		escapeTags expandTabs p.attrBoundary p.autoLinkURL
+		p.blockTags p.imageKey p.mkComment p.mkContainer
+		p.mkGridTable p.mkTag p.numericEntity p.rawHTML
+		p.tagBoundary smartSymbol
+	`
+)
+
+// TestParserInterface checks that ParserInterfaceVersion has been
+// increased if the interface between the grammar and the rest of
+// the package has changed.
+func TestParserInterface(t *testing.T) {
+	leg, err := os.ReadFile("parser.leg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(leg)
+	i := strings.Index(s, "\n%}\n")
+	j := strings.Index(s, "\n%%\n")
+	if !strings.HasPrefix(s, "%{\n") || i == -1 || j < i {
+		t.Fatal("sections of parser.leg not found")
+	}
+
+	generated := make(map[string]bool)
+	for _, name := range generatedFiles(t) {
+		generated[name] = true
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi fs.FileInfo) bool {
+		name := fi.Name()
+		return !strings.HasSuffix(name, "_test.go") && !generated[name] && name != "gen.go"
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defined := make(map[string]bool)
+	for _, f := range pkgs["markdown"].Files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					defined[d.Name.Name] = true
+				} else {
+					defined["p."+d.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch sp := spec.(type) {
+					case *ast.ValueSpec:
+						for _, n := range sp.Names {
+							defined[n.Name] = true
+						}
+					case *ast.TypeSpec:
+						defined[sp.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, a := range legActions(s[i+4 : j]) {
+		toks := strings.Fields(goTokens(strings.ReplaceAll(a, "$$", "yy")))
+		for k, tok := range toks {
+			switch {
+			case k > 0 && toks[k-1] == ".":
+			case tok == "p" && k+2 < len(toks) && toks[k+1] == ".":
+				name := "p." + toks[k+2]
+				if name == "p.extension" && k+4 < len(toks) {
+					used["Extensions."+toks[k+4]] = true
+				} else if defined[name] {
+					used[name] = true
+				}
+			case defined[tok]:
+				used[tok] = true
+			}
+		}
+	}
+	var names []string
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	iface := strings.Join(names, " ")
+
+	switch {
+	case iface != strings.Join(strings.Fields(parserInterface), " "):
+		t.Errorf("the interface of the grammar has changed; increase ParserInterfaceVersion, and update parserInterface and parserInterfaceOf:\n%s", iface)
+	case ParserInterfaceVersion != parserInterfaceOf:
+		t.Errorf("ParserInterfaceVersion is %d, the interface is recorded for version %d", ParserInterfaceVersion, parserInterfaceOf)
+	}
+}
+
+// goTokens returns the tokens of Go code, separated by blanks,
+// without comments and semicolons, so that code formatted by
+// gofmt, as the generated parser, compares equal to the original.
+func goTokens(src string) string {
+	var s scanner.Scanner
+	var b strings.Builder
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", -1, len(src)), []byte(src), nil, 0)
+	for {
+		_, tok, lit := s.Scan()
+		switch tok {
+		case token.EOF:
+			return b.String()
+		case token.SEMICOLON:
+			continue
+		}
+		if lit == "" {
+			lit = tok.String()
+		}
+		b.WriteString(lit + " ")
+	}
+}
+
+// legActions returns the code of the actions and predicates of
+// the rules of a leg grammar, skipping literals, character
+// classes, and comments.
+func legActions(rules string) (actions []string) {
+	for i := 0; i < len(rules); i++ {
+		switch c := rules[i]; c {
+		case '#':
+			for i < len(rules) && rules[i] != '\n' {
+				i++
+			}
+		case '\'', '"', '[':
+			end := c
+			if c == '[' {
+				end = ']'
+			}
+			for i++; i < len(rules) && rules[i] != end; i++ {
+				if rules[i] == '\\' {
+					i++
+				}
+			}
+		case '{':
+			n := goBlockLen(rules[i:])
+			actions = append(actions, rules[i+1:i+n-1])
+			i += n - 1
+		}
+	}
+	return
+}
+
+// goBlockLen returns the length of the block of Go code at the
+// start of s, from '{' to the matching '}'.
+func goBlockLen(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case '"', '\'', '`':
+			for i++; i < len(s) && s[i] != c; i++ {
+				if s[i] == '\\' && c != '`' {
+					i++
+				}
+			}
+		}
+	}
+	return len(s)
+}
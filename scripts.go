@@ -0,0 +1,133 @@
+package markdown
+
+// Handling of <script> elements.
+
+import (
+	"strings"
+)
+
+// Policies for <script> elements, see Extensions.Scripts.
+const (
+	ScriptsDefault = iota // scripts are raw HTML, subject to FilterHTML and AllowedHTML
+	ScriptsDrop           // scripts are removed
+	ScriptsPass           // scripts are kept, even if other HTML is filtered
+	ScriptsCollect        // scripts are removed, and returned by Document.Scripts
+)
+
+/* isScript - reports whether s, apart from surrounding white space,
+ * is a single <script> element
+ */
+func isScript(s string) bool {
+	rest, scripts := removeScripts(strings.TrimSpace(s))
+	return len(scripts) == 1 && rest == ""
+}
+
+/* removeScripts - removes the <script> elements from raw HTML,
+ * and returns them, including their tags, in the order of their
+ * occurrence. An element that is not closed extends to the end
+ * of s, as it would in a browser. Comments, in which scripts
+ * are inert, are kept as they are.
+ */
+func removeScripts(s string) (rest string, scripts []string) {
+	var b strings.Builder
+
+	for {
+		i := strings.IndexByte(s, '<')
+		if i == -1 {
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+		n := 1
+		if strings.HasPrefix(s, "<!--") {
+			if n = commentLen(s); n == -1 {
+				n = len(s)
+			}
+		} else if t, tn, ok := parseTag(s); ok && t.name == "script" && !t.closing {
+			end := skipElement(s[tn:], "script")
+			scripts = append(scripts, s[:len(s)-len(end)])
+			s = end
+			continue
+		}
+		b.WriteString(s[:n])
+		s = s[n:]
+	}
+	b.WriteString(s)
+	return b.String(), scripts
+}
+
+/* rawHTML - returns the element for raw HTML text; key is HTML
+ * or HTMLBLOCK. Scripts are handled according to Extensions.Scripts.
+ * What remains is dropped if HTML is filtered, otherwise tags that
+ * are not allowed are escaped.
+ */
+func (p *yyParser) rawHTML(text string, key int) *Node {
+	if el, ok := p.script(text, key); ok {
+		return el
+	}
+	var scripts []string
+	switch p.extension.Scripts {
+	case ScriptsDrop, ScriptsCollect:
+		text, scripts = removeScripts(text)
+	}
+
+	var el *Node
+	switch {
+	case p.extension.FilterHTML, key == HTMLBLOCK && p.extension.FilterHTMLBlocks:
+		el = p.mkList(LIST, nil)
+	case key == HTMLBLOCK:
+		el = p.mkString(escapeTags(text, p.blockTags()))
+		el.key = key
+	default:
+		el = p.mkString(escapeTags(text, p.extension.AllowedHTML))
+		el.key = key
+	}
+	if len(scripts) == 0 || p.extension.Scripts == ScriptsDrop {
+		return el
+	}
+
+	/* collected scripts follow the remaining HTML */
+	list := el
+	for _, script := range scripts {
+		s := p.mkString(script)
+		s.key = SCRIPT
+		list = cons(s, list)
+	}
+	return p.mkList(LIST, list)
+}
+
+/* script - returns the element for raw HTML text, if it is a
+ * script that is handled according to Extensions.Scripts;
+ * key is HTML or HTMLBLOCK
+ */
+func (p *yyParser) script(text string, key int) (el *Node, ok bool) {
+	if p.extension.Scripts == ScriptsDefault || !isScript(text) {
+		return nil, false
+	}
+	switch p.extension.Scripts {
+	case ScriptsDrop:
+		el = p.mkList(LIST, nil)
+	case ScriptsPass:
+		el = p.mkString(text)
+		el.key = key
+	default:
+		el = p.mkString(strings.TrimSpace(text))
+		el.key = SCRIPT
+	}
+	return el, true
+}
+
+// Scripts returns the contents of the <script> elements of the
+// document, including the tags, in the order of their occurrence,
+// if they have been collected using ScriptsCollect.
+func (d *Document) Scripts() []string {
+	var scripts []string
+	for _, tree := range d.blocks {
+		walkElements(tree, func(el *Node) {
+			if el.key == SCRIPT {
+				scripts = append(scripts, el.contents.str)
+			}
+		})
+	}
+	return scripts
+}
@@ -0,0 +1,47 @@
+package markdown
+
+// Typographic replacements of the Smart extension.
+
+import (
+	"strings"
+)
+
+var smartSymbols = map[string]string{
+	"<=>":  "⇔",
+	"<->":  "↔",
+	"<-":   "←",
+	"->":   "→",
+	"=>":   "⇒",
+	"(c)":  "©",
+	"(r)":  "®",
+	"(tm)": "™",
+	"1/2":  "½",
+	"1/3":  "⅓",
+	"2/3":  "⅔",
+	"1/4":  "¼",
+	"3/4":  "¾",
+	"1/5":  "⅕",
+	"2/5":  "⅖",
+	"3/5":  "⅗",
+	"4/5":  "⅘",
+	"1/6":  "⅙",
+	"5/6":  "⅚",
+	"1/8":  "⅛",
+	"3/8":  "⅜",
+	"5/8":  "⅝",
+	"7/8":  "⅞",
+}
+
+/* smartSymbol - returns the replacement for text matched by
+ * the SmartSymbol rule. Primes are recognized by their leading
+ * digit; fractions without a glyph of their own are kept.
+ */
+func smartSymbol(s string) string {
+	if r, ok := smartSymbols[strings.ToLower(s)]; ok {
+		return r
+	}
+	if s[0] >= '0' && s[0] <= '9' && !strings.Contains(s, "/") {
+		return strings.NewReplacer("'", "′", `"`, "″").Replace(s)
+	}
+	return s
+}
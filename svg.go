@@ -0,0 +1,140 @@
+package markdown
+
+// Rendering of SVG images.
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// Renderings of images with a .svg URL, see HTMLOptions.SVG.
+const (
+	SVGImg    = iota // an <img> element, as for other images
+	SVGObject        // an <object> element, containing the alternative text
+	SVGInline        // the SVG document, read from HTMLOptions.SVGFS
+)
+
+// DefaultSVGMaxInline is the size in bytes of the largest SVG
+// document inlined, if HTMLOptions.SVGMaxInline is zero.
+const DefaultSVGMaxInline = 16 << 10
+
+/* isSVGURL - reports whether the path of a URL ends in .svg
+ */
+func isSVGURL(url string) bool {
+	if i := strings.IndexAny(url, "?#"); i != -1 {
+		url = url[:i]
+	}
+	return strings.HasSuffix(strings.ToLower(url), ".svg") && !strings.HasPrefix(strings.ToLower(url), "data:")
+}
+
+/* svg - renders an image with a .svg URL according to
+ * HTMLOptions.SVG. It returns false if the image is to be
+ * rendered as an <img> element.
+ */
+func (w *htmlOut) svg(elt *Node) bool {
+	l := elt.contents.link
+	switch w.opt.SVG {
+	case SVGObject:
+		if w.opt.SVGFS != nil {
+			if doc, ok := w.readSVG(elt); ok {
+				w.checkSVG(elt, doc)
+			}
+		}
+		w.s(`<object data="`).str(l.url).s(`" type="image/svg+xml"`)
+		w.title(l.title)
+		w.s(">").elist(l.label).s("</object>")
+		return true
+	case SVGInline:
+		doc, ok := w.readSVG(elt)
+		if !ok {
+			return false
+		}
+		w.checkSVG(elt, doc)
+		if w.opt.StrictCSP {
+			doc = filterCSP(doc)
+		}
+		w.s("<svg")
+		if alt := inlineText(l.label); alt != "" {
+			w.s(` role="img" aria-label="`).str(alt).s(`"`)
+		}
+		w.s(doc[len("<svg"):])
+		return true
+	}
+	return false
+}
+
+/* readSVG - reads the SVG document an image refers to from
+ * HTMLOptions.SVGFS, and returns it starting at the <svg> tag.
+ * Missing or oversized documents are reported to SVGWarning.
+ */
+func (w *htmlOut) readSVG(elt *Node) (doc string, ok bool) {
+	url := elt.contents.link.url
+	if i := strings.IndexAny(url, "?#"); i != -1 {
+		url = url[:i]
+	}
+	name := path.Clean(strings.TrimPrefix(url, "/"))
+	if w.opt.SVGFS == nil || strings.Contains(url, ":") || strings.HasPrefix(url, "//") || !fs.ValidPath(name) {
+		w.svgWarning(elt, "not found in SVGFS")
+		return "", false
+	}
+	f, err := w.opt.SVGFS.Open(name)
+	if err != nil {
+		w.svgWarning(elt, err.Error())
+		return "", false
+	}
+	defer f.Close()
+
+	max := w.opt.SVGMaxInline
+	if max == 0 {
+		max = DefaultSVGMaxInline
+	}
+	b, err := io.ReadAll(io.LimitReader(f, int64(max)+1))
+	switch {
+	case err != nil:
+		w.svgWarning(elt, err.Error())
+		return "", false
+	case len(b) > max:
+		w.svgWarning(elt, "larger than SVGMaxInline")
+		return "", false
+	}
+	doc = string(b)
+	i := strings.Index(doc, "<svg")
+	if i == -1 {
+		w.svgWarning(elt, "no <svg> element")
+		return "", false
+	}
+	return strings.TrimSpace(doc[i:]), true
+}
+
+/* checkSVG - reports scripts, event handlers, and javascript:
+ * URLs within an SVG document to SVGWarning
+ */
+func (w *htmlOut) checkSVG(elt *Node, doc string) {
+	var reason string
+	filterHTML(doc, func(t *htmlTag, raw string) (string, bool) {
+		if t.name == "script" && reason == "" {
+			reason = "contains a script"
+		}
+		for _, a := range t.attrs {
+			switch {
+			case reason != "":
+			case strings.HasPrefix(a.name, "on"):
+				reason = "contains an event handler"
+			case isJavascriptURL(a.value):
+				reason = "contains a javascript: URL"
+			}
+		}
+		return raw, false
+	})
+	if reason != "" {
+		w.svgWarning(elt, reason)
+	}
+}
+
+func (w *htmlOut) svgWarning(elt *Node, reason string) {
+	if w.opt.SVGWarning != nil {
+		w.opt.SVGWarning(newImage(elt), reason)
+	}
+}
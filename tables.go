@@ -0,0 +1,117 @@
+package markdown
+
+// Grid tables, like
+//	+-------+----------+
+//	| Name  | Notes    |
+//	+=======+==========+
+//	| peg   | - fast   |
+//	|       | - small  |
+//	+-------+----------+
+
+import (
+	"strings"
+)
+
+/* mkGridTable - creates a TABLE element from the text of a grid
+ * table. The columns are those of the table's top border. The
+ * lines of a row are cut at the column boundaries, and the text of
+ * each cell is parsed later, as RAW text, so that it may contain
+ * blocks. Rows above a border made of '=' are the header rows,
+ * which are collected in a TABLEHEAD element.
+ */
+func (p *yyParser) mkGridTable(s string) *Node {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimLeft(l, " ")
+	}
+	var cols []int
+	for i, r := range []rune(lines[0]) {
+		if r == '+' {
+			cols = append(cols, i)
+		}
+	}
+
+	var list *Node
+	var cells [][]string /* lines of the cells of the current row */
+	head := false
+	for _, l := range lines[1:] {
+		if l[0] == '|' {
+			r := []rune(l)
+			if cells == nil {
+				cells = make([][]string, len(cols)-1)
+			}
+			for i := range cells {
+				cells[i] = append(cells[i], cellLine(r, cols[i]+1, cols[i+1]))
+			}
+			continue
+		}
+		list = cons(p.mkTableRow(cells), list)
+		cells = nil
+		if !head && strings.Contains(l, "=") {
+			list = cons(p.mkList(TABLEHEAD, list), nil)
+			head = true
+		}
+	}
+	return p.mkList(TABLE, list)
+}
+
+/* mkTableRow - creates a TABLEROW element from the lines of
+ * its cells
+ */
+func (p *yyParser) mkTableRow(cells [][]string) *Node {
+	var list *Node
+	for _, lines := range cells {
+		cell := p.mkElem(TABLECELL)
+		if text := dedent(lines); text != "" {
+			raw := p.mkString(text + "\n")
+			raw.key = RAW
+			cell.children = raw
+		}
+		list = cons(cell, list)
+	}
+	return p.mkList(TABLEROW, list)
+}
+
+/* cellLine - returns the part of a table row's line between
+ * the columns from and to, without trailing white space
+ */
+func cellLine(line []rune, from, to int) string {
+	if from >= len(line) {
+		return ""
+	}
+	if to > len(line) {
+		to = len(line)
+	}
+	return strings.TrimRight(string(line[from:to]), " \t")
+}
+
+/* dedent - joins lines, removing the indentation they have
+ * in common, and leading and trailing blank lines
+ */
+func dedent(lines []string) string {
+	for len(lines) != 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) != 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	indent := -1
+	for _, l := range lines {
+		if l == "" {
+			continue
+		}
+		if n := len(l) - len(strings.TrimLeft(l, " ")); indent == -1 || n < indent {
+			indent = n
+		}
+	}
+	var b strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if l != "" {
+			b.WriteString(l[indent:])
+		}
+	}
+	return b.String()
+}
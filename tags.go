@@ -0,0 +1,72 @@
+package markdown
+
+// Hashtags and mentions, like #golang or @alice.
+
+/* tagBoundary - returns true if a hashtag or mention may start at
+ * pos, i.e. if it is not preceded by a part of a word, as in
+ * C#sharp or me@example.org, or by the '&' of an entity
+ */
+func (p *yyParser) tagBoundary(pos int) bool {
+	if pos == 0 {
+		return true
+	}
+	c := p.Buffer[pos-1]
+	return !(isAlnum(c) || c == '_' || c == '&' || c >= 0x80)
+}
+
+/* mkTag - creates a TAG or MENTION element for text like #name
+ * or @name. Matches rejected by ResolveTag are kept as text.
+ */
+func (p *yyParser) mkTag(s string) *Node {
+	key := TAG
+	if s[0] == '@' {
+		key = MENTION
+	}
+	url := ""
+	if resolve := p.extension.ResolveTag; resolve != nil {
+		u, ok := resolve(key, s[1:])
+		if !ok {
+			return p.mkString(s)
+		}
+		url = u
+	}
+	el := p.mkElem(key)
+	el.contents.str = s[1:]
+	if url != "" {
+		el.contents.link = &link{url: url}
+	}
+	return el
+}
+
+/* tagText - returns the text of a TAG or MENTION element,
+ * including the leading '#' or '@'
+ */
+func tagText(el *Node) string {
+	if el.key == MENTION {
+		return "@" + el.contents.str
+	}
+	return "#" + el.contents.str
+}
+
+// A Tag describes a hashtag or mention recognized
+// using the Tags extension.
+type Tag struct {
+	Key  int    // TAG or MENTION
+	Name string // the name, without '#' or '@'
+	URL  string // the URL supplied by ResolveTag, if any
+	Line int    // line number of the top-level block containing the tag
+}
+
+// Tags returns the hashtags and mentions of the document,
+// in the order of their occurrence.
+func (d *Document) Tags() []Tag {
+	var tags []Tag
+	for _, tree := range d.blocks {
+		walkElements(tree, func(el *Node) {
+			if el.key == TAG || el.key == MENTION {
+				tags = append(tags, Tag{Key: el.key, Name: el.contents.str, URL: el.URL(), Line: tree.line})
+			}
+		})
+	}
+	return tags
+}
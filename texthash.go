@@ -0,0 +1,45 @@
+package markdown
+
+// Hashes of a document's text.
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// TextHash returns a hash of the document's plain text, so that
+// documents differing only in formatting, like emphasis, the
+// style of headings or lists, or the wrapping of lines, can be
+// detected as duplicates. Runs of white space are treated as a
+// single space, markup like raw HTML blocks, link references,
+// and URLs is ignored, and the text of notes is not included.
+// As smart punctuation is part of the text, documents to be
+// compared should be parsed with the same Extensions.
+func (d *Document) TextHash() string {
+	var b strings.Builder
+	for _, tree := range d.blocks {
+		writeBlockText(&b, tree)
+	}
+	h := sha256.Sum256([]byte(strings.Join(strings.Fields(b.String()), " ")))
+	return hex.EncodeToString(h[:])
+}
+
+/* writeBlockText - writes the plain text of a list of blocks
+ * to b, separating blocks by newlines
+ */
+func writeBlockText(b *strings.Builder, list *Node) {
+	for ; list != nil; list = list.next {
+		switch list.key {
+		case PLAIN, PARA, H1, H2, H3, H4, H5, H6, DEFTITLE, CITATIONLINE:
+			b.WriteString(inlineText(list.children))
+			b.WriteByte('\n')
+		case VERBATIM:
+			b.WriteString(list.contents.str)
+			b.WriteByte('\n')
+		case HTMLBLOCK, HRULE, REFERENCE, NOTE, TOC:
+		default:
+			writeBlockText(b, list.children)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package markdown
+
+// Table of contents support.
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/* isTOCPlaceholder - returns true if a block consists of nothing but
+ * a `[TOC]' or `{{TOC}}' token.
+ */
+func isTOCPlaceholder(block *Node) bool {
+	if block.key != PARA && block.key != PLAIN {
+		return false
+	}
+	switch strings.TrimSpace(inlineText(block.children)) {
+	case "[TOC]", "{{TOC}}":
+		return true
+	}
+	return false
+}
+
+/* inlineText - concatenates the text contained in a list of
+ * inline elements, ignoring any formatting. Quotes, dashes, and
+ * ellipses recognized by the Smart extension are represented
+ * by the corresponding Unicode characters.
+ */
+func inlineText(list *Node) string {
+	var b strings.Builder
+	var walk func(*Node)
+
+	walk = func(l *Node) {
+		for ; l != nil; l = l.next {
+			switch l.key {
+			case STR, SPACE, CODE:
+				b.WriteString(l.contents.str)
+			case LINK, IMAGE, MEDIA, EMBED:
+				walk(l.contents.link.label)
+			case TAG, MENTION:
+				b.WriteString(tagText(l))
+			case APOSTROPHE:
+				b.WriteString("’")
+			case SINGLEQUOTED:
+				b.WriteString("‘")
+				walk(l.children)
+				b.WriteString("’")
+			case DOUBLEQUOTED:
+				b.WriteString("“")
+				walk(l.children)
+				b.WriteString("”")
+			case ELLIPSIS:
+				b.WriteString("…")
+			case EMDASH:
+				b.WriteString("—")
+			case ENDASH:
+				b.WriteString("–")
+			case NOTE, HTML:
+			default:
+				walk(l.children)
+			}
+		}
+	}
+	walk(list)
+	return b.String()
+}
+
+// Slugify derives an identifier from the plain text of a heading,
+// the same way GitHub does: the text is lower-cased, letters,
+// digits, underscores, and dashes are kept, each space is turned
+// into a dash, and anything else is dropped. If nothing is left,
+// "section" is returned. Slugify is the default for the Slugify
+// fields of Extensions and HTMLOptions.
+func Slugify(s string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_' || r == '-':
+			b.WriteRune(r)
+		case r == ' ' || r == '\n':
+			b.WriteByte('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "section"
+	}
+	return b.String()
+}
+
+/* headingIDs - hands out unique identifiers for headings,
+ * derived from their text using a slug function. Repeated
+ * slugs get a numeric suffix.
+ */
+type headingIDs struct {
+	slugify func(string) string
+	seen    map[string]int
+}
+
+func newHeadingIDs(slugify func(string) string) *headingIDs {
+	if slugify == nil {
+		slugify = Slugify
+	}
+	return &headingIDs{slugify: slugify, seen: make(map[string]int)}
+}
+
+func (ids *headingIDs) make(text string) string {
+	id := ids.slugify(text)
+	n := ids.seen[id]
+	ids.seen[id] = n + 1
+	if n != 0 {
+		id += "-" + strconv.Itoa(n)
+	}
+	return id
+}
+
+/* makeTOC - assigns an id to each heading found in blocks, and
+ * replaces any TOC placeholder by a bullet list of links
+ * pointing to the headings. Nesting of the list follows
+ * the heading levels.
+ *
+ * The id of a heading is stored in its contents.str field.
+ */
+func (p *Parser) makeTOC(blocks []*Node) {
+	var placeholders []*Node
+	var headings []*Node
+
+	ids := newHeadingIDs(p.yy.extension.Slugify)
+	for _, b := range blocks {
+		for ; b != nil; b = b.next {
+			switch b.key {
+			case H1, H2, H3, H4, H5, H6:
+				b.contents.str = ids.make(inlineText(b.children))
+				headings = append(headings, b)
+			default:
+				if isTOCPlaceholder(b) {
+					placeholders = append(placeholders, b)
+				}
+			}
+		}
+	}
+	if len(placeholders) == 0 {
+		return
+	}
+
+	list := p.tocList(headings)
+	for _, el := range placeholders {
+		el.key = TOC
+		el.children = list
+	}
+}
+
+/* tocList - builds a (nested) bullet list from a list of headings
+ */
+func (p *Parser) tocList(headings []*Node) *Node {
+	type level struct {
+		key  int
+		list *Node // BULLETLIST
+		last *Node // last LISTITEM of list
+	}
+	var stack []level
+
+	yy := &p.yy
+	for _, h := range headings {
+		for n := len(stack); n > 1 && h.key <= stack[n-2].key; n-- {
+			stack = stack[:n-1]
+		}
+		if n := len(stack); n == 0 || h.key > stack[n-1].key {
+			list := yy.mkElem(BULLETLIST)
+			if n != 0 {
+				stack[n-1].last.children.next = list
+			}
+			stack = append(stack, level{key: h.key, list: list})
+		}
+		top := &stack[len(stack)-1]
+
+		plain := yy.mkElem(PLAIN)
+		plain.children = yy.mkLink(h.children, "#"+h.contents.str, "")
+		item := yy.mkElem(LISTITEM)
+		item.children = plain
+		if top.last == nil {
+			top.list.children = item
+		} else {
+			top.last.next = item
+		}
+		top.last = item
+	}
+	if len(stack) == 0 {
+		return nil
+	}
+	return stack[0].list
+}
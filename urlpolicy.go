@@ -0,0 +1,74 @@
+package markdown
+
+// Restrictions of the URLs of links and images.
+
+import (
+	"html"
+	"strings"
+)
+
+// A URLPolicy, set as HTMLOptions.URLs, restricts the URLs of
+// links and images in documents from untrusted sources, like
+// comments posted by users. The zero value is the safe default:
+// data: URLs, which may embed arbitrary content, and
+// protocol-relative URLs like //host/path, which refer to other
+// hosts while looking like paths, are rejected. The fields
+// permit them selectively.
+type URLPolicy struct {
+	// If DataImages is set, images may use data: URLs with a
+	// raster image type, like data:image/png;base64,...;
+	// image/svg+xml is not permitted, as SVG may contain scripts.
+	DataImages bool
+
+	// If DataLinks is set, links may use data: URLs of any type.
+	DataLinks bool
+
+	// If ProtocolRelative is set, links and images may use
+	// protocol-relative URLs.
+	ProtocolRelative bool
+}
+
+/* allows - reports whether url may be used by a link, or an image
+ */
+func (pol *URLPolicy) allows(url string, image bool) bool {
+	u := strings.ToLower(trimURL(url))
+	switch {
+	case strings.HasPrefix(u, "data:"):
+		if image {
+			return pol.DataImages && isRasterDataURL(u)
+		}
+		return pol.DataLinks
+	case strings.HasPrefix(u, "//"), strings.HasPrefix(u, `\\`), strings.HasPrefix(u, `/\`), strings.HasPrefix(u, `\/`):
+		/* browsers treat backslashes like slashes */
+		return pol.ProtocolRelative
+	}
+	return true
+}
+
+/* trimURL - decodes character references, and removes whitespace
+ * and control characters browsers would ignore
+ */
+func trimURL(url string) string {
+	var b strings.Builder
+	for _, r := range html.UnescapeString(url) {
+		if r > ' ' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+/* isRasterDataURL - reports whether a lower-cased data: URL has
+ * a raster image type
+ */
+func isRasterDataURL(u string) bool {
+	typ := strings.TrimPrefix(u, "data:")
+	if i := strings.IndexAny(typ, ";,"); i != -1 {
+		typ = typ[:i]
+	}
+	switch typ {
+	case "image/png", "image/gif", "image/jpeg", "image/webp", "image/avif", "image/bmp":
+		return true
+	}
+	return false
+}
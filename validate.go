@@ -0,0 +1,126 @@
+package markdown
+
+// Checking combinations of Extensions.
+
+import (
+	"strings"
+)
+
+// An ExtensionError describes a combination of Extensions that is
+// contradictory, or contains settings without any effect. Options
+// lists the names of the fields involved, in the lower-case form
+// used by ParseExtensions.
+type ExtensionError struct {
+	Options []string
+	Reason  string
+}
+
+func (e *ExtensionError) Error() string {
+	return "extensions " + strings.Join(e.Options, ", ") + ": " + e.Reason
+}
+
+// ExtensionErrors is returned by Validate if more than one
+// problem has been found.
+type ExtensionErrors []*ExtensionError
+
+func (list ExtensionErrors) Error() string {
+	s := make([]string, len(list))
+	for i, e := range list {
+		s[i] = e.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// Validate checks x for settings that contradict each other, like
+// BlocksOnly together with Smart, or that would be ignored, like
+// Primes without Smart, or values out of range. NewParser accepts
+// any combination; applications taking extensions from a
+// configuration should call Validate to report mistakes early.
+// The result is nil, an *ExtensionError, or ExtensionErrors.
+func (x *Extensions) Validate() error {
+	var list ExtensionErrors
+	add := func(reason string, options ...string) {
+		list = append(list, &ExtensionError{Options: options, Reason: reason})
+	}
+
+	if !x.Smart {
+		for _, f := range []struct {
+			on   bool
+			name string
+		}{
+			{x.Primes, "primes"},
+			{x.Arrows, "arrows"},
+			{x.Symbols, "symbols"},
+			{x.Fractions, "fractions"},
+		} {
+			if f.on {
+				add("requires smart", f.name, "smart")
+			}
+		}
+	}
+	if x.BlocksOnly {
+		for _, f := range []struct {
+			on   bool
+			name string
+		}{
+			{x.Smart, "smart"},
+			{x.Notes, "notes"},
+			{x.Strike, "strike"},
+			{x.Index, "index"},
+			{x.Spoilers, "spoilers"},
+			{x.Tags, "tags"},
+			{x.Media, "media"},
+			{x.Examples, "examples"},
+			{x.Embeds != nil, "embeds"},
+			{x.NoIntraEmphasis, "nointraemphasis"},
+			{x.AutoRefs != nil, "autorefs"},
+		} {
+			if f.on {
+				add("inline syntax is not recognized with blocksonly", "blocksonly", f.name)
+			}
+		}
+	}
+	if x.FilterHTML {
+		for _, f := range []struct {
+			on   bool
+			name string
+		}{
+			{x.AllowedHTML != nil, "allowedhtml"},
+			{x.AllowedBlockHTML != nil, "allowedblockhtml"},
+			{x.FilterHTMLBlocks, "filterhtmlblocks"},
+		} {
+			if f.on {
+				add(f.name+" is ignored if filterhtml is set", f.name, "filterhtml")
+			}
+		}
+	}
+	if x.AllowedBlockHTML != nil && x.FilterHTMLBlocks {
+		add("allowedblockhtml is ignored if filterhtmlblocks is set", "allowedblockhtml", "filterhtmlblocks")
+	}
+	if x.ResolveTag != nil && !x.Tags {
+		add("resolvetag requires tags", "resolvetag", "tags")
+	}
+	if (x.AutoRefs == nil) != (x.AutoRefURL == nil) {
+		add("autorefs and autorefurl must be set together", "autorefs", "autorefurl")
+	}
+	if x.CodeTabs < KeepTabs {
+		add("invalid value", "codetabs")
+	}
+	if x.DupRefs < DupRefFirst || x.DupRefs > DupRefNone {
+		add("invalid value", "duprefs")
+	}
+	if x.DupNotes < DupRefFirst || x.DupNotes > DupRefNone {
+		add("invalid value", "dupnotes")
+	}
+	if x.Scripts < ScriptsDefault || x.Scripts > ScriptsCollect {
+		add("invalid value", "scripts")
+	}
+
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	}
+	return list
+}
@@ -0,0 +1,57 @@
+package markdown
+
+// Line wrapping for text-based writers.
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+/* Writers supporting a line width render the inlines of
+ * a paragraph first, marking spaces that may be turned into
+ * line breaks by breakMark; spaces within links or code spans
+ * remain unmarked. The result is then filled into lines.
+ */
+const breakMark = "\x00"
+
+/* fillLines - breaks text at break marks into lines not longer
+ * than width, if possible, where the first line starts at column
+ * col, and further lines at column indent, the width of a prefix
+ * added by the writer. Newlines contained in text are kept, and
+ * no space is inserted after them. A word for which
+ * keep returns true remains on the line of the preceding word,
+ * so that it is not interpreted as markup, like a list marker,
+ * at the beginning of a line.
+ */
+func fillLines(text string, col, indent, width int, keep func(word string) bool) string {
+	var b strings.Builder
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			b.WriteByte('\n')
+			col = indent
+		}
+		var words []string
+		for _, word := range strings.Split(line, breakMark) {
+			if n := len(words); n > 0 && (word == "" || words[n-1] == "" || keep(word)) {
+				words[n-1] += " " + word
+				continue
+			}
+			words = append(words, word)
+		}
+		for j, word := range words {
+			n := utf8.RuneCountInString(word)
+			if j > 0 {
+				if col+1+n > width {
+					b.WriteByte('\n')
+					col = indent
+				} else {
+					b.WriteByte(' ')
+					col++
+				}
+			}
+			b.WriteString(word)
+			col += n
+		}
+	}
+	return b.String()
+}